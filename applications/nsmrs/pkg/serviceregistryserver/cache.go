@@ -48,6 +48,7 @@ type NSERegistryCache interface {
 	UpdateNetworkServiceEndpoint(nse *registry.NSERegistration) (*registry.NSERegistration, error)
 	DeleteNetworkServiceEndpoint(endpointName string) (*registry.NSERegistration, error)
 	GetEndpoints(networkServiceName string) []*registry.NSERegistration
+	Watch(ctx context.Context) <-chan struct{}
 }
 
 type nseRegistryCache struct {
@@ -55,6 +56,7 @@ type nseRegistryCache struct {
 	networkServiceEndpoints map[string][]*registry.NSERegistration
 	endpoints               map[string]*registry.NSERegistration
 	nseExpirationTimeout    time.Duration
+	watchers                map[chan struct{}]struct{}
 }
 
 //NewNSERegistryCache creates new nerwork service endpoints cache
@@ -63,6 +65,7 @@ func NewNSERegistryCache() NSERegistryCache {
 		networkServiceEndpoints: make(map[string][]*registry.NSERegistration),
 		endpoints:               make(map[string]*registry.NSERegistration),
 		nseExpirationTimeout:    NSEExpirationTimeoutEnv.GetOrDefaultDuration(NSEExpirationTimeoutDefault),
+		watchers:                make(map[chan struct{}]struct{}),
 	}
 }
 
@@ -91,6 +94,7 @@ func (rc *nseRegistryCache) addNetworkServiceEndpoint(entry *registry.NSERegistr
 
 	rc.networkServiceEndpoints[entry.NetworkService.Name] = append(rc.networkServiceEndpoints[entry.NetworkService.Name], entry)
 	rc.endpoints[entry.NetworkServiceEndpoint.Name] = entry
+	rc.notifyWatchers()
 
 	logrus.Infof("Registered NSE entry %v", entry)
 
@@ -126,6 +130,7 @@ func (rc *nseRegistryCache) DeleteNetworkServiceEndpoint(endpointName string) (*
 			if endpointList[i].NetworkServiceEndpoint.Name == endpointName {
 				endpoint := endpointList[i]
 				rc.networkServiceEndpoints[networkService] = append(endpointList[:i], endpointList[i+1:]...)
+				rc.notifyWatchers()
 				return endpoint, nil
 			}
 		}
@@ -138,6 +143,31 @@ func (rc *nseRegistryCache) GetEndpoints(networkServiceName string) []*registry.
 	return rc.networkServiceEndpoints[networkServiceName]
 }
 
+// Watch - returns a channel signalled every time Endpoints are added to or removed from cache until ctx is done
+func (rc *nseRegistryCache) Watch(ctx context.Context) <-chan struct{} {
+	rc.Lock()
+	defer rc.Unlock()
+
+	watchCh := make(chan struct{}, 1)
+	rc.watchers[watchCh] = struct{}{}
+	go func() {
+		<-ctx.Done()
+		rc.Lock()
+		defer rc.Unlock()
+		delete(rc.watchers, watchCh)
+	}()
+	return watchCh
+}
+
+func (rc *nseRegistryCache) notifyWatchers() {
+	for watchCh := range rc.watchers {
+		select {
+		case watchCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
 // StartNSMDTracking - starts tracking NSMD expiration time to keep registry up to dated
 func StartNSMDTracking(ctx context.Context, rc *nseRegistryCache) {
 	span := spanhelper.FromContext(ctx, "NsmrsCache.StartNSMDTracking")
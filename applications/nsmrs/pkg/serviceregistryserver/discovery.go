@@ -21,6 +21,7 @@ import (
 
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
@@ -48,11 +49,51 @@ func (d *discoveryService) FindNetworkService(ctx context.Context, request *regi
 		return nil, err
 	}
 
+	response := newFindNetworkServiceResponse(request.NetworkServiceName, networkServiceEnpoints)
+	logger.Infof("FindNetworkService done: %v", response)
+
+	return response, nil
+}
+
+func (d *discoveryService) WatchNetworkService(request *registry.FindNetworkServiceRequest, stream registry.NetworkServiceDiscovery_WatchNetworkServiceServer) error {
+	span := spanhelper.FromContext(stream.Context(), "Nsmrs.WatchNetworkService")
+	defer span.Finish()
+	logger := span.Logger()
+
+	watchCh := d.cache.Watch(span.Context())
+	var last *registry.FindNetworkServiceResponse
+	for {
+		// Empty response is sent while there are no Endpoints of the Network Service
+		response := &registry.FindNetworkServiceResponse{}
+		if networkServiceEnpoints := d.cache.GetEndpoints(request.NetworkServiceName); len(networkServiceEnpoints) > 0 {
+			response = newFindNetworkServiceResponse(request.NetworkServiceName, networkServiceEnpoints)
+		}
+		if last == nil || !proto.Equal(last, response) {
+			logger.Infof("Sending Network Service %v update: %v", request.NetworkServiceName, response)
+			if err := stream.Send(response); err != nil {
+				return err
+			}
+			last = response
+		}
+
+		select {
+		case <-span.Context().Done():
+			return nil
+		case <-watchCh:
+		}
+	}
+}
+
+func newFindNetworkServiceResponse(networkServiceName string, networkServiceEnpoints []*registry.NSERegistration) *registry.FindNetworkServiceResponse {
 	response := &registry.FindNetworkServiceResponse{
 		NetworkService: &registry.NetworkService{
-			Name:    request.NetworkServiceName,
-			Payload: networkServiceEnpoints[0].NetworkService.Payload,
-			Matches: networkServiceEnpoints[0].NetworkService.Matches,
+			Name:              networkServiceName,
+			Payload:           networkServiceEnpoints[0].NetworkService.Payload,
+			Matches:           networkServiceEnpoints[0].NetworkService.Matches,
+			SelectionStrategy: networkServiceEnpoints[0].NetworkService.SelectionStrategy,
+			TopologyKeys:      networkServiceEnpoints[0].NetworkService.TopologyKeys,
+			AffinityLabels:    networkServiceEnpoints[0].NetworkService.AffinityLabels,
+			HealPolicy:        networkServiceEnpoints[0].NetworkService.HealPolicy,
 		},
 		NetworkServiceManagers: make(map[string]*registry.NetworkServiceManager),
 		Payload:                networkServiceEnpoints[0].NetworkService.Payload,
@@ -62,8 +103,5 @@ func (d *discoveryService) FindNetworkService(ctx context.Context, request *regi
 		response.NetworkServiceManagers[endpoint.NetworkServiceManager.Name] = endpoint.NetworkServiceManager
 		response.NetworkServiceEndpoints = append(response.NetworkServiceEndpoints, endpoint.NetworkServiceEndpoint)
 	}
-
-	logger.Infof("FindNetworkService done: %v", response)
-
-	return response, nil
+	return response
 }
@@ -26,6 +26,8 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 )
@@ -35,6 +37,7 @@ type NSERegistryService interface {
 	RegisterNSE(ctx context.Context, request *registry.NSERegistration) (*registry.NSERegistration, error)
 	BulkRegisterNSE(registry.NetworkServiceRegistry_BulkRegisterNSEServer) error
 	RemoveNSE(ctx context.Context, request *registry.RemoveNSERequest) (*empty.Empty, error)
+	DrainNSE(ctx context.Context, request *registry.DrainNSERequest) (*empty.Empty, error)
 }
 
 type nseRegistryService struct {
@@ -110,6 +113,11 @@ func (rs *nseRegistryService) RemoveNSE(ctx context.Context, request *registry.R
 	return &empty.Empty{}, nil
 }
 
+// DrainNSE - draining is not supported for interdomain endpoints
+func (rs *nseRegistryService) DrainNSE(ctx context.Context, request *registry.DrainNSERequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "draining of interdomain NSE %s is not supported", request.GetNetworkServiceEndpointName())
+}
+
 func prepareNSERequest(request *registry.NSERegistration) *registry.NSERegistration {
 	// Add public IP to NSM name to avoid name collision for different clusters
 	nsmName := fmt.Sprintf("%s_%s", request.NetworkServiceManager.Name, request.NetworkServiceManager.Url)
@@ -17,7 +17,9 @@
 package tests
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 
@@ -92,3 +94,25 @@ func TestNSMRSCacheNSCollision(t *testing.T) {
 	_, err = cache.AddNetworkServiceEndpoint(nse1clone)
 	g.Expect(err.Error()).To(ContainSubstring("network service already exists with different parameters"))
 }
+
+func TestNSMRSCacheWatch(t *testing.T) {
+	g := NewWithT(t)
+
+	cache := serviceregistryserver.NewNSERegistryCache()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchCh := cache.Watch(ctx)
+	g.Consistently(watchCh).ShouldNot(Receive())
+
+	_, err := cache.AddNetworkServiceEndpoint(newTestNse("nse1", "ns1"))
+	g.Expect(err).To(BeNil())
+	g.Eventually(watchCh, time.Second).Should(Receive())
+
+	_, err = cache.AddNetworkServiceEndpoint(newTestNse("nse1", "ns1"))
+	g.Expect(err).NotTo(BeNil())
+	g.Consistently(watchCh).ShouldNot(Receive())
+
+	_, err = cache.DeleteNetworkServiceEndpoint("nse1")
+	g.Expect(err).To(BeNil())
+	g.Eventually(watchCh, time.Second).Should(Receive())
+}
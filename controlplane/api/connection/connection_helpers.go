@@ -27,8 +27,8 @@ func (c *Connection) IsRemote() bool {
 	if c == nil {
 		return false
 	}
-	// If we have two or more, it is remote
-	return len(c.GetPath().GetPathSegments()) > 1
+	// If there is a segment after the current one, it is remote
+	return int(c.GetPath().GetIndex())+1 < len(c.GetPath().GetPathSegments())
 }
 
 // GetSourceNetworkServiceManagerName - return source network service manager name
@@ -36,8 +36,8 @@ func (c *Connection) GetSourceNetworkServiceManagerName() string {
 	if c == nil {
 		return ""
 	}
-	if len(c.GetPath().GetPathSegments()) > 0 {
-		return c.GetPath().GetPathSegments()[0].GetName()
+	if index := int(c.GetPath().GetIndex()); index < len(c.GetPath().GetPathSegments()) {
+		return c.GetPath().GetPathSegments()[index].GetName()
 	}
 	return ""
 }
@@ -47,8 +47,8 @@ func (c *Connection) GetDestinationNetworkServiceManagerName() string {
 	if c == nil {
 		return ""
 	}
-	if len(c.GetPath().GetPathSegments()) >= 2 {
-		return c.GetPath().GetPathSegments()[1].GetName()
+	if index := int(c.GetPath().GetIndex()); index+1 < len(c.GetPath().GetPathSegments()) {
+		return c.GetPath().GetPathSegments()[index+1].GetName()
 	}
 	return ""
 }
@@ -23,4 +23,6 @@ const (
 	PodNameKey = "podName"
 	// NamespaceKey - namespace a container is running in
 	NamespaceKey = "namespace"
+	// NodeNameKey - node a container is running on
+	NodeNameKey = "nodeName"
 )
@@ -35,10 +35,6 @@ type Mechanism interface {
 	SrcPublicKey() (string, error)
 	// DstPublicKey - destination public key
 	DstPublicKey() (string, error)
-	// SrcPrivateKey - source private key
-	SrcPrivateKey() (string, error)
-	// dstPrivateKey - destination private key
-	DstPrivateKey() (string, error)
 	// SrcPort - Source interface listening port
 	SrcPort() (int, error)
 	// SrcPort - Destination interface listening port
@@ -94,16 +90,6 @@ func (m *mechanism) DstPublicKey() (string, error) {
 	return m.stringValue(DstPublicKey)
 }
 
-// SrcPrivateKey returns the SrcPrivateKey parameter of the Mechanism
-func (m *mechanism) SrcPrivateKey() (string, error) {
-	return m.stringValue(SrcPrivateKey)
-}
-
-// DstPrivateKey returns the DstPrivateKey parameter of the Mechanism
-func (m *mechanism) DstPrivateKey() (string, error) {
-	return m.stringValue(DstPrivateKey)
-}
-
 // SrcPort - Source interface listening port
 func (m *mechanism) SrcPort() (int, error) {
 	srcPortStr, err := m.stringValue(SrcPort)
@@ -133,12 +119,3 @@ func (m *mechanism) DstPort() (int, error) {
 
 	return int(dstPort), nil
 }
-
-// AssignPort - generate unique port by connection ID for wireguard connection
-func AssignPort(connID string) string {
-	id, err := strconv.ParseUint(connID, 16, 64)
-	if err != nil {
-		id = 0
-	}
-	return strconv.FormatUint(BasePort+id, 10)
-}
@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	duration "github.com/golang/protobuf/ptypes/duration"
 	empty "github.com/golang/protobuf/ptypes/empty"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
@@ -27,12 +28,16 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type NetworkService struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Payload              string   `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
-	Matches              []*Match `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Name                 string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Payload              string      `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Matches              []*Match    `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	SelectionStrategy    string      `protobuf:"bytes,4,opt,name=selection_strategy,json=selectionStrategy,proto3" json:"selection_strategy,omitempty"`
+	TopologyKeys         []string    `protobuf:"bytes,5,rep,name=topology_keys,json=topologyKeys,proto3" json:"topology_keys,omitempty"`
+	AffinityLabels       []string    `protobuf:"bytes,6,rep,name=affinity_labels,json=affinityLabels,proto3" json:"affinity_labels,omitempty"`
+	HealPolicy           *HealPolicy `protobuf:"bytes,7,opt,name=heal_policy,json=healPolicy,proto3" json:"heal_policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *NetworkService) Reset()         { *m = NetworkService{} }
@@ -81,19 +86,137 @@ func (m *NetworkService) GetMatches() []*Match {
 	return nil
 }
 
+func (m *NetworkService) GetSelectionStrategy() string {
+	if m != nil {
+		return m.SelectionStrategy
+	}
+	return ""
+}
+
+func (m *NetworkService) GetTopologyKeys() []string {
+	if m != nil {
+		return m.TopologyKeys
+	}
+	return nil
+}
+
+func (m *NetworkService) GetAffinityLabels() []string {
+	if m != nil {
+		return m.AffinityLabels
+	}
+	return nil
+}
+
+func (m *NetworkService) GetHealPolicy() *HealPolicy {
+	if m != nil {
+		return m.HealPolicy
+	}
+	return nil
+}
+
+// HealPolicy overrides the default heal policy of NSMgr for the connections to the Network Service, unset fields keep the default values.
+// Endpoint is one of reselect, wait-same
+type HealPolicy struct {
+	InitialBackoff       *duration.Duration `protobuf:"bytes,1,opt,name=initial_backoff,json=initialBackoff,proto3" json:"initial_backoff,omitempty"`
+	MaxBackoff           *duration.Duration `protobuf:"bytes,2,opt,name=max_backoff,json=maxBackoff,proto3" json:"max_backoff,omitempty"`
+	BackoffMultiplier    float64            `protobuf:"fixed64,3,opt,name=backoff_multiplier,json=backoffMultiplier,proto3" json:"backoff_multiplier,omitempty"`
+	Jitter               float64            `protobuf:"fixed64,4,opt,name=jitter,proto3" json:"jitter,omitempty"`
+	MaxAttempts          uint32             `protobuf:"varint,5,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	Deadline             *duration.Duration `protobuf:"bytes,6,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Endpoint             string             `protobuf:"bytes,7,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *HealPolicy) Reset()         { *m = HealPolicy{} }
+func (m *HealPolicy) String() string { return proto.CompactTextString(m) }
+func (*HealPolicy) ProtoMessage()    {}
+func (*HealPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41af05d40a615591, []int{1}
+}
+
+func (m *HealPolicy) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealPolicy.Unmarshal(m, b)
+}
+func (m *HealPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealPolicy.Marshal(b, m, deterministic)
+}
+func (m *HealPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealPolicy.Merge(m, src)
+}
+func (m *HealPolicy) XXX_Size() int {
+	return xxx_messageInfo_HealPolicy.Size(m)
+}
+func (m *HealPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealPolicy proto.InternalMessageInfo
+
+func (m *HealPolicy) GetInitialBackoff() *duration.Duration {
+	if m != nil {
+		return m.InitialBackoff
+	}
+	return nil
+}
+
+func (m *HealPolicy) GetMaxBackoff() *duration.Duration {
+	if m != nil {
+		return m.MaxBackoff
+	}
+	return nil
+}
+
+func (m *HealPolicy) GetBackoffMultiplier() float64 {
+	if m != nil {
+		return m.BackoffMultiplier
+	}
+	return 0
+}
+
+func (m *HealPolicy) GetJitter() float64 {
+	if m != nil {
+		return m.Jitter
+	}
+	return 0
+}
+
+func (m *HealPolicy) GetMaxAttempts() uint32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *HealPolicy) GetDeadline() *duration.Duration {
+	if m != nil {
+		return m.Deadline
+	}
+	return nil
+}
+
+func (m *HealPolicy) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
 type Match struct {
-	SourceSelector       map[string]string `protobuf:"bytes,1,rep,name=source_selector,json=sourceSelector,proto3" json:"source_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Routes               []*Destination    `protobuf:"bytes,2,rep,name=routes,proto3" json:"routes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	SourceSelector         map[string]string           `protobuf:"bytes,1,rep,name=source_selector,json=sourceSelector,proto3" json:"source_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Routes                 []*Destination              `protobuf:"bytes,2,rep,name=routes,proto3" json:"routes,omitempty"`
+	SourceMatchExpressions []*LabelSelectorRequirement `protobuf:"bytes,3,rep,name=source_match_expressions,json=sourceMatchExpressions,proto3" json:"source_match_expressions,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}                    `json:"-"`
+	XXX_unrecognized       []byte                      `json:"-"`
+	XXX_sizecache          int32                       `json:"-"`
 }
 
 func (m *Match) Reset()         { *m = Match{} }
 func (m *Match) String() string { return proto.CompactTextString(m) }
 func (*Match) ProtoMessage()    {}
 func (*Match) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{1}
+	return fileDescriptor_41af05d40a615591, []int{2}
 }
 
 func (m *Match) XXX_Unmarshal(b []byte) error {
@@ -128,19 +251,27 @@ func (m *Match) GetRoutes() []*Destination {
 	return nil
 }
 
+func (m *Match) GetSourceMatchExpressions() []*LabelSelectorRequirement {
+	if m != nil {
+		return m.SourceMatchExpressions
+	}
+	return nil
+}
+
 type Destination struct {
-	DestinationSelector  map[string]string `protobuf:"bytes,1,rep,name=destination_selector,json=destinationSelector,proto3" json:"destination_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Weight               uint32            `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	DestinationSelector         map[string]string           `protobuf:"bytes,1,rep,name=destination_selector,json=destinationSelector,proto3" json:"destination_selector,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Weight                      uint32                      `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	DestinationMatchExpressions []*LabelSelectorRequirement `protobuf:"bytes,3,rep,name=destination_match_expressions,json=destinationMatchExpressions,proto3" json:"destination_match_expressions,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{}                    `json:"-"`
+	XXX_unrecognized            []byte                      `json:"-"`
+	XXX_sizecache               int32                       `json:"-"`
 }
 
 func (m *Destination) Reset()         { *m = Destination{} }
 func (m *Destination) String() string { return proto.CompactTextString(m) }
 func (*Destination) ProtoMessage()    {}
 func (*Destination) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{2}
+	return fileDescriptor_41af05d40a615591, []int{3}
 }
 
 func (m *Destination) XXX_Unmarshal(b []byte) error {
@@ -175,11 +306,75 @@ func (m *Destination) GetWeight() uint32 {
 	return 0
 }
 
+func (m *Destination) GetDestinationMatchExpressions() []*LabelSelectorRequirement {
+	if m != nil {
+		return m.DestinationMatchExpressions
+	}
+	return nil
+}
+
+// LabelSelectorRequirement is a Kubernetes-style selector requirement, operator is one of In, NotIn, Exists, DoesNotExist
+type LabelSelectorRequirement struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operator             string   `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	Values               []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LabelSelectorRequirement) Reset()         { *m = LabelSelectorRequirement{} }
+func (m *LabelSelectorRequirement) String() string { return proto.CompactTextString(m) }
+func (*LabelSelectorRequirement) ProtoMessage()    {}
+func (*LabelSelectorRequirement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41af05d40a615591, []int{4}
+}
+
+func (m *LabelSelectorRequirement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LabelSelectorRequirement.Unmarshal(m, b)
+}
+func (m *LabelSelectorRequirement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LabelSelectorRequirement.Marshal(b, m, deterministic)
+}
+func (m *LabelSelectorRequirement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelSelectorRequirement.Merge(m, src)
+}
+func (m *LabelSelectorRequirement) XXX_Size() int {
+	return xxx_messageInfo_LabelSelectorRequirement.Size(m)
+}
+func (m *LabelSelectorRequirement) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelSelectorRequirement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelSelectorRequirement proto.InternalMessageInfo
+
+func (m *LabelSelectorRequirement) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *LabelSelectorRequirement) GetOperator() string {
+	if m != nil {
+		return m.Operator
+	}
+	return ""
+}
+
+func (m *LabelSelectorRequirement) GetValues() []string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 type NetworkServiceManager struct {
 	Name                 string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Url                  string               `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
 	ExpirationTime       *timestamp.Timestamp `protobuf:"bytes,3,opt,name=expiration_time,json=expirationTime,proto3" json:"expiration_time,omitempty"`
 	State                string               `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	Labels               map[string]string    `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -189,7 +384,7 @@ func (m *NetworkServiceManager) Reset()         { *m = NetworkServiceManager{} }
 func (m *NetworkServiceManager) String() string { return proto.CompactTextString(m) }
 func (*NetworkServiceManager) ProtoMessage()    {}
 func (*NetworkServiceManager) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{3}
+	return fileDescriptor_41af05d40a615591, []int{5}
 }
 
 func (m *NetworkServiceManager) XXX_Unmarshal(b []byte) error {
@@ -238,6 +433,13 @@ func (m *NetworkServiceManager) GetState() string {
 	return ""
 }
 
+func (m *NetworkServiceManager) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
 type NetworkServiceEndpoint struct {
 	Name                      string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Payload                   string            `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
@@ -245,16 +447,18 @@ type NetworkServiceEndpoint struct {
 	NetworkServiceManagerName string            `protobuf:"bytes,4,opt,name=network_service_manager_name,json=networkServiceManagerName,proto3" json:"network_service_manager_name,omitempty"`
 	Labels                    map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	State                     string            `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
-	XXX_NoUnkeyedLiteral      struct{}          `json:"-"`
-	XXX_unrecognized          []byte            `json:"-"`
-	XXX_sizecache             int32             `json:"-"`
+	// Maximum number of connections the endpoint serves across all NSMs, 0 means unlimited
+	MaxConnections       uint32   `protobuf:"varint,7,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *NetworkServiceEndpoint) Reset()         { *m = NetworkServiceEndpoint{} }
 func (m *NetworkServiceEndpoint) String() string { return proto.CompactTextString(m) }
 func (*NetworkServiceEndpoint) ProtoMessage()    {}
 func (*NetworkServiceEndpoint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{4}
+	return fileDescriptor_41af05d40a615591, []int{6}
 }
 
 func (m *NetworkServiceEndpoint) XXX_Unmarshal(b []byte) error {
@@ -317,6 +521,13 @@ func (m *NetworkServiceEndpoint) GetState() string {
 	return ""
 }
 
+func (m *NetworkServiceEndpoint) GetMaxConnections() uint32 {
+	if m != nil {
+		return m.MaxConnections
+	}
+	return 0
+}
+
 type FindNetworkServiceRequest struct {
 	NetworkServiceName   string   `protobuf:"bytes,1,opt,name=network_service_name,json=networkServiceName,proto3" json:"network_service_name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -328,7 +539,7 @@ func (m *FindNetworkServiceRequest) Reset()         { *m = FindNetworkServiceReq
 func (m *FindNetworkServiceRequest) String() string { return proto.CompactTextString(m) }
 func (*FindNetworkServiceRequest) ProtoMessage()    {}
 func (*FindNetworkServiceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{5}
+	return fileDescriptor_41af05d40a615591, []int{7}
 }
 
 func (m *FindNetworkServiceRequest) XXX_Unmarshal(b []byte) error {
@@ -370,7 +581,7 @@ func (m *FindNetworkServiceResponse) Reset()         { *m = FindNetworkServiceRe
 func (m *FindNetworkServiceResponse) String() string { return proto.CompactTextString(m) }
 func (*FindNetworkServiceResponse) ProtoMessage()    {}
 func (*FindNetworkServiceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{6}
+	return fileDescriptor_41af05d40a615591, []int{8}
 }
 
 func (m *FindNetworkServiceResponse) XXX_Unmarshal(b []byte) error {
@@ -432,7 +643,7 @@ func (m *NSERegistration) Reset()         { *m = NSERegistration{} }
 func (m *NSERegistration) String() string { return proto.CompactTextString(m) }
 func (*NSERegistration) ProtoMessage()    {}
 func (*NSERegistration) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{7}
+	return fileDescriptor_41af05d40a615591, []int{9}
 }
 
 func (m *NSERegistration) XXX_Unmarshal(b []byte) error {
@@ -485,7 +696,7 @@ func (m *RemoveNSERequest) Reset()         { *m = RemoveNSERequest{} }
 func (m *RemoveNSERequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveNSERequest) ProtoMessage()    {}
 func (*RemoveNSERequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{8}
+	return fileDescriptor_41af05d40a615591, []int{10}
 }
 
 func (m *RemoveNSERequest) XXX_Unmarshal(b []byte) error {
@@ -513,6 +724,45 @@ func (m *RemoveNSERequest) GetNetworkServiceEndpointName() string {
 	return ""
 }
 
+type DrainNSERequest struct {
+	NetworkServiceEndpointName string   `protobuf:"bytes,1,opt,name=network_service_endpoint_name,json=networkServiceEndpointName,proto3" json:"network_service_endpoint_name,omitempty"`
+	XXX_NoUnkeyedLiteral       struct{} `json:"-"`
+	XXX_unrecognized           []byte   `json:"-"`
+	XXX_sizecache              int32    `json:"-"`
+}
+
+func (m *DrainNSERequest) Reset()         { *m = DrainNSERequest{} }
+func (m *DrainNSERequest) String() string { return proto.CompactTextString(m) }
+func (*DrainNSERequest) ProtoMessage()    {}
+func (*DrainNSERequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_41af05d40a615591, []int{11}
+}
+
+func (m *DrainNSERequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DrainNSERequest.Unmarshal(m, b)
+}
+func (m *DrainNSERequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DrainNSERequest.Marshal(b, m, deterministic)
+}
+func (m *DrainNSERequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainNSERequest.Merge(m, src)
+}
+func (m *DrainNSERequest) XXX_Size() int {
+	return xxx_messageInfo_DrainNSERequest.Size(m)
+}
+func (m *DrainNSERequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainNSERequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainNSERequest proto.InternalMessageInfo
+
+func (m *DrainNSERequest) GetNetworkServiceEndpointName() string {
+	if m != nil {
+		return m.NetworkServiceEndpointName
+	}
+	return ""
+}
+
 type NetworkServiceEndpointList struct {
 	NetworkServiceEndpoints []*NetworkServiceEndpoint `protobuf:"bytes,1,rep,name=network_service_endpoints,json=networkServiceEndpoints,proto3" json:"network_service_endpoints,omitempty"`
 	XXX_NoUnkeyedLiteral    struct{}                  `json:"-"`
@@ -524,7 +774,7 @@ func (m *NetworkServiceEndpointList) Reset()         { *m = NetworkServiceEndpoi
 func (m *NetworkServiceEndpointList) String() string { return proto.CompactTextString(m) }
 func (*NetworkServiceEndpointList) ProtoMessage()    {}
 func (*NetworkServiceEndpointList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_41af05d40a615591, []int{9}
+	return fileDescriptor_41af05d40a615591, []int{12}
 }
 
 func (m *NetworkServiceEndpointList) XXX_Unmarshal(b []byte) error {
@@ -554,11 +804,14 @@ func (m *NetworkServiceEndpointList) GetNetworkServiceEndpoints() []*NetworkServ
 
 func init() {
 	proto.RegisterType((*NetworkService)(nil), "registry.NetworkService")
+	proto.RegisterType((*HealPolicy)(nil), "registry.HealPolicy")
 	proto.RegisterType((*Match)(nil), "registry.Match")
 	proto.RegisterMapType((map[string]string)(nil), "registry.Match.SourceSelectorEntry")
 	proto.RegisterType((*Destination)(nil), "registry.Destination")
 	proto.RegisterMapType((map[string]string)(nil), "registry.Destination.DestinationSelectorEntry")
+	proto.RegisterType((*LabelSelectorRequirement)(nil), "registry.LabelSelectorRequirement")
 	proto.RegisterType((*NetworkServiceManager)(nil), "registry.NetworkServiceManager")
+	proto.RegisterMapType((map[string]string)(nil), "registry.NetworkServiceManager.LabelsEntry")
 	proto.RegisterType((*NetworkServiceEndpoint)(nil), "registry.NetworkServiceEndpoint")
 	proto.RegisterMapType((map[string]string)(nil), "registry.NetworkServiceEndpoint.LabelsEntry")
 	proto.RegisterType((*FindNetworkServiceRequest)(nil), "registry.FindNetworkServiceRequest")
@@ -566,64 +819,90 @@ func init() {
 	proto.RegisterMapType((map[string]*NetworkServiceManager)(nil), "registry.FindNetworkServiceResponse.NetworkServiceManagersEntry")
 	proto.RegisterType((*NSERegistration)(nil), "registry.NSERegistration")
 	proto.RegisterType((*RemoveNSERequest)(nil), "registry.RemoveNSERequest")
+	proto.RegisterType((*DrainNSERequest)(nil), "registry.DrainNSERequest")
 	proto.RegisterType((*NetworkServiceEndpointList)(nil), "registry.NetworkServiceEndpointList")
 }
 
 func init() { proto.RegisterFile("registry.proto", fileDescriptor_41af05d40a615591) }
 
 var fileDescriptor_41af05d40a615591 = []byte{
-	// 811 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4d, 0x6e, 0xd3, 0x40,
-	0x14, 0xd6, 0x24, 0x6d, 0x4a, 0x9f, 0x21, 0xa9, 0xa6, 0x6d, 0xea, 0x18, 0x10, 0x51, 0xca, 0x22,
-	0x48, 0x60, 0xaa, 0x20, 0x24, 0x60, 0x53, 0x4a, 0x9b, 0xb2, 0x20, 0x09, 0x92, 0x03, 0x42, 0x42,
-	0x48, 0x91, 0x9b, 0x0c, 0xa9, 0xa9, 0xff, 0xf0, 0x4c, 0x52, 0xdc, 0x1b, 0x70, 0x03, 0x0e, 0xc1,
-	0x1d, 0x58, 0xb2, 0xed, 0x3d, 0xb8, 0x04, 0xf2, 0x8c, 0x13, 0xdb, 0x89, 0xdd, 0x34, 0xea, 0xa6,
-	0x9a, 0x9f, 0xf7, 0xbe, 0xf7, 0xe6, 0xfb, 0x3e, 0xbf, 0x06, 0x8a, 0x1e, 0x19, 0x1a, 0x94, 0x79,
-	0xbe, 0xea, 0x7a, 0x0e, 0x73, 0xf0, 0xad, 0xc9, 0x5e, 0x91, 0x5d, 0xe6, 0xbb, 0x84, 0x3e, 0x25,
-	0x96, 0xcb, 0x7c, 0xf1, 0x57, 0xc4, 0x28, 0xd5, 0xf0, 0x86, 0x19, 0x16, 0xa1, 0x4c, 0xb7, 0xdc,
-	0x68, 0x25, 0x22, 0x6a, 0x06, 0x14, 0x3b, 0x84, 0x9d, 0x3b, 0xde, 0x59, 0x97, 0x78, 0x63, 0xa3,
-	0x4f, 0x30, 0x86, 0x15, 0x5b, 0xb7, 0x88, 0x8c, 0xaa, 0xa8, 0xbe, 0xae, 0xf1, 0x35, 0x96, 0x61,
-	0xcd, 0xd5, 0x7d, 0xd3, 0xd1, 0x07, 0x72, 0x8e, 0x1f, 0x4f, 0xb6, 0xf8, 0x11, 0xac, 0x59, 0x3a,
-	0xeb, 0x9f, 0x12, 0x2a, 0xe7, 0xab, 0xf9, 0xba, 0xd4, 0x28, 0xa9, 0xd3, 0x3e, 0xdb, 0xc1, 0x85,
-	0x36, 0xb9, 0xaf, 0xfd, 0x45, 0xb0, 0xca, 0x8f, 0x70, 0x0b, 0x4a, 0xd4, 0x19, 0x79, 0x7d, 0xd2,
-	0xa3, 0xc4, 0x24, 0x7d, 0xe6, 0x78, 0x32, 0xe2, 0xc9, 0xbb, 0x33, 0xc9, 0x6a, 0x97, 0x87, 0x75,
-	0xc3, 0xa8, 0xa6, 0xcd, 0x3c, 0x5f, 0x2b, 0xd2, 0xc4, 0x21, 0x7e, 0x02, 0x05, 0xcf, 0x19, 0x31,
-	0x42, 0xe5, 0x1c, 0x07, 0xd9, 0x8e, 0x40, 0x8e, 0x08, 0x65, 0x86, 0xad, 0x33, 0xc3, 0xb1, 0xb5,
-	0x30, 0x48, 0x39, 0x80, 0xcd, 0x14, 0x54, 0xbc, 0x01, 0xf9, 0x33, 0xe2, 0x87, 0xaf, 0x0e, 0x96,
-	0x78, 0x0b, 0x56, 0xc7, 0xba, 0x39, 0x22, 0xe1, 0x93, 0xc5, 0xe6, 0x55, 0xee, 0x05, 0xaa, 0x5d,
-	0x22, 0x90, 0x62, 0xd0, 0x58, 0x87, 0xad, 0x41, 0xb4, 0x9d, 0x7d, 0x94, 0x9a, 0xda, 0x4f, 0x7c,
-	0x9d, 0x7c, 0xdf, 0xe6, 0x60, 0xfe, 0x06, 0x97, 0xa1, 0x70, 0x4e, 0x8c, 0xe1, 0x29, 0xe3, 0xdd,
-	0xdc, 0xd1, 0xc2, 0x9d, 0x72, 0x0c, 0x72, 0x16, 0xd0, 0x52, 0x4f, 0xfa, 0x85, 0x60, 0x3b, 0x69,
-	0x84, 0xb6, 0x6e, 0xeb, 0x43, 0xe2, 0xa5, 0xfa, 0x61, 0x03, 0xf2, 0x23, 0xcf, 0x0c, 0x51, 0x82,
-	0x25, 0x3e, 0x84, 0x12, 0xf9, 0xe1, 0x1a, 0x9e, 0x60, 0x20, 0x70, 0x99, 0x9c, 0xaf, 0xa2, 0xba,
-	0xd4, 0x50, 0xd4, 0xa1, 0xe3, 0x0c, 0x4d, 0x22, 0xfc, 0x76, 0x32, 0xfa, 0xaa, 0x7e, 0x98, 0x58,
-	0x50, 0x2b, 0x46, 0x29, 0xc1, 0x61, 0xd0, 0x1e, 0x65, 0x3a, 0x23, 0xf2, 0x8a, 0x68, 0x8f, 0x6f,
-	0x6a, 0x97, 0x39, 0x28, 0x27, 0x5b, 0x6b, 0xda, 0x03, 0xd7, 0x31, 0x6c, 0xb6, 0xa4, 0x57, 0xf7,
-	0x60, 0xcb, 0x16, 0x38, 0x3d, 0x2a, 0x80, 0x7a, 0x3c, 0x3b, 0xcf, 0xc3, 0xb0, 0x9d, 0xa8, 0xd1,
-	0x09, 0xb0, 0xf6, 0xe1, 0xde, 0x6c, 0x86, 0x25, 0x68, 0x11, 0x99, 0xa2, 0xcf, 0x8a, 0x9d, 0x46,
-	0x1c, 0x07, 0x38, 0x82, 0x82, 0xa9, 0x9f, 0x10, 0x93, 0xca, 0xab, 0xdc, 0x0b, 0x8f, 0x23, 0x2f,
-	0xa4, 0x3f, 0x49, 0x6d, 0xf1, 0x70, 0xe1, 0x84, 0x30, 0x37, 0xe2, 0xa5, 0x10, 0xe3, 0x45, 0x79,
-	0x09, 0x52, 0x2c, 0x78, 0x29, 0xb5, 0xdb, 0x50, 0x39, 0x36, 0xec, 0x41, 0xb2, 0x05, 0x8d, 0x7c,
-	0x1f, 0x11, 0xca, 0x32, 0x69, 0x42, 0x59, 0x34, 0xd5, 0xfe, 0xe4, 0x41, 0x49, 0xc3, 0xa3, 0xae,
-	0x63, 0xd3, 0x84, 0x22, 0x28, 0xa9, 0xc8, 0x01, 0x94, 0x66, 0x4a, 0xf1, 0x5e, 0xa5, 0x86, 0x9c,
-	0xc5, 0x93, 0x56, 0x4c, 0xd6, 0xc7, 0x17, 0x20, 0x67, 0x48, 0x34, 0x99, 0x48, 0xaf, 0x23, 0xac,
-	0xec, 0x26, 0xd5, 0x54, 0xf3, 0x87, 0x3a, 0x94, 0x53, 0x05, 0xa6, 0xf8, 0x0b, 0x54, 0x66, 0x6b,
-	0x93, 0x50, 0x47, 0x2a, 0xaf, 0xf0, 0xe2, 0xd5, 0x45, 0x82, 0x6b, 0x3b, 0x76, 0xea, 0x39, 0x55,
-	0xbe, 0xc1, 0xdd, 0x2b, 0x9a, 0x4a, 0xd1, 0xfb, 0x79, 0x5c, 0x6f, 0xa9, 0xf1, 0x20, 0xab, 0x74,
-	0x88, 0x13, 0x37, 0xc4, 0xcf, 0x1c, 0x94, 0x3a, 0xdd, 0xa6, 0x26, 0x12, 0xc4, 0x54, 0x4b, 0x11,
-	0x07, 0x2d, 0x29, 0xce, 0x27, 0xd8, 0xc9, 0x10, 0xe7, 0xba, 0x3d, 0x6e, 0xa7, 0x52, 0x8f, 0x3f,
-	0xcf, 0xab, 0x3e, 0x61, 0x3e, 0x9c, 0x3b, 0x8b, 0x89, 0x2f, 0xa7, 0x13, 0x5f, 0xfb, 0x08, 0x1b,
-	0x1a, 0xb1, 0x9c, 0x31, 0xe1, 0x84, 0x88, 0x6f, 0xe2, 0x00, 0xee, 0x67, 0xd5, 0x8b, 0x7f, 0x1c,
-	0x4a, 0x3a, 0x24, 0xff, 0x48, 0x2e, 0x40, 0x49, 0x6f, 0xa4, 0x65, 0x50, 0x76, 0xb5, 0x95, 0xd0,
-	0x0d, 0xad, 0xd4, 0xf8, 0x87, 0x66, 0x47, 0x68, 0xa8, 0xb4, 0x8f, 0x0f, 0x41, 0x12, 0x6b, 0xe2,
-	0x75, 0xba, 0x4d, 0x5c, 0x89, 0x15, 0x49, 0xfa, 0x41, 0xc9, 0xbe, 0xc2, 0xef, 0xa0, 0xf4, 0x66,
-	0x64, 0x9e, 0xdd, 0x18, 0xa8, 0x8e, 0xf6, 0x10, 0xde, 0x87, 0xf5, 0x29, 0xff, 0x58, 0x89, 0x62,
-	0x67, 0x45, 0x51, 0xca, 0x73, 0xff, 0x5a, 0x9a, 0xc1, 0x6f, 0x9f, 0xc6, 0x05, 0xec, 0x24, 0x1f,
-	0x7b, 0x64, 0xd0, 0xbe, 0x33, 0x26, 0x9e, 0x8f, 0x7b, 0x80, 0xe7, 0x67, 0x00, 0xde, 0xbd, 0x7a,
-	0x42, 0x88, 0x6a, 0x0f, 0xaf, 0x33, 0x46, 0x1a, 0xbf, 0x11, 0x48, 0x1d, 0x6a, 0x4d, 0xe9, 0x7d,
-	0x1f, 0xa7, 0xb7, 0x8d, 0x17, 0xf9, 0x5d, 0x59, 0x14, 0x80, 0x5b, 0x70, 0xfb, 0x2d, 0x61, 0x53,
-	0x69, 0x71, 0x06, 0x09, 0xf1, 0x76, 0xb3, 0x6d, 0x77, 0x52, 0xe0, 0x59, 0xcf, 0xfe, 0x07, 0x00,
-	0x00, 0xff, 0xff, 0x10, 0xd8, 0x69, 0xfe, 0x5d, 0x0a, 0x00, 0x00,
+	// 1206 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x6e, 0x1b, 0x45,
+	0x14, 0xd6, 0xda, 0x8d, 0x1b, 0x1f, 0x37, 0x76, 0x3a, 0x49, 0x9c, 0xcd, 0x96, 0x82, 0x71, 0x2a,
+	0xd5, 0x08, 0x62, 0x22, 0xa3, 0x48, 0x50, 0x09, 0x95, 0xfc, 0xb8, 0x20, 0x35, 0x09, 0x68, 0x5d,
+	0x54, 0x09, 0x21, 0x2d, 0x13, 0x7b, 0xec, 0x6c, 0xb3, 0x7f, 0xec, 0x8c, 0xd3, 0x6c, 0xdf, 0x80,
+	0x37, 0xe0, 0x86, 0x4b, 0x6e, 0x10, 0x0f, 0xc0, 0x1d, 0xd7, 0xbc, 0x04, 0xef, 0xc1, 0x1d, 0xda,
+	0x99, 0xd9, 0x3f, 0x7b, 0x37, 0x6e, 0x14, 0xb8, 0x89, 0x66, 0xe6, 0xcc, 0xf9, 0xce, 0xd9, 0xef,
+	0x7c, 0xe7, 0x4c, 0x0c, 0x75, 0x9f, 0x4c, 0x4c, 0xca, 0xfc, 0xa0, 0xeb, 0xf9, 0x2e, 0x73, 0xd1,
+	0x72, 0xb4, 0xd7, 0xde, 0xf5, 0x58, 0xe0, 0x11, 0xfa, 0xf1, 0x68, 0xea, 0x63, 0x66, 0xba, 0x4e,
+	0xbc, 0x10, 0x37, 0x35, 0x55, 0xda, 0x89, 0xed, 0xb1, 0x40, 0xfc, 0x95, 0x96, 0x96, 0xb4, 0x30,
+	0xd3, 0x26, 0x94, 0x61, 0xdb, 0x4b, 0x56, 0xe2, 0x46, 0xfb, 0x97, 0x12, 0xd4, 0x4f, 0x09, 0x7b,
+	0xed, 0xfa, 0x17, 0x03, 0xe2, 0x5f, 0x9a, 0x43, 0x82, 0x10, 0xdc, 0x71, 0xb0, 0x4d, 0x54, 0xa5,
+	0xa5, 0x74, 0xaa, 0x3a, 0x5f, 0x23, 0x15, 0xee, 0x7a, 0x38, 0xb0, 0x5c, 0x3c, 0x52, 0x4b, 0xfc,
+	0x38, 0xda, 0xa2, 0x0f, 0xe0, 0xae, 0x8d, 0xd9, 0xf0, 0x9c, 0x50, 0xb5, 0xdc, 0x2a, 0x77, 0x6a,
+	0xbd, 0x46, 0x37, 0xfe, 0x90, 0x93, 0xd0, 0xa0, 0x47, 0x76, 0xb4, 0x03, 0x88, 0x12, 0x8b, 0x0c,
+	0xc3, 0xd4, 0x0d, 0xca, 0x7c, 0xcc, 0xc8, 0x24, 0x50, 0xef, 0x70, 0xbc, 0xfb, 0xb1, 0x65, 0x20,
+	0x0d, 0x68, 0x1b, 0x56, 0x98, 0xeb, 0xb9, 0x96, 0x3b, 0x09, 0x8c, 0x0b, 0x12, 0x50, 0x75, 0xa9,
+	0x55, 0xee, 0x54, 0xf5, 0x7b, 0xd1, 0xe1, 0x73, 0x12, 0x50, 0xf4, 0x18, 0x1a, 0x78, 0x3c, 0x36,
+	0x1d, 0x93, 0x05, 0x86, 0x85, 0xcf, 0x88, 0x45, 0xd5, 0x0a, 0xbf, 0x56, 0x8f, 0x8e, 0x8f, 0xf9,
+	0x29, 0xda, 0x83, 0xda, 0x39, 0xc1, 0x96, 0xe1, 0xb9, 0x96, 0x39, 0x0c, 0xd4, 0xbb, 0x2d, 0xa5,
+	0x53, 0xeb, 0xad, 0x27, 0xb9, 0x7e, 0x45, 0xb0, 0xf5, 0x0d, 0xb7, 0xe9, 0x70, 0x1e, 0xaf, 0xdb,
+	0x7f, 0x95, 0x00, 0x12, 0x13, 0x3a, 0x80, 0x46, 0x08, 0x6a, 0x62, 0xcb, 0x38, 0xc3, 0xc3, 0x0b,
+	0x77, 0x3c, 0xe6, 0x34, 0xd5, 0x7a, 0x5b, 0xdd, 0x89, 0xeb, 0x4e, 0x2c, 0x22, 0x68, 0x3d, 0x9b,
+	0x8e, 0xbb, 0x47, 0xb2, 0x48, 0x7a, 0x5d, 0x7a, 0x1c, 0x08, 0x07, 0xf4, 0x04, 0x6a, 0x36, 0xbe,
+	0x8a, 0xfd, 0x4b, 0x8b, 0xfc, 0xc1, 0xc6, 0x57, 0x91, 0xef, 0x0e, 0x20, 0xe9, 0x67, 0xd8, 0x53,
+	0x8b, 0x99, 0x9e, 0x65, 0x12, 0x5f, 0x2d, 0xb7, 0x94, 0x8e, 0xa2, 0xdf, 0x97, 0x96, 0x93, 0xd8,
+	0x80, 0x9a, 0x50, 0x79, 0x65, 0x32, 0x46, 0x7c, 0xce, 0xb2, 0xa2, 0xcb, 0x1d, 0x7a, 0x1f, 0xee,
+	0x85, 0x29, 0x60, 0xc6, 0x42, 0xb5, 0x84, 0xcc, 0x2a, 0x9d, 0x15, 0x3d, 0x4c, 0x6b, 0x5f, 0x1e,
+	0xa1, 0x3d, 0x58, 0x1e, 0x11, 0x3c, 0xb2, 0x4c, 0x87, 0xa8, 0x95, 0x45, 0x29, 0xc6, 0x57, 0x91,
+	0x06, 0xcb, 0xc4, 0x19, 0x79, 0xae, 0xe9, 0x30, 0xce, 0x71, 0x55, 0x8f, 0xf7, 0xed, 0x5f, 0x4b,
+	0xb0, 0xc4, 0x25, 0x81, 0x8e, 0xa1, 0x41, 0xdd, 0xa9, 0x3f, 0x24, 0x86, 0x28, 0xbb, 0xeb, 0xab,
+	0x0a, 0x17, 0xcf, 0xf6, 0x8c, 0x78, 0xba, 0x03, 0x7e, 0x6d, 0x20, 0x6f, 0xf5, 0x1d, 0xe6, 0x07,
+	0x7a, 0x9d, 0x66, 0x0e, 0xd1, 0x0e, 0x54, 0x7c, 0x77, 0xca, 0x08, 0x55, 0x4b, 0x1c, 0x64, 0x23,
+	0x01, 0x39, 0x22, 0x94, 0x99, 0x8e, 0x48, 0x52, 0x5e, 0x42, 0xdf, 0x83, 0x2a, 0x83, 0x73, 0x61,
+	0x1a, 0xe4, 0xca, 0xf3, 0x09, 0xa5, 0xa6, 0xeb, 0x44, 0x12, 0x6e, 0x27, 0x00, 0x5c, 0x3d, 0x51,
+	0x24, 0x9d, 0xfc, 0x38, 0x35, 0x7d, 0x62, 0x13, 0x87, 0xe9, 0x4d, 0x81, 0xc1, 0xb3, 0xec, 0x27,
+	0x08, 0xda, 0x3e, 0xac, 0xe5, 0xe4, 0x8c, 0x56, 0xa1, 0x7c, 0x41, 0x02, 0xd9, 0x53, 0xe1, 0x12,
+	0xad, 0xc3, 0xd2, 0x25, 0xb6, 0xa6, 0x44, 0x36, 0x94, 0xd8, 0x3c, 0x29, 0x7d, 0xaa, 0xb4, 0xff,
+	0x28, 0x41, 0x2d, 0x95, 0x38, 0xc2, 0xb0, 0x3e, 0x4a, 0xb6, 0xb3, 0x94, 0x75, 0x73, 0xbf, 0x36,
+	0xbd, 0xce, 0xb2, 0xb7, 0x36, 0x9a, 0xb7, 0x84, 0x42, 0x79, 0x4d, 0xcc, 0xc9, 0x39, 0xe3, 0xd9,
+	0xac, 0xe8, 0x72, 0x87, 0xc6, 0xf0, 0x30, 0x1d, 0xfa, 0x36, 0x84, 0x3d, 0x48, 0x01, 0xcd, 0xb1,
+	0xf6, 0x0c, 0xd4, 0xa2, 0x84, 0x6f, 0x44, 0xdd, 0x0f, 0xa0, 0x16, 0x25, 0x90, 0x83, 0xa3, 0xc1,
+	0xb2, 0xeb, 0x11, 0x1f, 0x87, 0x64, 0x0a, 0xa8, 0x78, 0x1f, 0x32, 0xc2, 0x61, 0xc5, 0x27, 0x56,
+	0x75, 0xb9, 0x6b, 0xff, 0x5c, 0x82, 0x8d, 0xec, 0xc0, 0x3c, 0xc1, 0x0e, 0x9e, 0x10, 0x3f, 0x77,
+	0x6e, 0xae, 0x42, 0x79, 0xea, 0x5b, 0x12, 0x3c, 0x5c, 0xa2, 0x43, 0x68, 0x90, 0x2b, 0xcf, 0x14,
+	0x8d, 0x63, 0x84, 0xe3, 0x98, 0xb7, 0x6f, 0xad, 0xa7, 0xcd, 0xb5, 0xd7, 0x8b, 0x68, 0x56, 0xeb,
+	0xf5, 0xc4, 0x25, 0x3c, 0x0c, 0x09, 0xa0, 0x0c, 0x33, 0x22, 0x87, 0xa7, 0xd8, 0xa0, 0x43, 0xa8,
+	0xc8, 0x11, 0xb8, 0xc4, 0xab, 0xf2, 0x61, 0x52, 0x95, 0xdc, 0x8c, 0x45, 0xad, 0xa8, 0x90, 0x85,
+	0x74, 0xd5, 0x3e, 0x83, 0x5a, 0xea, 0xf8, 0x46, 0xe4, 0xff, 0x53, 0x82, 0x66, 0x36, 0x50, 0x5f,
+	0xb6, 0xfe, 0x0d, 0xdf, 0x94, 0x5d, 0x58, 0x77, 0x04, 0x8e, 0x41, 0x05, 0x90, 0xe1, 0x60, 0x49,
+	0x54, 0x55, 0x47, 0x4e, 0x26, 0xc6, 0x69, 0x88, 0xf5, 0x14, 0xde, 0x99, 0xf5, 0xb0, 0xc5, 0x47,
+	0x0a, 0x4f, 0xc1, 0xd3, 0x96, 0x93, 0x47, 0x03, 0x07, 0x38, 0x9a, 0xe1, 0xee, 0xa3, 0x22, 0xee,
+	0xa2, 0x4f, 0xca, 0x23, 0x2f, 0xa9, 0x4b, 0x25, 0x5d, 0x97, 0xc7, 0xd0, 0x08, 0xa7, 0xed, 0xd0,
+	0x75, 0x1c, 0xf1, 0xc4, 0x51, 0x3e, 0x1a, 0x57, 0xf4, 0xba, 0x8d, 0xaf, 0x0e, 0x93, 0xd3, 0xdb,
+	0x70, 0x7f, 0x02, 0x5b, 0xcf, 0x4c, 0x67, 0x94, 0xcd, 0x35, 0x54, 0x3f, 0xa1, 0xac, 0x90, 0x4f,
+	0xa5, 0x88, 0xcf, 0xf6, 0x9f, 0x65, 0xd0, 0xf2, 0xf0, 0xa8, 0xe7, 0x3a, 0x34, 0x53, 0x3a, 0x25,
+	0x5b, 0xba, 0x7d, 0x68, 0xcc, 0x84, 0x92, 0x0f, 0x9c, 0x5a, 0x44, 0xa8, 0x5e, 0xcf, 0xc6, 0x47,
+	0x6f, 0x40, 0x2d, 0xa8, 0x65, 0x34, 0x6e, 0xbe, 0x48, 0xb0, 0x8a, 0x93, 0xcc, 0xd7, 0xbc, 0x2c,
+	0x58, 0x33, 0x57, 0x09, 0xe1, 0xdb, 0xb0, 0x35, 0x1b, 0x3b, 0x7a, 0xbe, 0xa8, 0x7a, 0x87, 0x07,
+	0x6f, 0x2d, 0x52, 0x86, 0xbe, 0xe9, 0xe4, 0x9e, 0x53, 0xed, 0x15, 0x3c, 0xb8, 0x26, 0xa9, 0x9c,
+	0x7a, 0xef, 0xa5, 0xeb, 0x5d, 0xeb, 0xbd, 0xb7, 0xa0, 0xa1, 0xd3, 0x82, 0xf8, 0xa9, 0x04, 0x8d,
+	0xd3, 0x41, 0x5f, 0x17, 0x0e, 0xe2, 0x21, 0xc9, 0x29, 0x8e, 0x72, 0xc3, 0xe2, 0xbc, 0x84, 0xcd,
+	0x82, 0xe2, 0xbc, 0x6d, 0x8e, 0x1b, 0xb9, 0xd4, 0xa3, 0xef, 0x40, 0x2d, 0x62, 0x5e, 0x0e, 0xc8,
+	0xc5, 0xc4, 0x37, 0xf3, 0x89, 0x6f, 0x7f, 0x0b, 0xab, 0x3a, 0xb1, 0xdd, 0x4b, 0xc2, 0x09, 0x11,
+	0x3d, 0xb1, 0x0f, 0x0f, 0x8b, 0xe2, 0xa5, 0x9b, 0x43, 0xcb, 0x87, 0xe4, 0x4d, 0xf2, 0x02, 0x1a,
+	0x47, 0x3e, 0x36, 0x9d, 0xff, 0x16, 0xf5, 0x0d, 0x68, 0xf9, 0x9f, 0x77, 0x6c, 0x52, 0x76, 0xbd,
+	0x40, 0x95, 0x5b, 0x0a, 0xb4, 0xf7, 0xdb, 0xdc, 0x04, 0x97, 0xfa, 0x09, 0xd0, 0x21, 0xd4, 0xc4,
+	0x9a, 0xf8, 0xa7, 0x83, 0x3e, 0xda, 0x4a, 0x05, 0xc9, 0xaa, 0x4c, 0x2b, 0x36, 0xa1, 0xe7, 0xd0,
+	0x38, 0x98, 0x5a, 0x17, 0xb7, 0x06, 0xea, 0x28, 0xbb, 0x0a, 0x7a, 0x0a, 0xd5, 0xb8, 0xaa, 0x48,
+	0x4b, 0xee, 0xce, 0x96, 0x5a, 0x6b, 0xce, 0xbd, 0xac, 0xfd, 0xf0, 0x37, 0x12, 0xfa, 0x1c, 0x96,
+	0xa3, 0xfa, 0xa5, 0xd3, 0x98, 0xa9, 0x69, 0x91, 0x7b, 0xef, 0x6f, 0x05, 0x36, 0xb3, 0x64, 0x1d,
+	0x99, 0x74, 0xe8, 0x5e, 0x12, 0x3f, 0x40, 0x06, 0xa0, 0xf9, 0xc9, 0x84, 0xb6, 0xaf, 0x9f, 0x5b,
+	0x22, 0xdc, 0xa3, 0xb7, 0x19, 0x6e, 0xe8, 0x0c, 0xd6, 0x5e, 0x86, 0xff, 0x44, 0xfd, 0x6f, 0x11,
+	0x76, 0x95, 0xde, 0xef, 0x0a, 0xd4, 0x4e, 0xa9, 0x1d, 0x4b, 0xe0, 0xeb, 0xb4, 0x04, 0x4e, 0xd0,
+	0xa2, 0x4e, 0xd7, 0x16, 0x5d, 0x40, 0xc7, 0x70, 0xef, 0x4b, 0xc2, 0x62, 0xf9, 0xa1, 0x02, 0xa6,
+	0xb5, 0x47, 0x45, 0x40, 0xe9, 0xd6, 0x38, 0xab, 0x70, 0xaf, 0x4f, 0xfe, 0x1d, 0x00, 0x2d, 0x1e,
+	0x63, 0x8f, 0x49, 0x0f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -641,6 +920,7 @@ type NetworkServiceRegistryClient interface {
 	RegisterNSE(ctx context.Context, in *NSERegistration, opts ...grpc.CallOption) (*NSERegistration, error)
 	BulkRegisterNSE(ctx context.Context, opts ...grpc.CallOption) (NetworkServiceRegistry_BulkRegisterNSEClient, error)
 	RemoveNSE(ctx context.Context, in *RemoveNSERequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	DrainNSE(ctx context.Context, in *DrainNSERequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type networkServiceRegistryClient struct {
@@ -700,11 +980,21 @@ func (c *networkServiceRegistryClient) RemoveNSE(ctx context.Context, in *Remove
 	return out, nil
 }
 
+func (c *networkServiceRegistryClient) DrainNSE(ctx context.Context, in *DrainNSERequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/registry.NetworkServiceRegistry/DrainNSE", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetworkServiceRegistryServer is the server API for NetworkServiceRegistry service.
 type NetworkServiceRegistryServer interface {
 	RegisterNSE(context.Context, *NSERegistration) (*NSERegistration, error)
 	BulkRegisterNSE(NetworkServiceRegistry_BulkRegisterNSEServer) error
 	RemoveNSE(context.Context, *RemoveNSERequest) (*empty.Empty, error)
+	DrainNSE(context.Context, *DrainNSERequest) (*empty.Empty, error)
 }
 
 // UnimplementedNetworkServiceRegistryServer can be embedded to have forward compatible implementations.
@@ -720,6 +1010,9 @@ func (*UnimplementedNetworkServiceRegistryServer) BulkRegisterNSE(srv NetworkSer
 func (*UnimplementedNetworkServiceRegistryServer) RemoveNSE(ctx context.Context, req *RemoveNSERequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveNSE not implemented")
 }
+func (*UnimplementedNetworkServiceRegistryServer) DrainNSE(ctx context.Context, req *DrainNSERequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainNSE not implemented")
+}
 
 func RegisterNetworkServiceRegistryServer(s *grpc.Server, srv NetworkServiceRegistryServer) {
 	s.RegisterService(&_NetworkServiceRegistry_serviceDesc, srv)
@@ -787,6 +1080,24 @@ func _NetworkServiceRegistry_RemoveNSE_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServiceRegistry_DrainNSE_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainNSERequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetworkServiceRegistryServer).DrainNSE(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/registry.NetworkServiceRegistry/DrainNSE",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetworkServiceRegistryServer).DrainNSE(ctx, req.(*DrainNSERequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NetworkServiceRegistry_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "registry.NetworkServiceRegistry",
 	HandlerType: (*NetworkServiceRegistryServer)(nil),
@@ -799,6 +1110,10 @@ var _NetworkServiceRegistry_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveNSE",
 			Handler:    _NetworkServiceRegistry_RemoveNSE_Handler,
 		},
+		{
+			MethodName: "DrainNSE",
+			Handler:    _NetworkServiceRegistry_DrainNSE_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -816,6 +1131,7 @@ var _NetworkServiceRegistry_serviceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type NetworkServiceDiscoveryClient interface {
 	FindNetworkService(ctx context.Context, in *FindNetworkServiceRequest, opts ...grpc.CallOption) (*FindNetworkServiceResponse, error)
+	WatchNetworkService(ctx context.Context, in *FindNetworkServiceRequest, opts ...grpc.CallOption) (NetworkServiceDiscovery_WatchNetworkServiceClient, error)
 }
 
 type networkServiceDiscoveryClient struct {
@@ -835,9 +1151,42 @@ func (c *networkServiceDiscoveryClient) FindNetworkService(ctx context.Context,
 	return out, nil
 }
 
+func (c *networkServiceDiscoveryClient) WatchNetworkService(ctx context.Context, in *FindNetworkServiceRequest, opts ...grpc.CallOption) (NetworkServiceDiscovery_WatchNetworkServiceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_NetworkServiceDiscovery_serviceDesc.Streams[0], "/registry.NetworkServiceDiscovery/WatchNetworkService", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &networkServiceDiscoveryWatchNetworkServiceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NetworkServiceDiscovery_WatchNetworkServiceClient interface {
+	Recv() (*FindNetworkServiceResponse, error)
+	grpc.ClientStream
+}
+
+type networkServiceDiscoveryWatchNetworkServiceClient struct {
+	grpc.ClientStream
+}
+
+func (x *networkServiceDiscoveryWatchNetworkServiceClient) Recv() (*FindNetworkServiceResponse, error) {
+	m := new(FindNetworkServiceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // NetworkServiceDiscoveryServer is the server API for NetworkServiceDiscovery service.
 type NetworkServiceDiscoveryServer interface {
 	FindNetworkService(context.Context, *FindNetworkServiceRequest) (*FindNetworkServiceResponse, error)
+	WatchNetworkService(*FindNetworkServiceRequest, NetworkServiceDiscovery_WatchNetworkServiceServer) error
 }
 
 // UnimplementedNetworkServiceDiscoveryServer can be embedded to have forward compatible implementations.
@@ -847,6 +1196,9 @@ type UnimplementedNetworkServiceDiscoveryServer struct {
 func (*UnimplementedNetworkServiceDiscoveryServer) FindNetworkService(ctx context.Context, req *FindNetworkServiceRequest) (*FindNetworkServiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindNetworkService not implemented")
 }
+func (*UnimplementedNetworkServiceDiscoveryServer) WatchNetworkService(req *FindNetworkServiceRequest, srv NetworkServiceDiscovery_WatchNetworkServiceServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNetworkService not implemented")
+}
 
 func RegisterNetworkServiceDiscoveryServer(s *grpc.Server, srv NetworkServiceDiscoveryServer) {
 	s.RegisterService(&_NetworkServiceDiscovery_serviceDesc, srv)
@@ -870,6 +1222,27 @@ func _NetworkServiceDiscovery_FindNetworkService_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NetworkServiceDiscovery_WatchNetworkService_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindNetworkServiceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NetworkServiceDiscoveryServer).WatchNetworkService(m, &networkServiceDiscoveryWatchNetworkServiceServer{stream})
+}
+
+type NetworkServiceDiscovery_WatchNetworkServiceServer interface {
+	Send(*FindNetworkServiceResponse) error
+	grpc.ServerStream
+}
+
+type networkServiceDiscoveryWatchNetworkServiceServer struct {
+	grpc.ServerStream
+}
+
+func (x *networkServiceDiscoveryWatchNetworkServiceServer) Send(m *FindNetworkServiceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _NetworkServiceDiscovery_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "registry.NetworkServiceDiscovery",
 	HandlerType: (*NetworkServiceDiscoveryServer)(nil),
@@ -879,7 +1252,13 @@ var _NetworkServiceDiscovery_serviceDesc = grpc.ServiceDesc{
 			Handler:    _NetworkServiceDiscovery_FindNetworkService_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchNetworkService",
+			Handler:       _NetworkServiceDiscovery_WatchNetworkService_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "registry.proto",
 }
 
@@ -15,3 +15,11 @@ func (nse *NSERegistration) GetEndpointNSMName() EndpointNSMName {
 func NewEndpointNSMName(endpoint *NetworkServiceEndpoint, manager *NetworkServiceManager) EndpointNSMName {
 	return EndpointNSMName(endpoint.Name + ":" + manager.Url)
 }
+
+// EndpointStatePaused - state of the endpoint which is draining and must not be selected for new connections
+const EndpointStatePaused = "PAUSED"
+
+// IsPaused - returns true if the endpoint is draining and must not be selected for new connections
+func (m *NetworkServiceEndpoint) IsPaused() bool {
+	return m.GetState() == EndpointStatePaused
+}
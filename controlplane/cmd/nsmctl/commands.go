@@ -0,0 +1,369 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/admin"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+)
+
+// cli keeps addresses of the NSM components and the printer shared by the commands
+type cli struct {
+	nsmdAddress     string
+	registryAddress string
+	monitorAddress  string
+	timeout         time.Duration
+	printer         *printer
+}
+
+// networkService is a network service of the endpoints registered at NSMgr
+type networkService struct {
+	Name        string   `json:"name"`
+	Endpoints   []string `json:"endpoints"`
+	Connections uint32   `json:"connections"`
+}
+
+type networkServiceList struct {
+	NetworkServices []*networkService `json:"networkServices"`
+}
+
+func (c *cli) services(ctx context.Context, _ []string) error {
+	conn, err := c.dial(ctx, c.nsmdAddress)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	endpoints, err := admin.NewAdminClient(conn).ListEndpoints(ctx, &empty.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list endpoints")
+	}
+
+	services := groupByNetworkService(endpoints.GetEndpoints())
+	return c.printer.print(services, func(w io.Writer) {
+		fmt.Fprintln(w, "NETWORK SERVICE\tENDPOINTS\tCONNECTIONS")
+		for _, ns := range services.NetworkServices {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", ns.Name, strings.Join(ns.Endpoints, ","), ns.Connections)
+		}
+	})
+}
+
+func (c *cli) endpoints(ctx context.Context, args []string) error {
+	conn, err := c.dial(ctx, c.registryAddress)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	response, err := registry.NewNetworkServiceDiscoveryClient(conn).FindNetworkService(ctx, &registry.FindNetworkServiceRequest{
+		NetworkServiceName: args[0],
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find network service %s", args[0])
+	}
+
+	return c.printer.print(response, func(w io.Writer) {
+		fmt.Fprintln(w, "NAME\tNSM\tURL\tSTATE\tLABELS")
+		for _, endpoint := range response.GetNetworkServiceEndpoints() {
+			nsm := response.GetNetworkServiceManagers()[endpoint.GetNetworkServiceManagerName()]
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", endpoint.GetName(), endpoint.GetNetworkServiceManagerName(),
+				nsm.GetUrl(), endpoint.GetState(), formatLabels(endpoint.GetLabels()))
+		}
+	})
+}
+
+func (c *cli) connections(ctx context.Context, _ []string) error {
+	conn, err := c.dial(ctx, c.nsmdAddress)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	connections, err := admin.NewAdminClient(conn).ListConnections(ctx, &empty.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list connections")
+	}
+
+	return c.printer.print(connections, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tNETWORK SERVICE\tSTATE\tFORWARDER\tENDPOINT\tPATH")
+		for _, cc := range connections.GetConnections() {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", cc.GetId(), cc.GetNetworkService(), cc.GetState(),
+				cc.GetForwarder(), cc.GetEndpoint(), formatPath(cc.GetPath()))
+		}
+	})
+}
+
+func (c *cli) watch(ctx context.Context, _ []string) error {
+	conn, err := c.dial(ctx, c.monitorAddress)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	stream, err := crossconnect.NewMonitorCrossConnectClient(conn).MonitorCrossConnects(ctx, &empty.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "failed to monitor cross-connects")
+	}
+	for {
+		event, recvErr := stream.Recv()
+		if recvErr != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(recvErr, "cross-connect monitor stream is closed")
+		}
+		if err = c.printer.print(event, func(w io.Writer) {
+			for _, xcon := range sortedCrossConnects(event.GetCrossConnects()) {
+				fmt.Fprintf(w, "%s\t%s\t%s\tsrc=%s\tdst=%s\t%s\n", event.GetType(), xcon.GetId(),
+					xcon.GetSource().GetNetworkService(), xcon.GetSource().GetState(), xcon.GetDestination().GetState(),
+					formatPath(xcon.GetSource().GetPath()))
+			}
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *cli) close(ctx context.Context, args []string) error {
+	conn, err := c.dial(ctx, c.nsmdAddress)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	if _, err = admin.NewAdminClient(conn).CloseConnection(ctx, &admin.CloseConnectionRequest{Id: args[0]}); err != nil {
+		return errors.Wrapf(err, "failed to close connection %s", args[0])
+	}
+	fmt.Fprintf(c.printer.out, "connection %s is closed\n", args[0])
+	return nil
+}
+
+func (c *cli) heal(ctx context.Context, args []string) error {
+	healState, err := healStateArg(args)
+	if err != nil {
+		return err
+	}
+
+	conn, err := c.dial(ctx, c.nsmdAddress)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	if _, err = admin.NewAdminClient(conn).HealConnection(ctx, &admin.HealConnectionRequest{
+		Id:        args[0],
+		HealState: healState,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to heal connection %s", args[0])
+	}
+	fmt.Fprintf(c.printer.out, "healing of connection %s is started\n", args[0])
+	return nil
+}
+
+func (c *cli) heals(ctx context.Context, _ []string) error {
+	conn, err := c.dial(ctx, c.nsmdAddress)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	heals, err := admin.NewAdminClient(conn).ListHeals(ctx, &empty.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list heals")
+	}
+
+	return c.printer.print(heals, func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tCONNECTION\tNETWORK SERVICE\tHEAL STATE\tSTARTED\tDURATION\tOUTCOME\tENDPOINT\tFORWARDER")
+		for _, event := range heals.GetEvents() {
+			printHealEvent(w, event)
+		}
+	})
+}
+
+func (c *cli) watchHeals(ctx context.Context, _ []string) error {
+	conn, err := c.dial(ctx, c.nsmdAddress)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	stream, err := admin.NewAdminClient(conn).WatchHeals(ctx, &empty.Empty{})
+	if err != nil {
+		return errors.Wrap(err, "failed to watch heals")
+	}
+	for {
+		event, recvErr := stream.Recv()
+		if recvErr != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(recvErr, "heal stream is closed")
+		}
+		if err = c.printer.print(event, func(w io.Writer) {
+			printHealEvent(w, event)
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *cli) version(_ context.Context, _ []string) error {
+	fmt.Fprintf(c.printer.out, "nsmctl version: %s\n", version)
+	return nil
+}
+
+func (c *cli) dial(ctx context.Context, address string) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	conn, err := tools.DialContextTCP(ctx, address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to %s", address)
+	}
+	return conn, nil
+}
+
+// groupByNetworkService returns network services of the endpoints sorted by name
+func groupByNetworkService(endpoints []*admin.Endpoint) *networkServiceList {
+	services := map[string]*networkService{}
+	for _, endpoint := range endpoints {
+		name := endpoint.GetRegistration().GetNetworkService().GetName()
+		ns, ok := services[name]
+		if !ok {
+			ns = &networkService{Name: name}
+			services[name] = ns
+		}
+		ns.Endpoints = append(ns.Endpoints, endpoint.GetRegistration().GetNetworkServiceEndpoint().GetName())
+		ns.Connections += endpoint.GetConnections()
+	}
+
+	rv := &networkServiceList{
+		NetworkServices: make([]*networkService, 0, len(services)),
+	}
+	for _, ns := range services {
+		sort.Strings(ns.Endpoints)
+		rv.NetworkServices = append(rv.NetworkServices, ns)
+	}
+	sort.Slice(rv.NetworkServices, func(i, j int) bool {
+		return rv.NetworkServices[i].Name < rv.NetworkServices[j].Name
+	})
+	return rv
+}
+
+// formatPath returns names of the path segments, the current one is marked with *
+// healStates - heal states NSMgr can be asked to heal connection with
+var healStates = map[string]admin.HealState{
+	"dst_down":       admin.HealState_DST_DOWN,
+	"forwarder_down": admin.HealState_FORWARDER_DOWN,
+	"dst_update":     admin.HealState_DST_UPDATE,
+	"dst_nmgr_down":  admin.HealState_DST_NMGR_DOWN,
+}
+
+// healStateArg returns heal state of the optional second argument of heal command, DEFAULT if there is none
+func healStateArg(args []string) (admin.HealState, error) {
+	if len(args) < 2 {
+		return admin.HealState_DEFAULT, nil
+	}
+	healState, ok := healStates[strings.ToLower(args[1])]
+	if !ok {
+		return admin.HealState_DEFAULT, errors.Errorf("unknown heal state %q, expected one of dst_down, forwarder_down, dst_update or dst_nmgr_down", args[1])
+	}
+	return healState, nil
+}
+
+func formatPath(path *connection.Path) string {
+	names := make([]string, 0, len(path.GetPathSegments()))
+	for i, segment := range path.GetPathSegments() {
+		name := segment.GetName()
+		if uint32(i) == path.GetIndex() {
+			name = "*" + name
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, " -> ")
+}
+
+func printHealEvent(w io.Writer, event *admin.HealEvent) {
+	outcome := event.GetOutcome()
+	if outcome == "" {
+		outcome = "healing"
+	}
+	started, duration := formatHealTimes(event)
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", event.GetId(), event.GetConnectionId(),
+		event.GetNetworkService(), event.GetHealState(), started, duration, outcome, event.GetEndpoint(),
+		event.GetForwarder())
+}
+
+// formatHealTimes returns start time of the heal and its duration, duration is empty while the heal is in progress
+func formatHealTimes(event *admin.HealEvent) (started, duration string) {
+	start, err := ptypes.Timestamp(event.GetStartTime())
+	if err != nil {
+		return "", ""
+	}
+	started = start.Local().Format(time.RFC3339)
+	if end, endErr := ptypes.Timestamp(event.GetEndTime()); endErr == nil {
+		duration = end.Sub(start).Round(time.Millisecond).String()
+	}
+	return started, duration
+}
+
+// formatLabels returns labels as sorted comma separated key=value pairs
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func sortedCrossConnects(xcons map[string]*crossconnect.CrossConnect) []*crossconnect.CrossConnect {
+	rv := make([]*crossconnect.CrossConnect, 0, len(xcons))
+	for _, xcon := range xcons {
+		rv = append(rv, xcon)
+	}
+	sort.Slice(rv, func(i, j int) bool {
+		return rv[i].GetId() < rv[j].GetId()
+	})
+	return rv
+}
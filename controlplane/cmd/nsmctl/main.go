@@ -0,0 +1,128 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nsmctl is a command-line tool for day-2 operations of Network Service Mesh
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	"github.com/networkservicemesh/networkservicemesh/utils"
+)
+
+var version string
+
+const registryAddressEnv utils.EnvVar = "NSM_REGISTRY_ADDRESS"
+
+const usage = `nsmctl - Network Service Mesh command-line tool
+
+Usage: nsmctl [flags] <command> [arguments]
+
+Commands:
+  services                      list network services of the endpoints registered at NSMgr
+  endpoints <network-service>   list endpoints of the network service from the registry
+  connections                   list client connections of NSMgr with their path
+  watch                         watch cross-connect events of NSMgr
+  close <connection-id>         close the client connection
+  heal <connection-id> [state]  heal the client connection, state is one of dst_down (default), forwarder_down,
+                                dst_update or dst_nmgr_down
+  heals                         list recent heals of NSMgr with their outcome
+  watch-heals                   watch heals of NSMgr when they are started and finished
+  version                       print version of nsmctl
+
+Flags:
+`
+
+type command struct {
+	args int
+	run  func(c *cli, ctx context.Context, args []string) error
+}
+
+var commands = map[string]command{
+	"services":    {args: 0, run: (*cli).services},
+	"endpoints":   {args: 1, run: (*cli).endpoints},
+	"connections": {args: 0, run: (*cli).connections},
+	"watch":       {args: 0, run: (*cli).watch},
+	"close":       {args: 1, run: (*cli).close},
+	"heal":        {args: 1, run: (*cli).heal},
+	"heals":       {args: 0, run: (*cli).heals},
+	"watch-heals": {args: 0, run: (*cli).watchHeals},
+	"version":     {args: 0, run: (*cli).version},
+}
+
+func main() {
+	c := &cli{}
+	flag.StringVar(&c.nsmdAddress, "nsmd", nsmd.PublicAPIAddressEnv.GetStringOrDefault(nsmd.GetLocalIPAddress()+":5001"),
+		"address of NSMgr public API")
+	flag.StringVar(&c.registryAddress, "registry", registryAddressEnv.GetStringOrDefault("127.0.0.1:5000"),
+		"address of Network Service Registry")
+	flag.StringVar(&c.monitorAddress, "monitor", "", "address of cross-connect monitor, NSMgr public API if not set")
+	flag.DurationVar(&c.timeout, "timeout", 15*time.Second, "timeout of the calls")
+	output := flag.String("o", tableFormat, "output format: table, json or yaml")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Keep output of the tool clean from the logs of the dialer
+	logrus.SetLevel(logrus.WarnLevel)
+
+	if c.monitorAddress == "" {
+		c.monitorAddress = c.nsmdAddress
+	}
+	p, err := newPrinter(os.Stdout, *output)
+	if err != nil {
+		fail(err)
+	}
+	c.printer = p
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[args[0]]
+	if !ok || len(args)-1 < cmd.args {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-tools.NewOSSignalChannel()
+		cancel()
+	}()
+
+	if err = cmd.run(c, ctx, args[1:]); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "nsmctl: %s\n", strings.TrimSpace(err.Error()))
+	os.Exit(1)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/admin"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func TestGroupByNetworkService(t *testing.T) {
+	g := NewWithT(t)
+
+	endpoint := func(ns, name string, connections uint32) *admin.Endpoint {
+		return &admin.Endpoint{
+			Registration: &registry.NSERegistration{
+				NetworkService:         &registry.NetworkService{Name: ns},
+				NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: name},
+			},
+			Connections: connections,
+		}
+	}
+	services := groupByNetworkService([]*admin.Endpoint{
+		endpoint("vpn", "vpn-2", 1),
+		endpoint("icmp", "icmp-1", 0),
+		endpoint("vpn", "vpn-1", 2),
+	})
+
+	g.Expect(services.NetworkServices).To(Equal([]*networkService{
+		{Name: "icmp", Endpoints: []string{"icmp-1"}, Connections: 0},
+		{Name: "vpn", Endpoints: []string{"vpn-1", "vpn-2"}, Connections: 3},
+	}))
+}
+
+func TestFormatPath(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(formatPath(nil)).To(Equal(""))
+	g.Expect(formatPath(&connection.Path{
+		Index: 1,
+		PathSegments: []*connection.PathSegment{
+			{Name: "nsm-1"},
+			{Name: "nsm-2"},
+			{Name: "nse-1"},
+		},
+	})).To(Equal("nsm-1 -> *nsm-2 -> nse-1"))
+}
+
+func TestFormatLabels(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(formatLabels(nil)).To(Equal(""))
+	g.Expect(formatLabels(map[string]string{"b": "2", "a": "1"})).To(Equal("a=1,b=2"))
+}
+
+func TestFormatHealTimes(t *testing.T) {
+	g := NewWithT(t)
+
+	start := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	startTime, err := ptypes.TimestampProto(start)
+	g.Expect(err).To(BeNil())
+	endTime, err := ptypes.TimestampProto(start.Add(1500 * time.Millisecond))
+	g.Expect(err).To(BeNil())
+
+	started, duration := formatHealTimes(&admin.HealEvent{StartTime: startTime, EndTime: endTime})
+	g.Expect(started).To(Equal(start.Local().Format(time.RFC3339)))
+	g.Expect(duration).To(Equal("1.5s"))
+
+	_, duration = formatHealTimes(&admin.HealEvent{StartTime: startTime})
+	g.Expect(duration).To(BeEmpty())
+}
+
+func TestPrinter(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := newPrinter(&bytes.Buffer{}, "xml")
+	g.Expect(err).To(HaveOccurred())
+
+	out := &bytes.Buffer{}
+	p, err := newPrinter(out, tableFormat)
+	g.Expect(err).To(BeNil())
+	g.Expect(p.print(nil, func(w io.Writer) {
+		_, _ = w.Write([]byte("A\tB\nlonger\tvalue\n"))
+	})).To(BeNil())
+	g.Expect(out.String()).To(Equal("A       B\nlonger  value\n"))
+
+	out.Reset()
+	p, err = newPrinter(out, jsonFormat)
+	g.Expect(err).To(BeNil())
+	g.Expect(p.print(&networkServiceList{}, nil)).To(BeNil())
+	g.Expect(out.String()).To(Equal("{\n  \"networkServices\": null\n}\n"))
+}
+
+func TestHealStateArg(t *testing.T) {
+	g := NewWithT(t)
+
+	healState, err := healStateArg([]string{"1"})
+	g.Expect(err).To(BeNil())
+	g.Expect(healState).To(Equal(admin.HealState_DEFAULT))
+
+	healState, err = healStateArg([]string{"1", "FORWARDER_DOWN"})
+	g.Expect(err).To(BeNil())
+	g.Expect(healState).To(Equal(admin.HealState_FORWARDER_DOWN))
+
+	for _, name := range []string{"src_down", "default", "unknown"} {
+		_, err = healStateArg([]string{"1", name})
+		g.Expect(err).NotTo(BeNil())
+	}
+}
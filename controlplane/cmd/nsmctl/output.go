@@ -0,0 +1,104 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	tableFormat = "table"
+	jsonFormat  = "json"
+	yamlFormat  = "yaml"
+)
+
+// printer writes results of the commands in the selected format
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+func newPrinter(out io.Writer, format string) (*printer, error) {
+	switch format {
+	case tableFormat, jsonFormat, yamlFormat:
+		return &printer{out: out, format: format}, nil
+	}
+	return nil, errors.Errorf("unknown output format %q, expected %s, %s or %s", format, tableFormat, jsonFormat, yamlFormat)
+}
+
+// print writes v as JSON or YAML document, table writes v as a table
+func (p *printer) print(v interface{}, table func(w io.Writer)) error {
+	switch p.format {
+	case jsonFormat:
+		data, err := marshalJSON(v, "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.out, string(data))
+		return err
+	case yamlFormat:
+		data, err := marshalYAML(v)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.out, "---\n%s", data)
+		return err
+	default:
+		w := tabwriter.NewWriter(p.out, 0, 8, 2, ' ', 0)
+		table(w)
+		return w.Flush()
+	}
+}
+
+// marshalJSON marshals protobuf messages with jsonpb to keep the field names of the API, other values with encoding/json
+func marshalJSON(v interface{}, indent string) ([]byte, error) {
+	if msg, ok := v.(proto.Message); ok {
+		buffer := &bytes.Buffer{}
+		marshaler := &jsonpb.Marshaler{Indent: indent}
+		if err := marshaler.Marshal(buffer, msg); err != nil {
+			return nil, errors.Wrap(err, "failed to marshal to JSON")
+		}
+		return buffer.Bytes(), nil
+	}
+	data, err := json.MarshalIndent(v, "", indent)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal to JSON")
+	}
+	return data, nil
+}
+
+// marshalYAML converts JSON representation of v to YAML keeping the order of the fields
+func marshalYAML(v interface{}) ([]byte, error) {
+	data, err := marshalJSON(v, "")
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.MapSlice
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrap(err, "failed to convert JSON to YAML")
+	}
+	return yaml.Marshal(doc)
+}
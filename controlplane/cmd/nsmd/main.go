@@ -18,6 +18,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
@@ -44,6 +45,9 @@ func main() {
 	closer := jaeger.InitJaeger("nsmd")
 	defer func() { _ = closer.Close() }()
 
+	stopMetrics := metrics.ServePrometheusMetrics()
+	defer stopMetrics()
+
 	// Global NSMgr server span holder
 	span := spanhelper.FromContext(context.Background(), "nsmd.server")
 	span.LogValue("tracing.init-complete", fmt.Sprintf("%v", time.Since(start)))
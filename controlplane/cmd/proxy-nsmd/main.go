@@ -14,6 +14,8 @@ import (
 
 	"github.com/networkservicemesh/networkservicemesh/pkg/probes"
 
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 	"github.com/networkservicemesh/networkservicemesh/sdk/monitor/remote"
@@ -45,6 +47,10 @@ func main() {
 
 	closer := jaeger.InitJaeger("proxy-nsmd")
 	defer func() { _ = closer.Close() }()
+
+	stopMetrics := metrics.ServePrometheusMetrics()
+	defer stopMetrics()
+
 	goals := &proxyNsmdProbeGoals{}
 	nsmdProbes := probes.New("Prxoy NSMD liveness/readiness healthcheck", goals)
 	nsmdProbes.BeginHealthCheck()
@@ -88,7 +94,10 @@ func startAPIServerAt(sock net.Listener, serviceRegistry serviceregistry.Service
 	connection.RegisterMonitorConnectionServer(grpcServer, remoteConnectionMonitor)
 	probes.Append(health.NewGrpcHealth(grpcServer, sock.Addr(), time.Minute))
 	// Register Remote NetworkServiceManager
-	remoteServer := proxynetworkserviceserver.NewProxyNetworkServiceServer(serviceRegistry)
+	remoteServer := common.NewCompositeService("Proxy",
+		common.NewMetricsService("proxy"),
+		proxynetworkserviceserver.NewProxyNetworkServiceServer(serviceRegistry),
+	)
 	unified.RegisterNetworkServiceServer(grpcServer, remoteServer)
 
 	go func() {
@@ -63,8 +63,36 @@ const (
 	HealStateDstUpdate HealState = 4
 	// HealStateDstNmgrDown is a case when destination and/or Remote NSM is down: we need to heal NSE/Remote NSM.
 	HealStateDstNmgrDown HealState = 5
+	// HealStateWireguardKeyRotation is a case when Wireguard key of the local Forwarder is rotated: we need to re-program
+	// local Forwarder and Remote NSM with the new keys.
+	HealStateWireguardKeyRotation HealState = 6
+	// HealStateDstDrain is a case when destination is draining: we need to move the connection to another NSE before
+	// closing the connection to the draining one.
+	HealStateDstDrain HealState = 7
+	// HealStateForwarderDrain is a case when local Forwarder is draining: we need to move the connection to another
+	// local Forwarder.
+	HealStateForwarderDrain HealState = 8
 )
 
+var healStateNames = map[HealState]string{
+	HealStateDstDown:              "dst_down",
+	HealStateSrcDown:              "src_down",
+	HealStateForwarderDown:        "forwarder_down",
+	HealStateDstUpdate:            "dst_update",
+	HealStateDstNmgrDown:          "dst_nmgr_down",
+	HealStateWireguardKeyRotation: "wireguard_key_rotation",
+	HealStateDstDrain:             "dst_drain",
+	HealStateForwarderDrain:       "forwarder_drain",
+}
+
+// String returns name of the heal state
+func (s HealState) String() string {
+	if name, ok := healStateNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
 // NetworkServiceRequestManager - allow to provide local and remote service interfaces.
 type NetworkServiceRequestManager interface {
 	LocalManager(clientConnection ClientConnection) networkservice.NetworkServiceServer
@@ -77,6 +105,29 @@ type NetworkServiceHealProcessor interface {
 	CloseConnection(ctx context.Context, clientConnection ClientConnection) error
 }
 
+// HealEvent - record of the connection heal, Outcome is empty while the heal is in progress. Endpoint and Forwarder
+// are the ones of the connection after the heal
+type HealEvent struct {
+	ID             string
+	ConnectionID   string
+	NetworkService string
+	HealState      HealState
+	StartTime      time.Time
+	EndTime        time.Time
+	Outcome        string
+	Endpoint       string
+	Forwarder      string
+}
+
+// HealHistory - keeps the recent heals of the connections
+type HealHistory interface {
+	// Events returns copies of the recorded heals from the oldest to the newest
+	Events() []*HealEvent
+	// Watch returns channel receiving copies of the heals when they are started and finished, channel is closed when
+	// ctx is done
+	Watch(ctx context.Context) <-chan *HealEvent
+}
+
 // MonitorManager is an interface to provide access to different monitors
 type MonitorManager interface {
 	CrossConnectMonitor() crossconnect_monitor.MonitorServer
@@ -89,6 +140,9 @@ type NetworkServiceManager interface {
 	WaitForForwarder(ctx context.Context, duration time.Duration) error
 	RemoteConnectionLost(ctx context.Context, clientConnection ClientConnection)
 	NotifyRenamedEndpoint(nseOldName, nseNewName string)
+	DrainEndpoint(ctx context.Context, endpointName string) error
+	DrainForwarder(ctx context.Context, forwarderName string) error
+	HealHistory() HealHistory
 	// Getters
 	NseManager() NetworkServiceEndpointManager
 	SetRemoteServer(server networkservice.NetworkServiceServer)
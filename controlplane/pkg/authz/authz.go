@@ -0,0 +1,220 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package authz - authorizes clients to connect to the network services
+package authz
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// Action - action of the policy rule
+type Action string
+
+const (
+	// Allow - allows the matching request
+	Allow Action = "allow"
+	// Deny - denies the matching request
+	Deny Action = "deny"
+)
+
+// Request - attributes of the connection request the policy is evaluated on
+type Request struct {
+	// SpiffeID - SPIFFE ID of the request originator, the first path segment is signed with
+	SpiffeID string
+	// Namespace - namespace of the client from its SPIFFE ID, e.g. spiffe://example.org/ns/default/sa/nsc
+	Namespace string
+	// ClientLabels - labels of the client connection
+	ClientLabels map[string]string
+	// NetworkService - name of the requested network service
+	NetworkService string
+	// EndpointName - name of the selected network service endpoint
+	EndpointName string
+	// EndpointLabels - labels of the selected network service endpoint
+	EndpointLabels map[string]string
+}
+
+// Rule - policy rule, matches request if all the set fields match. SpiffeIDs and NetworkServices are path.Match
+// patterns, e.g. spiffe://example.org/ns/default/*
+type Rule struct {
+	Name            string            `json:"name"`
+	Action          Action            `json:"action"`
+	SpiffeIDs       []string          `json:"spiffeIds,omitempty"`
+	Namespaces      []string          `json:"namespaces,omitempty"`
+	ClientLabels    map[string]string `json:"clientLabels,omitempty"`
+	NetworkServices []string          `json:"networkServices,omitempty"`
+	EndpointLabels  map[string]string `json:"endpointLabels,omitempty"`
+}
+
+// Policy - ordered list of rules, the first matching rule decides, DefaultAction decides if none matches
+type Policy struct {
+	DefaultAction Action  `json:"defaultAction"`
+	Rules         []*Rule `json:"rules"`
+}
+
+// Authorizer - decides if the request is allowed
+type Authorizer interface {
+	// Authorize - returns error describing the decision if the request is denied
+	Authorize(request *Request) error
+}
+
+type policyAuthorizer struct {
+	policy *Policy
+}
+
+// NewPolicyAuthorizer - creates authorizer for the policy, nil policy allows everything
+func NewPolicyAuthorizer(policy *Policy) Authorizer {
+	return &policyAuthorizer{
+		policy: policy,
+	}
+}
+
+// NewDenyAllAuthorizer - creates authorizer denying everything
+func NewDenyAllAuthorizer() Authorizer {
+	return &policyAuthorizer{
+		policy: &Policy{DefaultAction: Deny},
+	}
+}
+
+type adminAuthorizer struct {
+	spiffeIDs []string
+}
+
+// NewAdminAuthorizer - creates authorizer of the administrative API allowing only callers with SPIFFE ID matching one
+// of the path.Match patterns, everything is denied if there are no patterns
+func NewAdminAuthorizer(spiffeIDs []string) Authorizer {
+	return &adminAuthorizer{
+		spiffeIDs: spiffeIDs,
+	}
+}
+
+func (a *adminAuthorizer) Authorize(request *Request) error {
+	if len(a.spiffeIDs) == 0 || !matchPatterns(a.spiffeIDs, request.SpiffeID) {
+		return errors.Errorf("caller %q is not allowed to use the administrative API", request.SpiffeID)
+	}
+	return nil
+}
+
+// LoadPolicy - reads and validates JSON policy from the file
+func LoadPolicy(policyFile string) (*Policy, error) {
+	data, err := ioutil.ReadFile(policyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read authorization policy from %s", policyFile)
+	}
+	policy := &Policy{}
+	if err = json.Unmarshal(data, policy); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse authorization policy from %s", policyFile)
+	}
+	if err = policy.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid authorization policy %s", policyFile)
+	}
+	return policy, nil
+}
+
+func (a *policyAuthorizer) Authorize(request *Request) error {
+	if a.policy == nil {
+		return nil
+	}
+	for i, rule := range a.policy.Rules {
+		if !rule.matches(request) {
+			continue
+		}
+		if rule.Action == Allow {
+			return nil
+		}
+		name := rule.Name
+		if name == "" {
+			name = "#" + strconv.Itoa(i)
+		}
+		return errors.Errorf("client %q of namespace %q is denied to connect to %s/%s by rule %s",
+			request.SpiffeID, request.Namespace, request.NetworkService, request.EndpointName, name)
+	}
+	if a.policy.DefaultAction == Allow {
+		return nil
+	}
+	return errors.Errorf("client %q of namespace %q is denied to connect to %s/%s by default",
+		request.SpiffeID, request.Namespace, request.NetworkService, request.EndpointName)
+}
+
+func (p *Policy) validate() error {
+	if err := validateAction(p.DefaultAction); err != nil {
+		return errors.Wrap(err, "defaultAction")
+	}
+	for i, rule := range p.Rules {
+		if err := validateAction(rule.Action); err != nil {
+			return errors.Wrapf(err, "rule %d", i)
+		}
+		for _, pattern := range append(append([]string{}, rule.SpiffeIDs...), rule.NetworkServices...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return errors.Wrapf(err, "rule %d pattern %s", i, pattern)
+			}
+		}
+	}
+	return nil
+}
+
+func validateAction(action Action) error {
+	if action != Allow && action != Deny {
+		return errors.Errorf("unknown action %q, expected %q or %q", action, Allow, Deny)
+	}
+	return nil
+}
+
+func (r *Rule) matches(request *Request) bool {
+	return matchPatterns(r.SpiffeIDs, request.SpiffeID) &&
+		matchValues(r.Namespaces, request.Namespace) &&
+		matchLabels(r.ClientLabels, request.ClientLabels) &&
+		matchPatterns(r.NetworkServices, request.NetworkService) &&
+		matchLabels(r.EndpointLabels, request.EndpointLabels)
+}
+
+func matchPatterns(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func matchValues(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func matchLabels(selector, labels map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testPolicy = `{
+  "defaultAction": "deny",
+  "rules": [
+    {
+      "name": "no-secure-for-test",
+      "action": "deny",
+      "namespaces": ["test"],
+      "networkServices": ["secure-*"]
+    },
+    {
+      "name": "default-ns",
+      "action": "allow",
+      "spiffeIds": ["spiffe://example.org/ns/*/sa/*"],
+      "endpointLabels": {"app": "icmp-responder"}
+    }
+  ]
+}`
+
+func TestPolicyAuthorizer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "authz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	policyFile := filepath.Join(dir, "policy.json")
+	if err = ioutil.WriteFile(policyFile, []byte(testPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadPolicy(policyFile)
+	if err != nil {
+		t.Fatalf("LoadPolicy() failed: %v", err)
+	}
+	authorizer := NewPolicyAuthorizer(policy)
+
+	for _, tc := range []struct {
+		name    string
+		request *Request
+		allowed bool
+	}{
+		{
+			name: "allowed",
+			request: &Request{
+				SpiffeID:       "spiffe://example.org/ns/default/sa/nsc",
+				Namespace:      "default",
+				NetworkService: "secure-intranet",
+				EndpointLabels: map[string]string{"app": "icmp-responder", "version": "1"},
+			},
+			allowed: true,
+		},
+		{
+			name: "denied by rule",
+			request: &Request{
+				SpiffeID:       "spiffe://example.org/ns/test/sa/nsc",
+				Namespace:      "test",
+				NetworkService: "secure-intranet",
+				EndpointLabels: map[string]string{"app": "icmp-responder"},
+			},
+		},
+		{
+			name: "denied by default",
+			request: &Request{
+				SpiffeID:       "spiffe://example.org/ns/default/sa/nsc",
+				Namespace:      "default",
+				NetworkService: "icmp-responder",
+				EndpointLabels: map[string]string{"app": "vpn-gateway"},
+			},
+		},
+	} {
+		err := authorizer.Authorize(tc.request)
+		if tc.allowed && err != nil {
+			t.Errorf("%s: Authorize() failed: %v", tc.name, err)
+		}
+		if !tc.allowed && err == nil {
+			t.Errorf("%s: Authorize() should fail", tc.name)
+		}
+	}
+}
+
+func TestLoadPolicyInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "authz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	for i, policy := range []string{
+		`{`,
+		`{"rules": []}`,
+		`{"defaultAction": "allow", "rules": [{"action": "permit"}]}`,
+		`{"defaultAction": "allow", "rules": [{"action": "deny", "networkServices": ["["]}]}`,
+	} {
+		policyFile := filepath.Join(dir, "policy.json")
+		if err = ioutil.WriteFile(policyFile, []byte(policy), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = LoadPolicy(policyFile); err == nil {
+			t.Errorf("LoadPolicy() should fail for policy %d", i)
+		}
+	}
+	if _, err = LoadPolicy(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadPolicy() should fail for missing file")
+	}
+}
+
+func TestNilPolicyAuthorizer(t *testing.T) {
+	if err := NewPolicyAuthorizer(nil).Authorize(&Request{NetworkService: "icmp-responder"}); err != nil {
+		t.Errorf("Authorize() failed: %v", err)
+	}
+	if err := NewDenyAllAuthorizer().Authorize(&Request{NetworkService: "icmp-responder"}); err == nil {
+		t.Error("Authorize() should fail")
+	}
+}
+
+func TestAdminAuthorizer(t *testing.T) {
+	authorizer := NewAdminAuthorizer([]string{"spiffe://example.org/ns/nsm-system/sa/*"})
+	if err := authorizer.Authorize(&Request{SpiffeID: "spiffe://example.org/ns/nsm-system/sa/nsmctl"}); err != nil {
+		t.Errorf("Authorize() failed: %v", err)
+	}
+	for _, id := range []string{"", "spiffe://example.org/ns/default/sa/nsc"} {
+		if err := authorizer.Authorize(&Request{SpiffeID: id}); err == nil {
+			t.Errorf("Authorize() should fail for %q", id)
+		}
+	}
+	if err := NewAdminAuthorizer(nil).Authorize(&Request{}); err == nil {
+		t.Error("Authorize() should fail if there are no administrators")
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/authz"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
+)
+
+// authorizationService checks if the client is allowed to connect to the endpoints of the network service
+type authorizationService struct {
+	model           model.Model
+	serviceRegistry serviceregistry.ServiceRegistry
+}
+
+// NewAuthorizationService - creates a service to authorize request to the endpoints of the requested network service,
+// should precede the forwarder service, so nothing is allocated for denied requests. Endpoints the client is denied to
+// connect to are ignored by the endpoint selector, request fails with codes.PermissionDenied if all are denied
+func NewAuthorizationService(model model.Model, serviceRegistry serviceregistry.ServiceRegistry) networkservice.NetworkServiceServer {
+	return &authorizationService{
+		model:           model,
+		serviceRegistry: serviceRegistry,
+	}
+}
+
+func (as *authorizationService) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	conn := request.GetConnection()
+	endpoints, err := as.candidateEndpoints(ctx, conn)
+	if err != nil {
+		Log(ctx).Errorf("AuthorizationService: failed to find endpoints of %s: %v", conn.GetNetworkService(), err)
+		return nil, err
+	}
+
+	spiffeID := originatorSpiffeID(conn.GetPath())
+	ignoredEndpoints := IgnoredEndpoints(ctx)
+	ctx = WithIgnoredEndpoints(ctx, ignoredEndpoints)
+
+	var denyErr error
+	allowed := 0
+	for _, endpoint := range endpoints {
+		if ignoredEndpoints[endpoint.GetEndpointNSMName()] != nil {
+			continue
+		}
+		authzRequest := &authz.Request{
+			SpiffeID:       spiffeID,
+			Namespace:      spiffeNamespace(spiffeID),
+			ClientLabels:   conn.GetLabels(),
+			NetworkService: conn.GetNetworkService(),
+			EndpointName:   endpoint.GetNetworkServiceEndpoint().GetName(),
+			EndpointLabels: endpoint.GetNetworkServiceEndpoint().GetLabels(),
+		}
+		if denyErr = as.serviceRegistry.Authorizer().Authorize(authzRequest); denyErr != nil {
+			Log(ctx).Infof("AuthorizationService: endpoint is ignored: %v", denyErr)
+			ignoredEndpoints[endpoint.GetEndpointNSMName()] = endpoint
+			continue
+		}
+		allowed++
+	}
+	if allowed == 0 && denyErr != nil {
+		Log(ctx).Errorf("AuthorizationService: request is denied: %v", denyErr)
+		return nil, status.Error(codes.PermissionDenied, denyErr.Error())
+	}
+
+	return ProcessNext(ctx, request)
+}
+
+func (as *authorizationService) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
+	return ProcessClose(ctx, connection)
+}
+
+// candidateEndpoints - returns the endpoint requested by the Remote NSM or all endpoints of the network service
+func (as *authorizationService) candidateEndpoints(ctx context.Context, conn *connection.Connection) ([]*registry.NSERegistration, error) {
+	targetEndpoint := conn.GetNetworkServiceEndpointName()
+	if targetEndpoint != "" {
+		if endpoint := as.model.GetEndpoint(targetEndpoint); endpoint != nil {
+			return []*registry.NSERegistration{endpoint.Endpoint}, nil
+		}
+	}
+
+	discoveryClient, err := as.serviceRegistry.DiscoveryClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := discoveryClient.FindNetworkService(ctx, &registry.FindNetworkServiceRequest{
+		NetworkServiceName: conn.GetNetworkService(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	endpoints := make([]*registry.NSERegistration, 0, len(response.GetNetworkServiceEndpoints()))
+	for _, endpoint := range response.GetNetworkServiceEndpoints() {
+		if targetEndpoint != "" && endpoint.GetName() != targetEndpoint {
+			continue
+		}
+		endpoints = append(endpoints, &registry.NSERegistration{
+			NetworkServiceManager:  response.GetNetworkServiceManagers()[endpoint.GetNetworkServiceManagerName()],
+			NetworkServiceEndpoint: endpoint,
+			NetworkService:         response.GetNetworkService(),
+		})
+	}
+	return endpoints, nil
+}
+
+// originatorSpiffeID - returns SPIFFE ID the first path segment is signed with. The path is verified by the path
+// verifier on Request, so the token is not verified again here: it could be already expired on heal
+func originatorSpiffeID(path *connection.Path) string {
+	segments := path.GetPathSegments()
+	if len(segments) == 0 || segments[0].GetToken() == "" {
+		return ""
+	}
+	claims, err := security.ParseToken(segments[0].GetToken())
+	if err != nil {
+		return ""
+	}
+	return claims.Subject
+}
+
+// spiffeNamespace - returns namespace of the Kubernetes workload SPIFFE ID, e.g. spiffe://example.org/ns/default/sa/nsc,
+// empty if it has none
+func spiffeNamespace(spiffeID string) string {
+	id, err := url.Parse(spiffeID)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(id.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i += 2 {
+		if segments[i] == "ns" {
+			return segments[i+1]
+		}
+	}
+	return ""
+}
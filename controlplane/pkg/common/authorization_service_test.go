@@ -0,0 +1,143 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/authz"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+)
+
+type authzServiceRegistry struct {
+	serviceregistry.ServiceRegistry
+	authorizer authz.Authorizer
+	response   *registry.FindNetworkServiceResponse
+}
+
+func (r *authzServiceRegistry) Authorizer() authz.Authorizer {
+	return r.authorizer
+}
+
+func (r *authzServiceRegistry) DiscoveryClient(context.Context) (registry.NetworkServiceDiscoveryClient, error) {
+	return &authzDiscoveryClient{response: r.response}, nil
+}
+
+type authzDiscoveryClient struct {
+	registry.NetworkServiceDiscoveryClient
+	response *registry.FindNetworkServiceResponse
+}
+
+func (c *authzDiscoveryClient) FindNetworkService(context.Context, *registry.FindNetworkServiceRequest, ...grpc.CallOption) (*registry.FindNetworkServiceResponse, error) {
+	return c.response, nil
+}
+
+type ignoredEndpointsRecorder struct {
+	ignored map[registry.EndpointNSMName]*registry.NSERegistration
+}
+
+func (r *ignoredEndpointsRecorder) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	r.ignored = IgnoredEndpoints(ctx)
+	return request.GetConnection(), nil
+}
+
+func (r *ignoredEndpointsRecorder) Close(context.Context, *connection.Connection) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func newAuthzServiceRegistry(policy *authz.Policy) *authzServiceRegistry {
+	return &authzServiceRegistry{
+		authorizer: authz.NewPolicyAuthorizer(policy),
+		response: &registry.FindNetworkServiceResponse{
+			NetworkService: &registry.NetworkService{Name: "ns"},
+			NetworkServiceManagers: map[string]*registry.NetworkServiceManager{
+				"nsm": {Name: "nsm", Url: "nsm:5001"},
+			},
+			NetworkServiceEndpoints: []*registry.NetworkServiceEndpoint{
+				{Name: "nse-1", NetworkServiceManagerName: "nsm", Labels: map[string]string{"app": "secure"}},
+				{Name: "nse-2", NetworkServiceManagerName: "nsm", Labels: map[string]string{"app": "test"}},
+			},
+		},
+	}
+}
+
+func authorizationRequest(labels map[string]string) *networkservice.NetworkServiceRequest {
+	return &networkservice.NetworkServiceRequest{
+		Connection: &connection.Connection{
+			Id:             "1",
+			NetworkService: "ns",
+			Labels:         labels,
+		},
+	}
+}
+
+func TestAuthorizationServiceIgnoresDeniedEndpoints(t *testing.T) {
+	g := NewWithT(t)
+
+	reg := newAuthzServiceRegistry(&authz.Policy{
+		DefaultAction: authz.Allow,
+		Rules: []*authz.Rule{
+			{Action: authz.Deny, EndpointLabels: map[string]string{"app": "secure"}},
+		},
+	})
+	next := &ignoredEndpointsRecorder{}
+	ctx := WithNext(context.Background(), next)
+
+	_, err := NewAuthorizationService(model.NewModel(), reg).Request(ctx, authorizationRequest(nil))
+	g.Expect(err).To(BeNil())
+	g.Expect(next.ignored).To(HaveLen(1))
+	g.Expect(next.ignored).To(HaveKey(registry.EndpointNSMName("nse-1:nsm:5001")))
+}
+
+func TestAuthorizationServiceDeniesNamespaceFromLabels(t *testing.T) {
+	g := NewWithT(t)
+
+	// Client can't get into the allowed namespace by setting the label, there is no verified SPIFFE ID in insecure mode
+	reg := newAuthzServiceRegistry(&authz.Policy{
+		DefaultAction: authz.Deny,
+		Rules: []*authz.Rule{
+			{Action: authz.Allow, Namespaces: []string{"default"}},
+		},
+	})
+	next := &ignoredEndpointsRecorder{}
+	ctx := WithNext(context.Background(), next)
+
+	_, err := NewAuthorizationService(model.NewModel(), reg).Request(ctx,
+		authorizationRequest(map[string]string{connection.NamespaceKey: "default"}))
+	g.Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	g.Expect(next.ignored).To(BeNil())
+}
+
+func TestSpiffeNamespace(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(spiffeNamespace("spiffe://example.org/ns/default/sa/nsc")).To(Equal("default"))
+	g.Expect(spiffeNamespace("spiffe://example.org/sa/nsc/ns/test")).To(Equal("test"))
+	g.Expect(spiffeNamespace("spiffe://example.org/nsc")).To(BeEmpty())
+	g.Expect(spiffeNamespace("")).To(BeEmpty())
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	sdkcommon "github.com/networkservicemesh/networkservicemesh/sdk/common"
+)
+
+// IsLeaseRefresh checks if the request only refreshes the lease of the Ready client connection
+func IsLeaseRefresh(clientConnection *model.ClientConnection, request *networkservice.NetworkServiceRequest) bool {
+	return clientConnection.ConnectionState == model.ClientConnectionReady &&
+		sdkcommon.IsLeaseRefresh(request, clientConnection.GetConnectionSource())
+}
+
+// RefreshLease stores the refreshed lease from the request path into the client connection and returns its source
+// connection, the connection itself is not changed
+func RefreshLease(ctx context.Context, m model.Model, clientConnection *model.ClientConnection, request *networkservice.NetworkServiceRequest) *connection.Connection {
+	Log(ctx).Infof("NSM:(%v) Refreshing lease of the connection", clientConnection.GetID())
+
+	m.ApplyClientConnectionChanges(ctx, clientConnection.GetID(), func(modelCC *model.ClientConnection) {
+		if modelCC.Request == nil {
+			modelCC.Request = request
+			return
+		}
+		leaseRequest := modelCC.Request.Clone()
+		leaseRequest.Connection.Path = request.GetConnection().GetPath()
+		modelCC.Request = leaseRequest
+	})
+	return clientConnection.GetConnectionSource()
+}
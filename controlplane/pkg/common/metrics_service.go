@@ -0,0 +1,60 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
+)
+
+// metricsService tracks latencies and errors of the calls handled by the rest of the chain
+type metricsService struct {
+	name string
+}
+
+// NewMetricsService - creates a service to report Request/Close metrics labeled with the service name,
+// should follow the authorization service, so network service labels are not taken from unauthorized requests
+func NewMetricsService(name string) networkservice.NetworkServiceServer {
+	return &metricsService{
+		name: name,
+	}
+}
+
+func (ms *metricsService) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	networkService := request.GetConnection().GetNetworkService()
+	start := time.Now()
+	conn, err := ProcessNext(ctx, request)
+	metrics.ObserveRequest(ms.name, networkService, start, err)
+	return conn, err
+}
+
+func (ms *metricsService) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
+	networkService := connection.GetNetworkService()
+	if modelConnection := ModelConnection(ctx); modelConnection != nil {
+		networkService = modelConnection.GetNetworkService()
+	}
+	start := time.Now()
+	result, err := ProcessClose(ctx, connection)
+	metrics.ObserveClose(ms.name, networkService, start, err)
+	return result, err
+}
@@ -16,7 +16,15 @@
 
 package common
 
-import "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+import (
+	"context"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	sdkcommon "github.com/networkservicemesh/networkservicemesh/sdk/common"
+)
 
 // These functions are intentionally not put in api path_helper because they are particular to adapting the existing
 // code to using Path and so intentionally overly simplified.
@@ -33,3 +41,39 @@ func AppendStrings2Path(path *connection.Path, strs ...string) *connection.Path
 	}
 	return path
 }
+
+// AppendPath returns a copy of the incoming request path continued by NSMgr, so the tokens of the previous hops are
+// kept in the chain. The segment of NSMgr is appended unless the previous NSMgr has already added it, the names of the
+// next NSMgrs follow it. Index of the result points to the segment of NSMgr
+func AppendPath(path *connection.Path, nsmName string, nextNsmNames ...string) *connection.Path {
+	result := &connection.Path{}
+	if len(path.GetPathSegments()) > 0 {
+		result = path.Clone()
+		result.Index++
+	}
+	index := int(result.GetIndex())
+	if index >= len(result.GetPathSegments()) || result.GetPathSegments()[index].GetName() != nsmName {
+		result.PathSegments = append(result.GetPathSegments()[:index], &connection.PathSegment{Name: nsmName})
+	}
+	result.PathSegments = result.GetPathSegments()[:index+1]
+	return AppendStrings2Path(result, nextNsmNames...)
+}
+
+// SignPath sets the lease of the current PathSegment of the connection and signs it with the X.509 SVID of NSMgr for the
+// next hop, see sdk/common.SignPath. NSMgr always leases the connections it requests, since it refreshes their leases
+// itself
+func SignPath(ctx context.Context, conn *connection.Connection, nextHop string) error {
+	if err := sdkcommon.LeasePath(conn.GetPath()); err != nil {
+		return err
+	}
+	return sdkcommon.SignPath(ctx, tools.GetConfig().SecurityProvider, conn, nextHop)
+}
+
+// NextHop returns name of the next hop NSMgr sends the request for the endpoint to: the endpoint if it is local, its
+// NSMgr otherwise
+func NextHop(nseManager nsm.NetworkServiceEndpointManager, endpoint *registry.NSERegistration) string {
+	if nseManager.IsLocalEndpoint(endpoint) {
+		return endpoint.GetNetworkServiceEndpoint().GetName()
+	}
+	return endpoint.GetNetworkServiceManager().GetName()
+}
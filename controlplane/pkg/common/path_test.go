@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+)
+
+func pathNames(path *connection.Path) []string {
+	names := make([]string, 0, len(path.GetPathSegments()))
+	for _, segment := range path.GetPathSegments() {
+		names = append(names, segment.GetName())
+	}
+	return names
+}
+
+func TestAppendPath(t *testing.T) {
+	g := NewWithT(t)
+
+	clientPath := &connection.Path{
+		PathSegments: []*connection.PathSegment{{Name: "nsc", Token: "nsc-token"}},
+	}
+
+	// Local NSMgr requests Remote NSMgr
+	srcPath := AppendPath(clientPath, "nsmgr-1", "nsmgr-2")
+	g.Expect(pathNames(srcPath)).To(Equal([]string{"nsc", "nsmgr-1", "nsmgr-2"}))
+	g.Expect(srcPath.GetIndex()).To(Equal(uint32(1)))
+	g.Expect(srcPath.GetPathSegments()[0].GetToken()).To(Equal("nsc-token"))
+	g.Expect(pathNames(clientPath)).To(Equal([]string{"nsc"}))
+
+	conn := &connection.Connection{Path: srcPath}
+	g.Expect(conn.IsRemote()).To(BeTrue())
+	g.Expect(conn.GetSourceNetworkServiceManagerName()).To(Equal("nsmgr-1"))
+	g.Expect(conn.GetDestinationNetworkServiceManagerName()).To(Equal("nsmgr-2"))
+
+	// Remote NSMgr requests its local NSE, the segment is already added by the Local NSMgr
+	srcPath.GetPathSegments()[1].Token = "nsmgr-1-token"
+	dstPath := AppendPath(srcPath, "nsmgr-2")
+	g.Expect(pathNames(dstPath)).To(Equal([]string{"nsc", "nsmgr-1", "nsmgr-2"}))
+	g.Expect(dstPath.GetIndex()).To(Equal(uint32(2)))
+	g.Expect(dstPath.GetPathSegments()[1].GetToken()).To(Equal("nsmgr-1-token"))
+	g.Expect((&connection.Connection{Path: dstPath}).IsRemote()).To(BeFalse())
+
+	// Local NSMgr requests its local NSE
+	localPath := AppendPath(clientPath, "nsmgr-1")
+	g.Expect(pathNames(localPath)).To(Equal([]string{"nsc", "nsmgr-1"}))
+	g.Expect(localPath.GetIndex()).To(Equal(uint32(1)))
+	g.Expect((&connection.Connection{Path: localPath}).IsRemote()).To(BeFalse())
+
+	// Client doesn't send the path
+	g.Expect(pathNames(AppendPath(nil, "nsmgr-1", "nsmgr-2"))).To(Equal([]string{"nsmgr-1", "nsmgr-2"}))
+	g.Expect(AppendPath(nil, "nsmgr-1").GetIndex()).To(Equal(uint32(0)))
+}
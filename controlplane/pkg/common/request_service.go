@@ -18,9 +18,13 @@ import (
 	"context"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+	sdkcommon "github.com/networkservicemesh/networkservicemesh/sdk/common"
 )
 
 // requestValidator -
@@ -45,3 +49,63 @@ func (cce *requestValidator) Close(ctx context.Context, connection *connection.C
 func NewRequestValidator() networkservice.NetworkServiceServer {
 	return &requestValidator{}
 }
+
+// pathVerifier -
+type pathVerifier struct {
+	model      model.Model
+	verifyPeer bool
+}
+
+func (cce *pathVerifier) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	err := cce.verify(ctx, request.GetConnection())
+
+	if err != nil {
+		Log(ctx).Error(err)
+		return nil, err
+	}
+	return ProcessNext(ctx, request)
+}
+
+func (cce *pathVerifier) verify(ctx context.Context, conn *connection.Connection) error {
+	provider := tools.GetConfig().SecurityProvider
+	if provider == nil {
+		return nil
+	}
+	if ModelConnection(ctx) != nil {
+		// Heal and restore request the connection NSMgr already has in its model, the path has been verified then
+		return nil
+	}
+	path := conn.GetPath()
+	if !cce.verifyPeer && path.GetIndex() != 0 {
+		// Local clients always originate the connections, NSMgrs send the requests to the remote API
+		return errors.Errorf("path index %d of the local request is not the client one", path.GetIndex())
+	}
+	if err := sdkcommon.VerifyPath(ctx, provider, conn, cce.model.GetNsm().GetName()); err != nil {
+		return err
+	}
+	if cce.verifyPeer {
+		return sdkcommon.VerifyPathPeer(ctx, path)
+	}
+	return nil
+}
+
+func (cce *pathVerifier) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
+	return ProcessClose(ctx, connection)
+}
+
+// NewPathVerifier - creates a service to verify signed tokens of the request path on the local API of NSMgr, so a
+// request can't impersonate another client
+func NewPathVerifier(model model.Model) networkservice.NetworkServiceServer {
+	return &pathVerifier{
+		model: model,
+	}
+}
+
+// NewRemotePathVerifier - creates a service to verify signed tokens of the request path on the remote API of NSMgr, the
+// current path segment should be signed by the mTLS peer sending the request
+func NewRemotePathVerifier(model model.Model) networkservice.NetworkServiceServer {
+	return &pathVerifier{
+		model:      model,
+		verifyPeer: true,
+	}
+}
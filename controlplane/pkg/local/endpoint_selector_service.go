@@ -22,6 +22,8 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connectioncontext"
@@ -109,6 +111,10 @@ func (cce *endpointSelectorService) Request(ctx context.Context, request *networ
 func (cce *endpointSelectorService) combineErrors(span spanhelper.SpanHelper, err, lastError error) (*connection.Connection, error) {
 	if lastError != nil {
 		span.LogError(lastError)
+		// Client should know it is not authorized to use the last NSE
+		if status.Code(err) == codes.PermissionDenied {
+			return nil, status.Errorf(codes.PermissionDenied, "NSM:(7.1.5) %v. Last NSE Error: %v", err, lastError)
+		}
 		return nil, errors.Errorf("NSM:(7.1.5) %v. Last NSE Error: %v", err, lastError)
 	}
 	return nil, err
@@ -146,6 +152,10 @@ func (cce *endpointSelectorService) checkNSEUpdateIsRequired(ctx context.Context
 			if _, err := common.ProcessClose(ctx, request.GetConnection()); err != nil {
 				logger.Errorf("NSM:(4.1) Error during close of NSE during Request.Upgrade %v Existing connection: %v error %v", request, clientConnection, err)
 			}
+		} else if _, ok := common.IgnoredEndpoints(ctx)[clientConnection.Endpoint.GetEndpointNSMName()]; ok {
+			// Current NSE is ignored, f.e. it is draining, so we need to request another one.
+			requestNSEOnUpdate = true
+			logger.Infof("Current NSE is ignored, NSE request is required")
 		} else {
 			// 4.2 Check if NSE is still required, if some more context requests are different.
 			requestNSEOnUpdate = cce.checkNeedNSERequest(logger, request.Connection, clientConnection, dp)
@@ -21,6 +21,8 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	mechanismCommon "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/common"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
@@ -97,6 +99,9 @@ func (cce *endpointService) Request(ctx context.Context, request *networkservice
 	} else {
 		message = cce.createRemoteNSMRequest(endpoint, request.Connection, common.RemoteMechanisms(ctx), clientConnection)
 	}
+	if err = common.SignPath(ctx, message.GetConnection(), common.NextHop(cce.nseManager, endpoint)); err != nil {
+		return nil, errors.Errorf("NSM:(7.2.6.2) Failed to sign request path. %v", err)
+	}
 	logger.Infof("NSM:(7.2.6.2) Requesting NSE with request %v", message)
 
 	span := spanhelper.FromContext(ctx, "nse.request")
@@ -107,7 +112,12 @@ func (cce *endpointService) Request(ctx context.Context, request *networkservice
 	nseConn, e := client.Request(ctx, message)
 	span.LogObject("nse.response", nseConn)
 	if e != nil {
-		e = errors.Errorf("NSM:(7.2.6.2.1) error requesting networkservice from %+v with message %#v error: %s", endpoint, message, e)
+		if status.Code(e) == codes.PermissionDenied {
+			// Remote NSMgr doesn't authorize the client
+			e = status.Errorf(codes.PermissionDenied, "NSM:(7.2.6.2.1) error requesting networkservice from %+v with message %#v error: %s", endpoint, message, e)
+		} else {
+			e = errors.Errorf("NSM:(7.2.6.2.1) error requesting networkservice from %+v with message %#v error: %s", endpoint, message, e)
+		}
 		span.LogError(e)
 		return nil, e
 	}
@@ -146,7 +156,7 @@ func (cce *endpointService) createLocalNSERequest(endpoint *registry.NSERegistra
 					NetworkService: localDst.NetworkService,
 					Context:        localDst.GetContext(),
 					Labels:         localDst.GetLabels(),
-					Path:           common.Strings2Path(cce.model.GetNsm().GetName()),
+					Path:           common.AppendPath(requestConn.GetPath(), cce.model.GetNsm().GetName()),
 				},
 				MechanismPreferences: localMechanisms,
 			}
@@ -157,7 +167,7 @@ func (cce *endpointService) createLocalNSERequest(endpoint *registry.NSERegistra
 		Connection: &connection.Connection{
 			Id:             cce.model.ConnectionID(), // ID for NSE is managed by NSMgr
 			NetworkService: endpoint.GetNetworkService().GetName(),
-			Path:           common.Strings2Path(cce.model.GetNsm().GetName()),
+			Path:           common.AppendPath(requestConn.GetPath(), cce.model.GetNsm().GetName()),
 			Context:        requestConn.GetContext(),
 			Labels:         requestConn.GetLabels(),
 		},
@@ -177,7 +187,7 @@ func (cce *endpointService) createRemoteNSMRequest(endpoint *registry.NSERegistr
 					Context:                    remoteDst.GetContext(),
 					Labels:                     remoteDst.GetLabels(),
 					NetworkServiceEndpointName: endpoint.GetNetworkServiceEndpoint().GetName(),
-					Path: common.Strings2Path(
+					Path: common.AppendPath(requestConn.GetPath(),
 						cce.model.GetNsm().GetName(),                  // src
 						endpoint.GetNetworkServiceManager().GetName(), // dst
 					),
@@ -194,7 +204,7 @@ func (cce *endpointService) createRemoteNSMRequest(endpoint *registry.NSERegistr
 			Context:                    requestConn.GetContext(),
 			Labels:                     requestConn.GetLabels(),
 			NetworkServiceEndpointName: endpoint.GetNetworkServiceEndpoint().GetName(),
-			Path: common.Strings2Path(
+			Path: common.AppendPath(requestConn.GetPath(),
 				cce.model.GetNsm().GetName(),                  // src
 				endpoint.GetNetworkServiceManager().GetName(), // dst
 			),
@@ -0,0 +1,115 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+// forwarderHealth remembers forwarders failed to program connections. The counter is reset on the first successful programming.
+type forwarderHealth struct {
+	sync.RWMutex
+	failures map[string]int
+}
+
+func newForwarderHealth() *forwarderHealth {
+	return &forwarderHealth{
+		failures: make(map[string]int),
+	}
+}
+
+func (h *forwarderHealth) failed(name string) {
+	h.Lock()
+	defer h.Unlock()
+	h.failures[name]++
+}
+
+func (h *forwarderHealth) succeeded(name string) {
+	h.Lock()
+	defer h.Unlock()
+	delete(h.failures, name)
+}
+
+func (h *forwarderHealth) failureCount(name string) int {
+	h.RLock()
+	defer h.RUnlock()
+	return h.failures[name]
+}
+
+type rankedForwarder struct {
+	forwarder  *model.Forwarder
+	preference int
+	failures   int
+	load       int
+}
+
+// rankForwarders orders not draining forwarders supporting requested mechanisms by the mechanism preference order of the request,
+// then by the recent programming failures and then by the amount of connections programmed on the forwarder
+func rankForwarders(mdl model.Model, health *forwarderHealth, request *networkservice.NetworkServiceRequest) []*model.Forwarder {
+	var ranked []*rankedForwarder
+	for _, dp := range mdl.SelectForwarders(nil) {
+		if dp.Draining {
+			continue
+		}
+		preference := mechanismPreference(request, dp)
+		if preference < 0 {
+			continue
+		}
+		ranked = append(ranked, &rankedForwarder{
+			forwarder:  dp,
+			preference: preference,
+			failures:   health.failureCount(dp.RegisteredName),
+			load:       mdl.ForwarderConnectionCount(dp.RegisteredName),
+		})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.preference != b.preference {
+			return a.preference < b.preference
+		}
+		if a.failures != b.failures {
+			return a.failures < b.failures
+		}
+		if a.load != b.load {
+			return a.load < b.load
+		}
+		return a.forwarder.RegisteredName < b.forwarder.RegisteredName
+	})
+
+	result := make([]*model.Forwarder, 0, len(ranked))
+	for _, r := range ranked {
+		result = append(result, r.forwarder)
+	}
+	return result
+}
+
+// mechanismPreference returns index of the first requested mechanism supported by the forwarder, -1 if none is supported
+func mechanismPreference(request *networkservice.NetworkServiceRequest, dp *model.Forwarder) int {
+	for i, m := range request.GetRequestMechanismPreferences() {
+		for _, dpMechanism := range dp.LocalMechanisms {
+			if dpMechanism.GetType() == m.GetType() {
+				return i
+			}
+		}
+	}
+	return -1
+}
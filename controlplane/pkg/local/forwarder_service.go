@@ -17,12 +17,12 @@ package local
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/srv6"
@@ -50,18 +50,7 @@ const (
 type forwarderService struct {
 	serviceRegistry serviceregistry.ServiceRegistry
 	model           model.Model
-}
-
-func (cce *forwarderService) selectForwarder(request *networkservice.NetworkServiceRequest) (*model.Forwarder, error) {
-	dp, err := cce.model.SelectForwarder(func(dp *model.Forwarder) bool {
-		for _, m := range request.GetRequestMechanismPreferences() {
-			if cce.findMechanism(dp.LocalMechanisms, m.GetType()) != nil {
-				return true
-			}
-		}
-		return false
-	})
-	return dp, err
+	health          *forwarderHealth
 }
 
 func (cce *forwarderService) findMechanism(mechanismPreferences []*connection.Mechanism, mechanismType string) *connection.Mechanism {
@@ -105,29 +94,55 @@ func (cce *forwarderService) Request(ctx context.Context, request *networkservic
 		return nil, err
 	}
 
-	// TODO: We could iterate forwarders to match required one, if failed with first one.
-	dp, err := cce.selectForwarder(request)
-	if err != nil {
-		return nil, err
+	// 4. Rank forwarders, the next one is tried if programming of the previous one fails
+	forwarders := rankForwarders(cce.model, cce.health, request)
+	if len(forwarders) == 0 {
+		return nil, errors.New("no appropriate forwarders found")
 	}
 
-	// 5. Select a local forwarder and put it into conn object
-	err = cce.updateMechanism(request, dp)
-	if err != nil {
-		return nil, errors.Errorf("NSM:(5.1) %v", err)
-	}
+	for i, dp := range forwarders {
+		// 5. Select a local forwarder and put it into conn object
+		if mechanismErr := cce.updateMechanism(request, dp); mechanismErr != nil {
+			return nil, errors.Errorf("NSM:(5.1) %v", mechanismErr)
+		}
 
-	span.LogObject("dataplane", dp)
+		span.LogObject("dataplane", dp)
 
-	ctx = common.WithForwarder(ctx, dp)
-	ctx = common.WithRemoteMechanisms(ctx, cce.prepareRemoteMechanisms(request, dp))
-	conn, connErr := common.ProcessNext(ctx, request)
-	if connErr != nil {
-		return conn, connErr
-	}
+		dpCtx := common.WithForwarder(ctx, dp)
+		dpCtx = common.WithRemoteMechanisms(dpCtx, cce.prepareRemoteMechanisms(request, dp))
+		conn, connErr := common.ProcessNext(dpCtx, request)
+		if connErr != nil {
+			// Nothing is programmed, but remote mechanisms are prepared, so release all allocated for them
+			cce.releaseMechanisms(request.GetConnection().GetId())
+			return conn, connErr
+		}
+		cce.releaseUnselectedMechanisms(request, clientConnection)
 
-	// We need to program forwarder.
-	return cce.programForwarder(ctx, conn, dp, clientConnection)
+		// We need to program forwarder. Only the last candidate is retried, the others get a single attempt
+		// so that the fallback does not wait for all the retries of a broken forwarder.
+		attempts := 1
+		if i == len(forwarders)-1 {
+			attempts = ForwarderRetryCount
+		}
+		programmed, err := cce.programForwarder(dpCtx, conn, dp, clientConnection, attempts)
+		if err == nil {
+			cce.health.succeeded(dp.RegisteredName)
+			return programmed, nil
+		}
+		cce.health.failed(dp.RegisteredName)
+
+		if i == len(forwarders)-1 || ctx.Err() != nil {
+			return programmed, err
+		}
+		logger.Errorf("NSM:(9.4) Failed to program forwarder %v: %v. Falling back to forwarder %v",
+			dp.RegisteredName, err, forwarders[i+1].RegisteredName)
+		// NSE connection is set up for the mechanisms of the failed forwarder, so it should be closed before the next try.
+		if _, closeErr := common.ProcessClose(dpCtx, conn); closeErr != nil {
+			logger.Errorf("NSM:(9.4.1) Failed to close NSE connection: %v", closeErr)
+		}
+		request.Connection.Mechanism = nil
+	}
+	return nil, errors.New("no appropriate forwarders found")
 }
 
 // prepareRemoteMechanisms fills mechanism properties
@@ -138,9 +153,17 @@ func (cce *forwarderService) prepareRemoteMechanisms(request *networkservice.Net
 		m := mechanism.Clone()
 		switch m.GetType() {
 		case srv6.MECHANISM:
-			cce.prepareSRv6Mechanism(m, request)
+			if err := cce.prepareSRv6Mechanism(m, request); err != nil {
+				// Do not offer SRv6 without SIDs
+				logrus.Errorf("NSM:(5.2) Failed to prepare SRv6 mechanism: %v", err)
+				continue
+			}
 		case wireguard.MECHANISM:
-			cce.prepareWireguardMechanism(m, request)
+			if err := cce.prepareWireguardMechanism(m, request); err != nil {
+				// Do not offer Wireguard without a listen port
+				logrus.Errorf("NSM:(5.2) Failed to prepare Wireguard mechanism: %v", err)
+				continue
+			}
 		}
 		mechanisms = append(mechanisms, m)
 	}
@@ -148,34 +171,53 @@ func (cce *forwarderService) prepareRemoteMechanisms(request *networkservice.Net
 	return mechanisms
 }
 
-func (cce *forwarderService) prepareSRv6Mechanism(m *connection.Mechanism, request *networkservice.NetworkServiceRequest) *connection.Mechanism {
+// releaseMechanisms releases VNI, SIDs and Wireguard port allocated for the connection
+func (cce *forwarderService) releaseMechanisms(connectionID string) {
+	cce.serviceRegistry.VniAllocator().Release(connectionID)
+	cce.serviceRegistry.SIDAllocator().Release(connectionID)
+	cce.serviceRegistry.WireguardPortAllocator().Release(connectionID)
+}
+
+// releaseUnselectedMechanisms releases SIDs and Wireguard port offered to the remote NSM, but not used by the mechanism it selected
+func (cce *forwarderService) releaseUnselectedMechanisms(request *networkservice.NetworkServiceRequest, clientConnection *model.ClientConnection) {
+	selected := clientConnection.Xcon.GetRemoteDestination().GetMechanism().GetType()
+	if selected != srv6.MECHANISM {
+		cce.serviceRegistry.SIDAllocator().Release(request.GetConnection().GetId())
+	}
+	if selected != wireguard.MECHANISM {
+		cce.serviceRegistry.WireguardPortAllocator().Release(request.GetConnection().GetId())
+	}
+}
+
+func (cce *forwarderService) prepareSRv6Mechanism(m *connection.Mechanism, request *networkservice.NetworkServiceRequest) error {
 	parameters := m.GetParameters()
 	if parameters == nil {
 		parameters = map[string]string{}
 	}
-	parameters[srv6.SrcBSID] = cce.serviceRegistry.SIDAllocator().SID(request.Connection.GetId())
-	parameters[srv6.SrcLocalSID] = cce.serviceRegistry.SIDAllocator().SID(request.Connection.GetId())
+	bsid, localSID, err := cce.serviceRegistry.SIDAllocator().SIDs(request.Connection.GetId())
+	if err != nil {
+		return err
+	}
+	parameters[srv6.SrcBSID] = bsid
+	parameters[srv6.SrcLocalSID] = localSID
 	m.Parameters = parameters
-	return m
+	return nil
 }
 
-func (cce *forwarderService) prepareWireguardMechanism(m *connection.Mechanism, request *networkservice.NetworkServiceRequest) *connection.Mechanism {
+func (cce *forwarderService) prepareWireguardMechanism(m *connection.Mechanism, request *networkservice.NetworkServiceRequest) error {
 	parameters := m.GetParameters()
 	if parameters == nil {
 		parameters = map[string]string{}
 	}
-
-	key, err := wgtypes.GeneratePrivateKey()
+	port, err := cce.serviceRegistry.WireguardPortAllocator().Port(request.Connection.GetId())
 	if err != nil {
-		return m
+		return err
 	}
 
-	parameters[wireguard.SrcPrivateKey] = key.String()
-	parameters[wireguard.SrcPublicKey] = key.PublicKey().String()
-
-	parameters[wireguard.SrcPort] = wireguard.AssignPort(request.Connection.Id)
-
-	return m
+	// SrcPublicKey comes from the forwarder, the private key never leaves it
+	parameters[wireguard.SrcPort] = strconv.Itoa(port)
+	m.Parameters = parameters
+	return nil
 }
 
 func (cce *forwarderService) doFailureClose(ctx context.Context) {
@@ -207,6 +249,8 @@ func (cce *forwarderService) Close(ctx context.Context, conn *connection.Connect
 }
 
 func (cce *forwarderService) performClose(ctx context.Context, cc *model.ClientConnection, logger logrus.FieldLogger) error {
+	// Release VNI restored, SIDs and Wireguard port allocated for the connection
+	cce.releaseMechanisms(cc.GetID())
 	// Close endpoints, etc
 	if cc.ForwarderState != model.ForwarderStateNone {
 		logger.Info("NSM.Forwarder: Closing cross connection on forwarder...")
@@ -229,7 +273,7 @@ func (cce *forwarderService) performClose(ctx context.Context, cc *model.ClientC
 	return nil
 }
 
-func (cce *forwarderService) programForwarder(ctx context.Context, conn *connection.Connection, dp *model.Forwarder, clientConnection *model.ClientConnection) (*connection.Connection, error) {
+func (cce *forwarderService) programForwarder(ctx context.Context, conn *connection.Connection, dp *model.Forwarder, clientConnection *model.ClientConnection, attempts int) (*connection.Connection, error) {
 	span := spanhelper.FromContext(ctx, "programForwarder")
 	defer span.Finish()
 	// We need to program forwarder.
@@ -250,7 +294,7 @@ func (cce *forwarderService) programForwarder(ctx context.Context, conn *connect
 	var newXcon *crossconnect.CrossConnect
 	// 9. We need to program forwarder with our values.
 	// 9.1 Sending updated request to forwarder.
-	for dpRetry := 0; dpRetry < ForwarderRetryCount; dpRetry++ {
+	for dpRetry := 0; dpRetry < attempts; dpRetry++ {
 		if ctx.Err() != nil {
 			cce.doFailureClose(ctx)
 			return nil, ctx.Err()
@@ -270,7 +314,7 @@ func (cce *forwarderService) programForwarder(ctx context.Context, conn *connect
 			attemptSpan.Logger().Errorf("NSM:(9.1.1) Forwarder request failed: %v retry: %v", err, dpRetry)
 
 			// Let's try again with a short delay
-			if dpRetry < ForwarderRetryCount-1 {
+			if dpRetry < attempts-1 {
 				<-time.After(ForwarderRetryDelay)
 				continue
 			}
@@ -322,5 +366,6 @@ func NewForwarderService(model model.Model, serviceRegistry serviceregistry.Serv
 	return &forwarderService{
 		model:           model,
 		serviceRegistry: serviceRegistry,
+		health:          newForwarderHealth(),
 	}
 }
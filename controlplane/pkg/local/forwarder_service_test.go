@@ -0,0 +1,289 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/srv6"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/sid"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/wgport"
+	forwarderapi "github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarder"
+)
+
+// testAllocator remembers values allocated for the connections to check nothing is leaked
+type testAllocator struct {
+	sync.Mutex
+	allocated map[string][]string
+}
+
+func newTestAllocator() *testAllocator {
+	return &testAllocator{allocated: make(map[string][]string)}
+}
+
+func (a *testAllocator) allocate(connectionID string) int {
+	a.Lock()
+	defer a.Unlock()
+	a.allocated[connectionID] = append(a.allocated[connectionID], connectionID)
+	return len(a.allocated[connectionID])
+}
+
+func (a *testAllocator) Release(connectionID string) {
+	a.Lock()
+	defer a.Unlock()
+	delete(a.allocated, connectionID)
+}
+
+func (a *testAllocator) size() int {
+	a.Lock()
+	defer a.Unlock()
+	return len(a.allocated)
+}
+
+type testVniAllocator struct{ *testAllocator }
+
+func (a testVniAllocator) Vni(connectionID, _, _ string) (uint32, error) {
+	return uint32(a.allocate(connectionID)), nil
+}
+
+func (a testVniAllocator) Restore(connectionID, _, _ string, _ uint32) error {
+	a.allocate(connectionID)
+	return nil
+}
+
+func (a testVniAllocator) ConnectionIDs() []string {
+	a.Lock()
+	defer a.Unlock()
+	ids := make([]string, 0, len(a.allocated))
+	for id := range a.allocated {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+type testSIDAllocator struct{ *testAllocator }
+
+func (a testSIDAllocator) SIDs(connectionID string) (bsid, localSID string, err error) {
+	return "fd25::" + strconv.Itoa(a.allocate(connectionID)), "fd25::" + strconv.Itoa(a.allocate(connectionID)), nil
+}
+
+func (a testSIDAllocator) Restore(connectionID, _ string) error {
+	a.allocate(connectionID)
+	return nil
+}
+
+type testPortAllocator struct{ *testAllocator }
+
+func (a testPortAllocator) Port(connectionID string) (int, error) {
+	return 51820 + a.allocate(connectionID), nil
+}
+
+func (a testPortAllocator) Restore(connectionID string, _ int) error {
+	a.allocate(connectionID)
+	return nil
+}
+
+type testForwarderClient struct {
+	forwarderapi.ForwarderClient
+	err      error
+	requests int
+}
+
+func (c *testForwarderClient) Request(_ context.Context, in *crossconnect.CrossConnect, _ ...grpc.CallOption) (*crossconnect.CrossConnect, error) {
+	c.requests++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return in, nil
+}
+
+func (c *testForwarderClient) Close(context.Context, *crossconnect.CrossConnect, ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+type testServiceRegistry struct {
+	serviceregistry.ServiceRegistry
+	forwarders map[string]*testForwarderClient
+	vni        *testAllocator
+	sid        *testAllocator
+	wgPort     *testAllocator
+}
+
+func newTestServiceRegistry() *testServiceRegistry {
+	return &testServiceRegistry{
+		forwarders: make(map[string]*testForwarderClient),
+		vni:        newTestAllocator(),
+		sid:        newTestAllocator(),
+		wgPort:     newTestAllocator(),
+	}
+}
+
+func (r *testServiceRegistry) WaitForForwarderAvailable(context.Context, model.Model, time.Duration) error {
+	return nil
+}
+
+func (r *testServiceRegistry) ForwarderConnection(_ context.Context, dp *model.Forwarder) (forwarderapi.ForwarderClient, *grpc.ClientConn, error) {
+	return r.forwarders[dp.RegisteredName], nil, nil
+}
+
+func (r *testServiceRegistry) VniAllocator() vni.VniAllocator {
+	return testVniAllocator{r.vni}
+}
+
+func (r *testServiceRegistry) SIDAllocator() sid.Allocator {
+	return testSIDAllocator{r.sid}
+}
+
+func (r *testServiceRegistry) WireguardPortAllocator() wgport.Allocator {
+	return testPortAllocator{r.wgPort}
+}
+
+type testNextService struct {
+	err         error
+	destination *connection.Connection
+	closed      int
+}
+
+func (s *testNextService) Request(ctx context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.destination != nil {
+		common.ModelConnection(ctx).Xcon.Destination = s.destination
+	}
+	return request.GetConnection().Clone(), nil
+}
+
+func (s *testNextService) Close(context.Context, *connection.Connection) (*empty.Empty, error) {
+	s.closed++
+	return &empty.Empty{}, nil
+}
+
+func addTestForwarder(mdl model.Model, reg *testServiceRegistry, name string, err error, remoteMechanisms ...*connection.Mechanism) *testForwarderClient {
+	mdl.AddForwarder(context.Background(), &model.Forwarder{
+		RegisteredName:       name,
+		LocalMechanisms:      []*connection.Mechanism{{Type: kernel.MECHANISM}},
+		RemoteMechanisms:     remoteMechanisms,
+		MechanismsConfigured: true,
+	})
+	client := &testForwarderClient{err: err}
+	reg.forwarders[name] = client
+	return client
+}
+
+func requestForwarderService(mdl model.Model, reg *testServiceRegistry, next *testNextService) (*model.ClientConnection, error) {
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &connection.Connection{
+			Id:             "1",
+			NetworkService: "ns",
+			Context:        &connectioncontext.ConnectionContext{},
+		},
+		MechanismPreferences: []*connection.Mechanism{{Type: kernel.MECHANISM}},
+	}
+	cc := &model.ClientConnection{
+		ConnectionID: "1",
+		Request:      request,
+		Xcon: &crossconnect.CrossConnect{
+			Id:     "1",
+			Source: request.GetConnection(),
+		},
+	}
+
+	ctx := common.WithModelConnection(context.Background(), cc)
+	ctx = common.WithNext(ctx, next)
+	_, err := NewForwarderService(mdl, reg).Request(ctx, request)
+	return cc, err
+}
+
+func TestForwarderServiceFallbackAfterFirstAttempt(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	reg := newTestServiceRegistry()
+	broken := addTestForwarder(mdl, reg, "forwarder-1", errors.New("forwarder is broken"))
+	healthy := addTestForwarder(mdl, reg, "forwarder-2", nil)
+	next := &testNextService{}
+
+	start := time.Now()
+	cc, err := requestForwarderService(mdl, reg, next)
+	g.Expect(err).To(BeNil())
+	g.Expect(time.Since(start)).To(BeNumerically("<", ForwarderRetryDelay))
+
+	g.Expect(broken.requests).To(Equal(1))
+	g.Expect(healthy.requests).To(Equal(1))
+	g.Expect(next.closed).To(Equal(1))
+	g.Expect(cc.ForwarderRegisteredName).To(Equal("forwarder-2"))
+	g.Expect(cc.ForwarderState).To(Equal(model.ForwarderStateReady))
+}
+
+func TestForwarderServiceReleasesUnselectedMechanisms(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	reg := newTestServiceRegistry()
+	addTestForwarder(mdl, reg, "forwarder", nil, &connection.Mechanism{Type: srv6.MECHANISM}, &connection.Mechanism{Type: wireguard.MECHANISM})
+	next := &testNextService{
+		destination: &connection.Connection{
+			Id:             "2",
+			NetworkService: "ns",
+			Mechanism:      &connection.Mechanism{Type: wireguard.MECHANISM},
+			Path:           &connection.Path{PathSegments: []*connection.PathSegment{{Name: "nsm1"}, {Name: "nsm2"}}},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := requestForwarderService(mdl, reg, next)
+		g.Expect(err).To(BeNil())
+		g.Expect(reg.sid.size()).To(Equal(0))
+		g.Expect(reg.wgPort.size()).To(Equal(1))
+	}
+}
+
+func TestForwarderServiceReleasesMechanismsOnFailedRequest(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	reg := newTestServiceRegistry()
+	fwd := addTestForwarder(mdl, reg, "forwarder", nil, &connection.Mechanism{Type: srv6.MECHANISM}, &connection.Mechanism{Type: wireguard.MECHANISM})
+	next := &testNextService{err: errors.New("permission denied")}
+
+	_, err := requestForwarderService(mdl, reg, next)
+	g.Expect(err).NotTo(BeNil())
+	g.Expect(fwd.requests).To(Equal(0))
+	g.Expect(reg.vni.size()).To(Equal(0))
+	g.Expect(reg.sid.size()).To(Equal(0))
+	g.Expect(reg.wgPort.size()).To(Equal(0))
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
+)
+
+const (
+	nsmNamespace  = "nsm"
+	nsmdSubsystem = "nsmd"
+
+	// ServiceKey is label for the network service server handling the call, f.e. "local", "remote" or "proxy"
+	ServiceKey = "service"
+	// NetworkServiceKey is label for network service name
+	NetworkServiceKey = "network_service"
+	// CodeKey is label for gRPC status code of the call
+	CodeKey = "code"
+	// HealStateKey is label for the cause of healing
+	HealStateKey = "heal_state"
+	// OutcomeKey is label for the result of healing
+	OutcomeKey = "outcome"
+	// ForwarderKey is label for forwarder name
+	ForwarderKey = "forwarder"
+	// EventKey is label for forwarder registration event
+	EventKey = "event"
+	// MethodKey is label for gRPC method name
+	MethodKey = "method"
+	// TargetKey is label for the part of the connection moved by healing
+	TargetKey = "target"
+
+	// HealOutcomeHealed is outcome of healing if connection is recovered
+	HealOutcomeHealed = "healed"
+	// HealOutcomeClosed is outcome of healing if connection is closed
+	HealOutcomeClosed = "closed"
+	// HealOutcomeKept is outcome of healing if connection is kept as is, f.e. there is no endpoint to move it from the
+	// draining one
+	HealOutcomeKept = "kept"
+
+	// HealTargetEndpoint is target of healing moving connection to another endpoint
+	HealTargetEndpoint = "endpoint"
+	// HealTargetForwarder is target of healing moving connection to another forwarder
+	HealTargetForwarder = "forwarder"
+
+	// ForwarderRegistered is event of forwarder registration
+	ForwarderRegistered = "registered"
+	// ForwarderUpdated is event of forwarder mechanisms update
+	ForwarderUpdated = "updated"
+	// ForwarderUnregistered is event of forwarder removal
+	ForwarderUnregistered = "unregistered"
+)
+
+var (
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "request_duration_seconds",
+		Help:      "Latency of network service requests",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{ServiceKey, NetworkServiceKey})
+	requestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "request_errors_total",
+		Help:      "Number of failed network service requests",
+	}, []string{ServiceKey, NetworkServiceKey, CodeKey})
+	closeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "close_duration_seconds",
+		Help:      "Latency of connection closes",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{ServiceKey, NetworkServiceKey})
+	closeErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "close_errors_total",
+		Help:      "Number of failed connection closes",
+	}, []string{ServiceKey, NetworkServiceKey, CodeKey})
+	healAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "heal_attempts_total",
+		Help:      "Number of connection healing attempts",
+	}, []string{HealStateKey})
+	healOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "heal_outcomes_total",
+		Help:      "Number of finished connection healings by result",
+	}, []string{HealStateKey, OutcomeKey})
+	healDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "heal_duration_seconds",
+		Help:      "Duration of connection healings by result",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+	}, []string{HealStateKey, OutcomeKey})
+	healMoves = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "heal_moves_total",
+		Help:      "Number of healed connections moved to another endpoint or forwarder",
+	}, []string{HealStateKey, TargetKey})
+	activeConnections = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "active_connections",
+		Help:      "Number of ready client connections",
+	}, []string{NetworkServiceKey, ForwarderKey})
+	registryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "registry_call_duration_seconds",
+		Help:      "Latency of network service registry calls",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{MethodKey, CodeKey})
+	forwarderEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "forwarder_events_total",
+		Help:      "Number of forwarder registration events",
+	}, []string{ForwarderKey, EventKey})
+	forwarderDrainGap = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: nsmNamespace,
+		Subsystem: nsmdSubsystem,
+		Name:      "forwarder_drain_gap_seconds",
+		Help:      "Time connections are down while moved from the draining forwarder",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{ForwarderKey})
+)
+
+var registerNsmdMetricsOnce sync.Once
+
+// registerNsmdMetrics registers nsmd collectors on first use, so processes not reporting nsmd metrics do not expose them
+func registerNsmdMetrics() {
+	registerNsmdMetricsOnce.Do(func() {
+		for _, c := range []prometheus.Collector{
+			requestDuration, requestErrors, closeDuration, closeErrors, healAttempts, healOutcomes, healDuration,
+			healMoves, activeConnections, registryDuration, forwarderEvents, forwarderDrainGap,
+		} {
+			if err := prometheus.Register(c); err != nil {
+				logrus.Infof("failed to register collector %v, err: %v", c, err)
+			}
+		}
+	})
+}
+
+// ObserveRequest tracks latency and result of the network service request handled by the service
+func ObserveRequest(service, networkService string, start time.Time, err error) {
+	registerNsmdMetrics()
+	requestDuration.WithLabelValues(service, networkService).Observe(time.Since(start).Seconds())
+	if err != nil {
+		requestErrors.WithLabelValues(service, networkService, status.Code(err).String()).Inc()
+	}
+}
+
+// ObserveClose tracks latency and result of the connection close handled by the service
+func ObserveClose(service, networkService string, start time.Time, err error) {
+	registerNsmdMetrics()
+	closeDuration.WithLabelValues(service, networkService).Observe(time.Since(start).Seconds())
+	if err != nil {
+		closeErrors.WithLabelValues(service, networkService, status.Code(err).String()).Inc()
+	}
+}
+
+// ObserveHealAttempt tracks start of the connection healing caused by healState
+func ObserveHealAttempt(healState string) {
+	registerNsmdMetrics()
+	healAttempts.WithLabelValues(healState).Inc()
+}
+
+// ObserveHealOutcome tracks result and duration of the connection healing caused by healState
+func ObserveHealOutcome(healState, outcome string, start time.Time) {
+	registerNsmdMetrics()
+	healOutcomes.WithLabelValues(healState, outcome).Inc()
+	healDuration.WithLabelValues(healState, outcome).Observe(time.Since(start).Seconds())
+}
+
+// ObserveHealMove tracks the connection healing caused by healState moving the connection to another target
+func ObserveHealMove(healState, target string) {
+	registerNsmdMetrics()
+	healMoves.WithLabelValues(healState, target).Inc()
+}
+
+// ObserveForwarderEvent tracks registration event of the forwarder
+func ObserveForwarderEvent(forwarder, event string) {
+	registerNsmdMetrics()
+	forwarderEvents.WithLabelValues(forwarder, event).Inc()
+}
+
+// ObserveForwarderDrainGap tracks time the connection is down while moved from the draining forwarder
+func ObserveForwarderDrainGap(forwarder string, gap time.Duration) {
+	registerNsmdMetrics()
+	forwarderDrainGap.WithLabelValues(forwarder).Observe(gap.Seconds())
+}
+
+// ConnectionsKey is a set of labels active connections are counted by
+type ConnectionsKey struct {
+	NetworkService string
+	Forwarder      string
+}
+
+var (
+	activeConnectionsKeys  = map[ConnectionsKey]bool{}
+	activeConnectionsMutex sync.Mutex
+)
+
+// SetActiveConnections sets numbers of active connections, previously set series missing in counts are removed
+func SetActiveConnections(counts map[ConnectionsKey]int) {
+	registerNsmdMetrics()
+	activeConnectionsMutex.Lock()
+	defer activeConnectionsMutex.Unlock()
+
+	for key := range activeConnectionsKeys {
+		if _, ok := counts[key]; !ok {
+			activeConnections.DeleteLabelValues(key.NetworkService, key.Forwarder)
+			delete(activeConnectionsKeys, key)
+		}
+	}
+	for key, count := range counts {
+		activeConnections.WithLabelValues(key.NetworkService, key.Forwarder).Set(float64(count))
+		activeConnectionsKeys[key] = true
+	}
+}
+
+// RegistryClientInterceptor returns client interceptor tracking latencies of network service registry calls
+func RegistryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		registerNsmdMetrics()
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		registryDuration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// ServePrometheusMetrics starts Prometheus metrics server if it is enabled with PrometheusEnv,
+// returned function shuts the server down
+func ServePrometheusMetrics() func() {
+	prom, err := tools.ReadEnvBool(PrometheusEnv, PrometheusDefault)
+	if err != nil {
+		logrus.Errorf("failed to read %v env var: %v", PrometheusEnv, err)
+		return func() {}
+	}
+	if !prom {
+		return func() {}
+	}
+
+	logrus.Infof("Starting Prometheus server")
+	server := GetPrometheusMetricsServer()
+	go func() {
+		if serveErr := server.ListenAndServe(); serveErr != nil && serveErr != http.ErrServerClosed {
+			logrus.Errorf("failed to listen and serve prometheus server: %v", serveErr)
+		}
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if shutdownErr := server.Shutdown(ctx); shutdownErr != nil {
+			logrus.Errorf("failed to shut down prometheus server: %v", shutdownErr)
+		}
+	}
+}
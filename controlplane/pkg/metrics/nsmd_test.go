@@ -0,0 +1,61 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSetActiveConnections(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	icmp := ConnectionsKey{NetworkService: "icmp-responder", Forwarder: "forwarder-1"}
+	vpn := ConnectionsKey{NetworkService: "secure-intranet-connectivity", Forwarder: "forwarder-1"}
+
+	SetActiveConnections(map[ConnectionsKey]int{
+		icmp: 2,
+		vpn:  1,
+	})
+	g.Expect(activeConnectionsSeries()).To(gomega.Equal(2))
+	g.Expect(testutil.ToFloat64(activeConnections.WithLabelValues(icmp.NetworkService, icmp.Forwarder))).To(gomega.Equal(2.0))
+
+	SetActiveConnections(map[ConnectionsKey]int{
+		icmp: 3,
+	})
+	g.Expect(activeConnectionsSeries()).To(gomega.Equal(1))
+	g.Expect(testutil.ToFloat64(activeConnections.WithLabelValues(icmp.NetworkService, icmp.Forwarder))).To(gomega.Equal(3.0))
+
+	SetActiveConnections(map[ConnectionsKey]int{})
+	g.Expect(activeConnectionsSeries()).To(gomega.Equal(0))
+}
+
+func activeConnectionsSeries() int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		activeConnections.Collect(ch)
+		close(ch)
+	}()
+	count := 0
+	for range ch {
+		count++
+	}
+	return count
+}
@@ -36,6 +36,23 @@ const (
 	ClientConnectionClosing ClientConnectionState = 5
 )
 
+var clientConnectionStateNames = map[ClientConnectionState]string{
+	ClientConnectionReady:        "ready",
+	ClientConnectionRequesting:   "requesting",
+	ClientConnectionBroken:       "broken",
+	ClientConnectionHealingBegin: "healing_begin",
+	ClientConnectionHealing:      "healing",
+	ClientConnectionClosing:      "closing",
+}
+
+// String returns name of the client connection state
+func (s ClientConnectionState) String() string {
+	if name, ok := clientConnectionStateNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
 // ClientConnection struct in model that describes cross connect between NetworkServiceClient and NetworkServiceEndpoint
 type ClientConnection struct {
 	ConnectionID            string
@@ -150,6 +167,40 @@ func (d *clientConnectionDomain) GetAllClientConnections() []*ClientConnection {
 	return rv
 }
 
+// ConnectionCounts returns amount of active client connections per endpoint name. Connections which are closing, broken
+// or reported down by the remote side are not counted.
+func (d *clientConnectionDomain) ConnectionCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, cc := range d.GetAllClientConnections() {
+		if cc.ConnectionState == ClientConnectionClosing || cc.ConnectionState == ClientConnectionBroken {
+			continue
+		}
+		if cc.Xcon.GetDestination().GetState() == connection.State_DOWN {
+			continue
+		}
+		if name := cc.Endpoint.GetNetworkServiceEndpoint().GetName(); name != "" {
+			counts[name]++
+		}
+	}
+	return counts
+}
+
+// ConnectionCount returns amount of active client connections to the endpoint
+func (d *clientConnectionDomain) ConnectionCount(endpointName string) int {
+	return d.ConnectionCounts()[endpointName]
+}
+
+// ForwarderConnectionCount returns amount of client connections programmed on the forwarder
+func (d *clientConnectionDomain) ForwarderConnectionCount(forwarderName string) int {
+	count := 0
+	for _, cc := range d.GetAllClientConnections() {
+		if cc.ForwarderRegisteredName == forwarderName && cc.ForwarderState == ForwarderStateReady {
+			count++
+		}
+	}
+	return count
+}
+
 func (d *clientConnectionDomain) DeleteClientConnection(ctx context.Context, connectionID string) {
 	d.delete(ctx, connectionID)
 }
@@ -8,6 +8,7 @@ import (
 
 	. "github.com/onsi/gomega"
 
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 )
@@ -280,3 +281,64 @@ func TestApplyChanges(t *testing.T) {
 	upd := ccd.GetClientConnection("1")
 	g.Expect(upd.RemoteNsm.Name).To(Equal("updatedMaster"))
 }
+
+func TestConnectionCount(t *testing.T) {
+	g := NewWithT(t)
+
+	ccd := newClientConnectionDomain()
+	addConnection := func(id, endpointName string, state ClientConnectionState, dstState connection.State) {
+		ccd.AddClientConnection(context.Background(), &ClientConnection{
+			ConnectionID: id,
+			Xcon: &crossconnect.CrossConnect{
+				Id: id,
+				Destination: &connection.Connection{
+					Id:    id,
+					State: dstState,
+				},
+			},
+			Endpoint: &registry.NSERegistration{
+				NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+					Name:               endpointName,
+					NetworkServiceName: "ns1",
+				},
+			},
+			ConnectionState: state,
+		})
+	}
+
+	addConnection("1", "endp1", ClientConnectionReady, connection.State_UP)
+	addConnection("2", "endp1", ClientConnectionHealing, connection.State_UP)
+	addConnection("3", "endp1", ClientConnectionClosing, connection.State_UP)
+	addConnection("4", "endp1", ClientConnectionReady, connection.State_DOWN)
+	addConnection("5", "endp2", ClientConnectionReady, connection.State_UP)
+	ccd.AddClientConnection(context.Background(), &ClientConnection{
+		ConnectionID:    "6",
+		ConnectionState: ClientConnectionRequesting,
+	})
+
+	g.Expect(ccd.ConnectionCount("endp1")).To(Equal(2))
+	g.Expect(ccd.ConnectionCount("endp2")).To(Equal(1))
+	g.Expect(ccd.ConnectionCount("endp3")).To(Equal(0))
+}
+
+func TestForwarderConnectionCount(t *testing.T) {
+	g := NewWithT(t)
+
+	ccd := newClientConnectionDomain()
+	addConnection := func(id, forwarderName string, state ForwarderState) {
+		ccd.AddClientConnection(context.Background(), &ClientConnection{
+			ConnectionID:            id,
+			ForwarderRegisteredName: forwarderName,
+			ForwarderState:          state,
+		})
+	}
+
+	addConnection("1", "dp1", ForwarderStateReady)
+	addConnection("2", "dp1", ForwarderStateReady)
+	addConnection("3", "dp1", ForwarderStateNone)
+	addConnection("4", "dp2", ForwarderStateReady)
+
+	g.Expect(ccd.ForwarderConnectionCount("dp1")).To(Equal(2))
+	g.Expect(ccd.ForwarderConnectionCount("dp2")).To(Equal(1))
+	g.Expect(ccd.ForwarderConnectionCount("dp3")).To(Equal(0))
+}
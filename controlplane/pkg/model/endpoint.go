@@ -76,6 +76,16 @@ func (d *endpointDomain) GetEndpointsByNetworkService(nsName string) []*Endpoint
 	return rv
 }
 
+// GetAllEndpoints returns all endpoints registered in the model
+func (d *endpointDomain) GetAllEndpoints() []*Endpoint {
+	var rv []*Endpoint
+	d.kvRange(func(_ string, value interface{}) bool {
+		rv = append(rv, value.(*Endpoint))
+		return true
+	})
+	return rv
+}
+
 func (d *endpointDomain) DeleteEndpoint(ctx context.Context, name string) {
 	d.delete(ctx, name)
 }
@@ -104,6 +104,39 @@ func TestGetEndpointsByNs(t *testing.T) {
 	}
 }
 
+func TestGetAllEndpoints(t *testing.T) {
+	g := NewWithT(t)
+
+	ed := newEndpointDomain()
+	g.Expect(ed.GetAllEndpoints()).To(BeEmpty())
+
+	amount := 5
+	for i := 0; i < amount; i++ {
+		ed.AddEndpoint(context.Background(), &Endpoint{
+			Endpoint: &registry.NSERegistration{
+				NetworkService: &registry.NetworkService{
+					Name: fmt.Sprintf("%d", i%2),
+				},
+				NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+					Name: fmt.Sprintf("%d", i),
+				},
+			},
+		})
+	}
+
+	all := ed.GetAllEndpoints()
+	g.Expect(len(all)).To(Equal(amount))
+
+	expected := make([]bool, amount)
+	for _, endp := range all {
+		idx, _ := strconv.ParseInt(endp.EndpointName(), 10, 64)
+		expected[idx] = true
+	}
+	for i := 0; i < amount; i++ {
+		g.Expect(expected[i]).To(BeTrue())
+	}
+}
+
 func TestDeleteEndpoint(t *testing.T) {
 	g := NewWithT(t)
 
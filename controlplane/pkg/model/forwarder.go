@@ -19,6 +19,14 @@ const (
 	ForwarderStateReady ForwarderState = 1 // In case forwarder is configured for connection.
 )
 
+// String returns name of the forwarder state
+func (s ForwarderState) String() string {
+	if s == ForwarderStateReady {
+		return "ready"
+	}
+	return "none"
+}
+
 // Forwarder structure in Model that describes forwarder
 type Forwarder struct {
 	RegisteredName       string
@@ -26,6 +34,8 @@ type Forwarder struct {
 	LocalMechanisms      []*connection.Mechanism
 	RemoteMechanisms     []*connection.Mechanism
 	MechanismsConfigured bool
+	// Draining forwarder is not selected for the new connections, its connections are moved to other forwarders
+	Draining bool
 }
 
 // Clone returns pointer to copy of Forwarder
@@ -50,6 +60,7 @@ func (d *Forwarder) clone() cloneable {
 		LocalMechanisms:      lm,
 		RemoteMechanisms:     rm,
 		MechanismsConfigured: d.MechanismsConfigured,
+		Draining:             d.Draining,
 	}
 }
 
@@ -103,6 +114,14 @@ func (d *forwarderDomain) UpdateForwarder(ctx context.Context, dp *Forwarder) {
 	d.store(ctx, dp.RegisteredName, dp)
 }
 
+func (d *forwarderDomain) ApplyForwarderChanges(ctx context.Context, name string, f func(*Forwarder)) *Forwarder {
+	upd := d.applyChanges(ctx, name, func(v interface{}) { f(v.(*Forwarder)) })
+	if upd != nil {
+		return upd.(*Forwarder)
+	}
+	return nil
+}
+
 func (d *forwarderDomain) SelectForwarder(forwarderSelector func(dp *Forwarder) bool) (*Forwarder, error) {
 	var rv *Forwarder
 	d.kvRange(func(key string, value interface{}) bool {
@@ -128,6 +147,19 @@ func (d *forwarderDomain) SelectForwarder(forwarderSelector func(dp *Forwarder)
 	return rv, nil
 }
 
+// SelectForwarders returns all forwarders matching forwarderSelector, all forwarders are returned for nil forwarderSelector
+func (d *forwarderDomain) SelectForwarders(forwarderSelector func(dp *Forwarder) bool) []*Forwarder {
+	var rv []*Forwarder
+	d.kvRange(func(key string, value interface{}) bool {
+		dp := value.(*Forwarder)
+		if forwarderSelector == nil || forwarderSelector(dp) {
+			rv = append(rv, dp)
+		}
+		return true
+	})
+	return rv
+}
+
 func (d *forwarderDomain) SetForwarderModificationHandler(h *ModificationHandler) func() {
 	return d.addHandler(h)
 }
@@ -35,6 +35,7 @@ func TestAddAndGetDp(t *testing.T) {
 			},
 		},
 		MechanismsConfigured: true,
+		Draining:             true,
 	}
 
 	dd := newForwarderDomain()
@@ -44,6 +45,7 @@ func TestAddAndGetDp(t *testing.T) {
 	g.Expect(getDp.RegisteredName).To(Equal(dp.RegisteredName))
 	g.Expect(getDp.SocketLocation).To(Equal(dp.SocketLocation))
 	g.Expect(getDp.MechanismsConfigured).To(Equal(dp.MechanismsConfigured))
+	g.Expect(getDp.Draining).To(Equal(dp.Draining))
 	g.Expect(getDp.LocalMechanisms).To(Equal(dp.LocalMechanisms))
 	g.Expect(getDp.RemoteMechanisms).To(Equal(dp.RemoteMechanisms))
 
@@ -136,3 +138,27 @@ func TestSelectDp(t *testing.T) {
 	g.Expect(err).To(BeNil())
 	g.Expect(first.RegisteredName).ToNot(BeNil())
 }
+
+func TestSelectForwarders(t *testing.T) {
+	g := NewWithT(t)
+
+	dd := newForwarderDomain()
+	for i := 0; i < 4; i++ {
+		dd.AddForwarder(context.Background(), &Forwarder{
+			RegisteredName: fmt.Sprintf("dp%d", i),
+			SocketLocation: fmt.Sprintf("/socket-%d", i),
+		})
+	}
+
+	selected := dd.SelectForwarders(func(dp *Forwarder) bool {
+		return dp.RegisteredName != "dp2"
+	})
+	names := []string{}
+	for _, dp := range selected {
+		names = append(names, dp.RegisteredName)
+	}
+	g.Expect(names).To(ConsistOf("dp0", "dp1", "dp3"))
+
+	g.Expect(dd.SelectForwarders(nil)).To(HaveLen(4))
+	g.Expect(dd.SelectForwarders(func(dp *Forwarder) bool { return false })).To(BeEmpty())
+}
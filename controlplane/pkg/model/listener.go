@@ -9,6 +9,7 @@ type Listener interface {
 	EndpointDeleted(ctx context.Context, endpoint *Endpoint)
 
 	ForwarderAdded(ctx context.Context, forwarder *Forwarder)
+	ForwarderUpdated(ctx context.Context, old, new *Forwarder)
 	ForwarderDeleted(ctx context.Context, forwarder *Forwarder)
 
 	ClientConnectionAdded(ctx context.Context, clientConnection *ClientConnection)
@@ -31,6 +32,9 @@ func (ListenerImpl) EndpointDeleted(ctx context.Context, endpoint *Endpoint) {}
 // ForwarderAdded will be called when Forwarder is added to model, accept pointer to copy
 func (ListenerImpl) ForwarderAdded(ctx context.Context, forwarder *Forwarder) {}
 
+// ForwarderUpdated will be called when Forwarder in model is updated
+func (ListenerImpl) ForwarderUpdated(ctx context.Context, old, new *Forwarder) {}
+
 // ForwarderDeleted will be called when Forwarder in model is deleted
 func (ListenerImpl) ForwarderDeleted(ctx context.Context, forwarder *Forwarder) {}
 
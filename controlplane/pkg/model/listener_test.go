@@ -27,6 +27,10 @@ func (t *testListener) ForwarderAdded(ctx context.Context, forwarder *Forwarder)
 	t.Done()
 }
 
+func (t *testListener) ForwarderUpdated(ctx context.Context, old, new *Forwarder) {
+	t.Done()
+}
+
 func (t *testListener) ForwarderDeleted(ctx context.Context, forwarder *Forwarder) {
 	t.Done()
 }
@@ -46,7 +50,7 @@ func (t *testListener) ClientConnectionUpdated(ctx context.Context, old, new *Cl
 func TestModelListener(t *testing.T) {
 	m := NewModel()
 	ln := testListener{}
-	ln.Add(8)
+	ln.Add(9)
 	m.AddListener(&ln)
 
 	m.AddEndpoint(context.Background(), &Endpoint{})
@@ -54,6 +58,7 @@ func TestModelListener(t *testing.T) {
 	m.DeleteEndpoint(context.Background(), "")
 
 	m.AddForwarder(context.Background(), &Forwarder{})
+	m.UpdateForwarder(context.Background(), &Forwarder{})
 	m.DeleteForwarder(context.Background(), "")
 
 	m.AddClientConnection(context.Background(), &ClientConnection{})
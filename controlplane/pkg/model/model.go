@@ -13,6 +13,7 @@ import (
 
 type Model interface {
 	GetEndpointsByNetworkService(nsName string) []*Endpoint
+	GetAllEndpoints() []*Endpoint
 
 	AddEndpoint(ctx context.Context, endpoint *Endpoint)
 	GetEndpoint(name string) *Endpoint
@@ -22,12 +23,18 @@ type Model interface {
 	GetForwarder(name string) *Forwarder
 	AddForwarder(ctx context.Context, forwarder *Forwarder)
 	UpdateForwarder(ctx context.Context, forwarder *Forwarder)
+	ApplyForwarderChanges(ctx context.Context, name string, changeFunc func(*Forwarder)) *Forwarder
 	DeleteForwarder(ctx context.Context, name string)
 	SelectForwarder(forwarderSelector func(dp *Forwarder) bool) (*Forwarder, error)
+	SelectForwarders(forwarderSelector func(dp *Forwarder) bool) []*Forwarder
 
 	AddClientConnection(ctx context.Context, clientConnection *ClientConnection)
 	GetClientConnection(connectionID string) *ClientConnection
 	GetAllClientConnections() []*ClientConnection
+	ConnectionCount(endpointName string) int
+	ConnectionCounts() map[string]int
+	SetRemoteConnectionCounts(nsmName string, counts map[string]int)
+	ForwarderConnectionCount(forwarderName string) int
 	UpdateClientConnection(ctx context.Context, clientConnection *ClientConnection)
 	DeleteClientConnection(ctx context.Context, connectionID string)
 	ApplyClientConnectionChanges(ctx context.Context, connectionID string, changeFunc func(*ClientConnection)) *ClientConnection
@@ -55,6 +62,8 @@ type model struct {
 	selector         selector.Selector
 	nsm              *registry.NetworkServiceManager
 	listeners        map[Listener]func()
+
+	remoteConnectionCounts map[string]map[string]int
 }
 
 func (m *model) AddListener(listener Listener) {
@@ -74,6 +83,9 @@ func (m *model) AddListener(listener Listener) {
 		AddFunc: func(ctx context.Context, new interface{}) {
 			listener.ForwarderAdded(ctx, new.(*Forwarder))
 		},
+		UpdateFunc: func(ctx context.Context, old interface{}, new interface{}) {
+			listener.ForwarderUpdated(ctx, old.(*Forwarder), new.(*Forwarder))
+		},
 		DeleteFunc: func(ctx context.Context, del interface{}) {
 			listener.ForwarderDeleted(ctx, del.(*Forwarder))
 		},
@@ -119,13 +131,15 @@ func (m *model) ListenerCount() int {
 
 // NewModel returns new instance of Model
 func NewModel() Model {
-	return &model{
+	m := &model{
 		clientConnectionDomain: newClientConnectionDomain(),
 		endpointDomain:         newEndpointDomain(),
 		forwarderDomain:        newForwarderDomain(),
-		selector:               selector.NewMatchSelector(),
 		listeners:              make(map[Listener]func()),
+		remoteConnectionCounts: make(map[string]map[string]int),
 	}
+	m.selector = selector.NewMatchSelector(selector.NewRegistry(m))
+	return m
 }
 
 func (m *model) ConnectionID() string {
@@ -163,6 +177,43 @@ func (m *model) SetNsm(nsm *registry.NetworkServiceManager) {
 	m.nsm = nsm
 }
 
+// SetRemoteConnectionCounts stores amount of active connections per endpoint reported by the crossconnect monitor of
+// the remote NSMD, nil counts forget the NSMD
+func (m *model) SetRemoteConnectionCounts(nsmName string, counts map[string]int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if counts == nil {
+		delete(m.remoteConnectionCounts, nsmName)
+		return
+	}
+	m.remoteConnectionCounts[nsmName] = counts
+}
+
+// ConnectionCounts returns amount of active connections per endpoint across NSMDs. Connections of the local model are
+// merged with the counts reported by remote NSMDs, the larger value wins since a remote NSMD could not yet report
+// connections just created by this NSMD.
+func (m *model) ConnectionCounts() map[string]int {
+	counts := m.clientConnectionDomain.ConnectionCounts()
+
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	for _, remoteCounts := range m.remoteConnectionCounts {
+		for name, count := range remoteCounts {
+			if count > counts[name] {
+				counts[name] = count
+			}
+		}
+	}
+	return counts
+}
+
+// ConnectionCount returns amount of active connections to the endpoint across NSMDs
+func (m *model) ConnectionCount(endpointName string) int {
+	return m.ConnectionCounts()[endpointName]
+}
+
 func (m *model) GetSelector() selector.Selector {
 	return m.selector
 }
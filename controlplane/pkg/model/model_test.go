@@ -0,0 +1,55 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func TestConnectionCountsAcrossNSMDs(t *testing.T) {
+	g := NewWithT(t)
+
+	m := NewModel()
+	for _, id := range []string{"1", "2"} {
+		m.AddClientConnection(context.Background(), &ClientConnection{
+			ConnectionID: id,
+			Xcon: &crossconnect.CrossConnect{
+				Id:          id,
+				Destination: &connection.Connection{Id: id, State: connection.State_UP},
+			},
+			Endpoint: &registry.NSERegistration{
+				NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "local-nse"},
+			},
+			ConnectionState: ClientConnectionReady,
+		})
+	}
+	m.AddClientConnection(context.Background(), &ClientConnection{
+		ConnectionID: "3",
+		Xcon: &crossconnect.CrossConnect{
+			Id:          "3",
+			Destination: &connection.Connection{Id: "3", State: connection.State_UP},
+		},
+		Endpoint: &registry.NSERegistration{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "remote-nse1"},
+		},
+		ConnectionState: ClientConnectionReady,
+	})
+
+	m.SetRemoteConnectionCounts("nsmd2", map[string]int{"remote-nse1": 4, "remote-nse2": 1})
+
+	g.Expect(m.ConnectionCounts()).To(Equal(map[string]int{
+		"local-nse":   2,
+		"remote-nse1": 4,
+		"remote-nse2": 1,
+	}))
+	g.Expect(m.ConnectionCount("remote-nse1")).To(Equal(4))
+
+	m.SetRemoteConnectionCounts("nsmd2", nil)
+	g.Expect(m.ConnectionCount("remote-nse1")).To(Equal(1))
+	g.Expect(m.ConnectionCount("remote-nse2")).To(Equal(0))
+}
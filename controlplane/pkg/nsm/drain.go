@@ -0,0 +1,209 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/utils"
+)
+
+const (
+	// DrainCheckIntervalDefault - default interval of updating the watched network services of the endpoints and connections
+	DrainCheckIntervalDefault = 5 * time.Second
+	// DrainCheckIntervalEnv - environment variable contains interval of updating the watched network services of the
+	// endpoints and connections
+	DrainCheckIntervalEnv = utils.EnvVar("NSMD_DRAIN_CHECK_INTERVAL")
+)
+
+// DrainEndpoint stops selecting the local endpoint for the new connections and moves its connections with local source
+// to other endpoints. Connections with remote source are moved by their source NSMgr, see monitorDrainingEndpoints.
+func (srv *networkServiceManager) DrainEndpoint(ctx context.Context, endpointName string) error {
+	endpoint := srv.model.GetEndpoint(endpointName)
+	if endpoint == nil {
+		return errors.Errorf("endpoint %v is not found", endpointName)
+	}
+	logrus.Infof("NSM: Draining endpoint %v", endpointName)
+	srv.setEndpointState(ctx, endpoint, registry.EndpointStatePaused)
+
+	for _, cc := range srv.model.GetAllClientConnections() {
+		if cc.Endpoint.GetNetworkServiceEndpoint().GetName() == endpointName {
+			srv.migrateConnection(ctx, cc)
+		}
+	}
+	return nil
+}
+
+// monitorDrainingEndpoints moves connections from the endpoints draining in the registry, f.e. if NSE custom resource
+// is paused or NSE is drained via another NSMgr. Network services of the local endpoints and connections are watched,
+// the set of the watched network services is updated every interval. Only connections with local source are moved,
+// so connections of the remote clients are moved by their NSMgrs watching the same network service
+func (srv *networkServiceManager) monitorDrainingEndpoints(interval time.Duration) {
+	watches := map[string]*drainWatch{}
+	defer func() {
+		for _, w := range watches {
+			w.cancel()
+		}
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-srv.ctx.Done():
+			return
+		case <-ticker.C:
+			srv.updateDrainWatches(srv.ctx, watches)
+		}
+	}
+}
+
+// drainWatch - watch of the draining endpoints of the network service, done is closed when the watch is finished
+type drainWatch struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// updateDrainWatches starts watches of the network services used by the local endpoints and connections, restarts
+// the finished ones and stops watches of the network services not used anymore
+func (srv *networkServiceManager) updateDrainWatches(ctx context.Context, watches map[string]*drainWatch) {
+	networkServices := map[string]bool{}
+	for _, endpoint := range srv.model.GetAllEndpoints() {
+		networkServices[endpoint.Endpoint.GetNetworkService().GetName()] = true
+	}
+	for _, cc := range srv.model.GetAllClientConnections() {
+		if cc.Endpoint != nil {
+			networkServices[cc.Endpoint.GetNetworkService().GetName()] = true
+		}
+	}
+
+	for networkService, w := range watches {
+		select {
+		case <-w.done:
+			delete(watches, networkService)
+			continue
+		default:
+		}
+		if !networkServices[networkService] {
+			w.cancel()
+			delete(watches, networkService)
+		}
+	}
+	for networkService := range networkServices {
+		if _, ok := watches[networkService]; ok {
+			continue
+		}
+		watchCtx, cancel := context.WithCancel(ctx)
+		w := &drainWatch{
+			cancel: cancel,
+			done:   make(chan struct{}),
+		}
+		watches[networkService] = w
+		go func(networkService string) {
+			defer close(w.done)
+			srv.watchDrainingEndpoints(watchCtx, networkService)
+		}(networkService)
+	}
+}
+
+// watchDrainingEndpoints syncs draining endpoints of the network service on every update of the registry watch. If
+// the registry is not able to watch network services, it is asked once, so the network service is checked on every
+// update of the watches
+func (srv *networkServiceManager) watchDrainingEndpoints(ctx context.Context, networkService string) {
+	discoveryClient, err := srv.serviceRegistry.DiscoveryClient(ctx)
+	if err != nil {
+		logrus.Errorf("NSM: Failed to watch draining endpoints of network service %v: %v", networkService, err)
+		return
+	}
+	request := &registry.FindNetworkServiceRequest{
+		NetworkServiceName: networkService,
+	}
+
+	stream, err := discoveryClient.WatchNetworkService(ctx, request)
+	for err == nil {
+		var response *registry.FindNetworkServiceResponse
+		if response, err = stream.Recv(); err == nil {
+			srv.syncDrainingEndpoints(ctx, response.GetNetworkServiceEndpoints())
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	if status.Code(err) != codes.Unimplemented {
+		logrus.Errorf("NSM: Watch of draining endpoints of network service %v is closed: %v", networkService, err)
+		return
+	}
+
+	response, err := discoveryClient.FindNetworkService(ctx, request)
+	if err != nil {
+		logrus.Errorf("NSM: Failed to check draining endpoints of network service %v: %v", networkService, err)
+		return
+	}
+	srv.syncDrainingEndpoints(ctx, response.GetNetworkServiceEndpoints())
+}
+
+// syncDrainingEndpoints updates state of the local endpoints and moves connections from the draining ones if there
+// are endpoints to move them to
+func (srv *networkServiceManager) syncDrainingEndpoints(ctx context.Context, endpoints []*registry.NetworkServiceEndpoint) {
+	draining := map[string]bool{}
+	available := false
+	for _, nse := range endpoints {
+		if endpoint := srv.model.GetEndpoint(nse.GetName()); endpoint != nil &&
+			endpoint.Endpoint.GetNetworkServiceEndpoint().GetState() != nse.GetState() {
+			srv.setEndpointState(ctx, endpoint, nse.GetState())
+		}
+		if nse.IsPaused() {
+			draining[nse.GetName()] = true
+		} else {
+			available = true
+		}
+	}
+	if len(draining) == 0 || !available {
+		return
+	}
+
+	for _, cc := range srv.model.GetAllClientConnections() {
+		if cc.Endpoint != nil && draining[cc.Endpoint.GetNetworkServiceEndpoint().GetName()] {
+			srv.migrateConnection(ctx, cc)
+		}
+	}
+}
+
+// migrateConnection heals connection with local source to move it from the draining endpoint, connection with remote
+// source is left to its source NSMgr: only it can select another endpoint for the client
+func (srv *networkServiceManager) migrateConnection(ctx context.Context, cc *model.ClientConnection) {
+	if !srv.props.HealEnabled || cc.ConnectionState != model.ClientConnectionReady || cc.GetConnectionSource().IsRemote() {
+		return
+	}
+	logrus.Infof("NSM: Moving connection %v from draining endpoint %v", cc.GetID(), cc.Endpoint.GetNetworkServiceEndpoint().GetName())
+	srv.Heal(ctx, cc, nsm.HealStateDstDrain)
+}
+
+func (srv *networkServiceManager) setEndpointState(ctx context.Context, endpoint *model.Endpoint, state string) {
+	logrus.Infof("NSM: Endpoint %v state is changed to %q", endpoint.Endpoint.GetNetworkServiceEndpoint().GetName(), state)
+	endpoint.Endpoint.NetworkServiceEndpoint.State = state
+	srv.model.UpdateEndpoint(ctx, endpoint)
+}
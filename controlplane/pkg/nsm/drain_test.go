@@ -0,0 +1,136 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/properties"
+)
+
+func drainTestConnection(id, endpointName string, srcPath *connection.Path) *model.ClientConnection {
+	return &model.ClientConnection{
+		ConnectionID: id,
+		Xcon: crossconnect.NewCrossConnect(id, "IP",
+			&connection.Connection{Id: id, Path: srcPath},
+			&connection.Connection{Id: id + "-dst"},
+		),
+		Endpoint: &registry.NSERegistration{
+			NetworkService:         &registry.NetworkService{Name: "ns"},
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: endpointName},
+		},
+		ConnectionState: model.ClientConnectionReady,
+	}
+}
+
+func TestSyncDrainingEndpointsMovesOnlyLocalSource(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	recorder := &healRecorder{healed: map[string]nsm.HealState{}}
+	srv := &networkServiceManager{
+		NetworkServiceHealProcessor: recorder,
+		model:                       mdl,
+		props:                       &properties.Properties{HealEnabled: true},
+	}
+
+	mdl.AddClientConnection(context.Background(), drainTestConnection("1", "nse-1", nil))
+	// Client of the connection is on another NSMgr, it moves the connection itself
+	mdl.AddClientConnection(context.Background(), drainTestConnection("2", "nse-1", &connection.Path{
+		PathSegments: []*connection.PathSegment{{Name: "nsm-src"}, {Name: "nsm-dst"}},
+	}))
+	mdl.AddClientConnection(context.Background(), drainTestConnection("3", "nse-2", nil))
+
+	srv.syncDrainingEndpoints(context.Background(), []*registry.NetworkServiceEndpoint{
+		{Name: "nse-1", State: registry.EndpointStatePaused},
+		{Name: "nse-2"},
+	})
+
+	g.Expect(recorder.healed).To(Equal(map[string]nsm.HealState{"1": nsm.HealStateDstDrain}))
+}
+
+func TestSyncDrainingEndpointsNowhereToMove(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	recorder := &healRecorder{healed: map[string]nsm.HealState{}}
+	srv := &networkServiceManager{
+		NetworkServiceHealProcessor: recorder,
+		model:                       mdl,
+		props:                       &properties.Properties{HealEnabled: true},
+	}
+
+	mdl.AddClientConnection(context.Background(), drainTestConnection("1", "nse-1", nil))
+
+	srv.syncDrainingEndpoints(context.Background(), []*registry.NetworkServiceEndpoint{
+		{Name: "nse-1", State: registry.EndpointStatePaused},
+	})
+
+	g.Expect(recorder.healed).To(BeEmpty())
+}
+
+func TestUpdateDrainWatches(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	recorder := &healRecorder{healed: map[string]nsm.HealState{}}
+	updates := make(chan *registry.FindNetworkServiceResponse)
+	srv := &networkServiceManager{
+		NetworkServiceHealProcessor: recorder,
+		model:                       mdl,
+		props:                       &properties.Properties{HealEnabled: true},
+		serviceRegistry: &serviceRegistryStub{
+			discoveryClient: &discoveryClientStub{updates: updates},
+		},
+	}
+
+	mdl.AddClientConnection(context.Background(), drainTestConnection("1", "nse-1", nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watches := map[string]*drainWatch{}
+	srv.updateDrainWatches(ctx, watches)
+	g.Expect(watches).To(HaveKey("ns"))
+
+	updates <- &registry.FindNetworkServiceResponse{
+		NetworkServiceEndpoints: []*registry.NetworkServiceEndpoint{
+			{Name: "nse-1", State: registry.EndpointStatePaused},
+			{Name: "nse-2"},
+		},
+	}
+	g.Eventually(func() map[string]nsm.HealState {
+		recorder.Lock()
+		defer recorder.Unlock()
+		return recorder.healed
+	}, time.Second).Should(Equal(map[string]nsm.HealState{"1": nsm.HealStateDstDrain}))
+
+	// Network service is not used anymore, so its watch is stopped
+	w := watches["ns"]
+	mdl.DeleteClientConnection(context.Background(), "1")
+	srv.updateDrainWatches(ctx, watches)
+	g.Expect(watches).To(BeEmpty())
+	g.Eventually(w.done, time.Second).Should(BeClosed())
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+const forwarderDrainCheckInterval = 100 * time.Millisecond
+
+// DrainForwarder stops selecting the forwarder for the new connections, moves its connections to other forwarders
+// one by one and removes the drained forwarder from the model once no connection is left on it. If draining is
+// interrupted or a connection fails to move, the forwarder is kept and selected for the new connections again
+func (srv *networkServiceManager) DrainForwarder(ctx context.Context, forwarderName string) error {
+	if srv.model.GetForwarder(forwarderName) == nil {
+		return errors.Errorf("forwarder %v is not found", forwarderName)
+	}
+	if !srv.props.HealEnabled {
+		return errors.Errorf("heal is disabled, connections of forwarder %v can't be moved", forwarderName)
+	}
+	if _, err := srv.model.SelectForwarder(func(dp *model.Forwarder) bool {
+		return dp.RegisteredName != forwarderName && dp.MechanismsConfigured && !dp.Draining
+	}); err != nil {
+		return errors.Errorf("there is no forwarder to move connections of forwarder %v to", forwarderName)
+	}
+
+	logrus.Infof("NSM: Draining forwarder %v", forwarderName)
+	if srv.model.ApplyForwarderChanges(ctx, forwarderName, func(dp *model.Forwarder) { dp.Draining = true }) == nil {
+		return errors.Errorf("forwarder %v is not found", forwarderName)
+	}
+
+	err := srv.moveConnections(ctx, forwarderName)
+	if err != nil {
+		logrus.Errorf("NSM: Draining of forwarder %v is interrupted: %v", forwarderName, err)
+		srv.model.ApplyForwarderChanges(context.Background(), forwarderName, func(dp *model.Forwarder) { dp.Draining = false })
+		return err
+	}
+
+	logrus.Infof("NSM: Forwarder %v is drained", forwarderName)
+	srv.model.DeleteForwarder(ctx, forwarderName)
+	return nil
+}
+
+// moveConnections moves the connections from the draining forwarder, fails if any connection is left on it
+func (srv *networkServiceManager) moveConnections(ctx context.Context, forwarderName string) error {
+	for _, cc := range srv.forwarderConnections(forwarderName) {
+		// Connections being requested or healed now don't select the draining forwarder
+		if cc.ConnectionState != model.ClientConnectionReady {
+			continue
+		}
+		if err := srv.moveConnection(ctx, cc); err != nil {
+			return err
+		}
+	}
+	if left := srv.forwarderConnections(forwarderName); len(left) > 0 {
+		return errors.Errorf("%d connections are left on forwarder %v, e.g. %v", len(left), forwarderName, left[0].GetID())
+	}
+	return nil
+}
+
+// forwarderConnections returns the connections programmed on the forwarder
+func (srv *networkServiceManager) forwarderConnections(forwarderName string) []*model.ClientConnection {
+	var result []*model.ClientConnection
+	for _, cc := range srv.model.GetAllClientConnections() {
+		if cc.ForwarderRegisteredName == forwarderName {
+			result = append(result, cc)
+		}
+	}
+	return result
+}
+
+// moveConnection heals the connection to move it from the draining forwarder and waits for the heal to finish. It
+// fails if the heal closes the connection, leaves it on the draining forwarder or doesn't finish in time
+func (srv *networkServiceManager) moveConnection(ctx context.Context, cc *model.ClientConnection) error {
+	forwarderName := cc.ForwarderRegisteredName
+	logrus.Infof("NSM: Moving connection %v from draining forwarder %v", cc.GetID(), forwarderName)
+	srv.Heal(ctx, cc, nsm.HealStateForwarderDrain)
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, srv.props.HealTimeout+srv.props.CloseTimeout)
+	defer waitCancel()
+	for ; true; <-time.After(forwarderDrainCheckInterval) {
+		modelCC := srv.model.GetClientConnection(cc.GetID())
+		if modelCC == nil {
+			return errors.Errorf("connection %v is closed while moving from forwarder %v", cc.GetID(), forwarderName)
+		}
+		if modelCC.ConnectionState == model.ClientConnectionReady {
+			if modelCC.ForwarderRegisteredName == forwarderName {
+				return errors.Errorf("connection %v is not moved from forwarder %v", cc.GetID(), forwarderName)
+			}
+			return nil
+		}
+		if waitCtx.Err() != nil {
+			break
+		}
+	}
+	if ctx.Err() != nil {
+		return errors.Wrapf(ctx.Err(), "failed to drain forwarder %v", forwarderName)
+	}
+	return errors.Errorf("timeout waiting for connection %v to move from forwarder %v", cc.GetID(), forwarderName)
+}
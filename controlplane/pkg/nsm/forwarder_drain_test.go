@@ -0,0 +1,146 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/properties"
+)
+
+// startHealProcessor starts the heal without finishing it, so the connection is never moved
+type startHealProcessor struct {
+	model model.Model
+}
+
+func (p *startHealProcessor) Heal(ctx context.Context, cc nsm.ClientConnection, _ nsm.HealState) {
+	p.model.ApplyClientConnectionChanges(ctx, cc.GetID(), func(modelCC *model.ClientConnection) {
+		modelCC.ConnectionState = model.ClientConnectionHealing
+	})
+}
+
+func (p *startHealProcessor) CloseConnection(context.Context, nsm.ClientConnection) error {
+	return nil
+}
+
+// moveHealProcessor moves the healed connection to the forwarder, or closes it if the forwarder is not set
+type moveHealProcessor struct {
+	model     model.Model
+	forwarder string
+}
+
+func (p *moveHealProcessor) Heal(ctx context.Context, cc nsm.ClientConnection, _ nsm.HealState) {
+	if p.forwarder == "" {
+		p.model.DeleteClientConnection(ctx, cc.GetID())
+		return
+	}
+	p.model.ApplyClientConnectionChanges(ctx, cc.GetID(), func(modelCC *model.ClientConnection) {
+		modelCC.ForwarderRegisteredName = p.forwarder
+	})
+}
+
+func (p *moveHealProcessor) CloseConnection(context.Context, nsm.ClientConnection) error {
+	return nil
+}
+
+// newForwarderDrainTestManager creates the manager with two forwarders and a connection on forwarder-1
+func newForwarderDrainTestManager(mdl model.Model, healProcessor nsm.NetworkServiceHealProcessor) *networkServiceManager {
+	mdl.AddForwarder(context.Background(), &model.Forwarder{RegisteredName: "forwarder-1", MechanismsConfigured: true})
+	mdl.AddForwarder(context.Background(), &model.Forwarder{RegisteredName: "forwarder-2", MechanismsConfigured: true})
+	cc := drainTestConnection("1", "nse-1", nil)
+	cc.ForwarderRegisteredName = "forwarder-1"
+	mdl.AddClientConnection(context.Background(), cc)
+
+	return &networkServiceManager{
+		NetworkServiceHealProcessor: healProcessor,
+		model:                       mdl,
+		props:                       &properties.Properties{HealEnabled: true, HealTimeout: time.Second},
+	}
+}
+
+func TestDrainForwarderInterrupted(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	srv := newForwarderDrainTestManager(mdl, &startHealProcessor{model: mdl})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	g.Expect(srv.DrainForwarder(ctx, "forwarder-1")).NotTo(Succeed())
+
+	forwarder := mdl.GetForwarder("forwarder-1")
+	g.Expect(forwarder).NotTo(BeNil())
+	g.Expect(forwarder.Draining).To(BeFalse())
+}
+
+func TestDrainForwarderFailed(t *testing.T) {
+	for name, newHealProcessor := range map[string]func(mdl model.Model) nsm.NetworkServiceHealProcessor{
+		"closed": func(mdl model.Model) nsm.NetworkServiceHealProcessor {
+			return &moveHealProcessor{model: mdl}
+		},
+		"not moved": func(model.Model) nsm.NetworkServiceHealProcessor {
+			return &healRecorder{healed: map[string]nsm.HealState{}}
+		},
+		"timeout": func(mdl model.Model) nsm.NetworkServiceHealProcessor {
+			return &startHealProcessor{model: mdl}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mdl := model.NewModel()
+			srv := newForwarderDrainTestManager(mdl, newHealProcessor(mdl))
+			srv.props.HealTimeout = 0
+
+			g.Expect(srv.DrainForwarder(context.Background(), "forwarder-1")).NotTo(Succeed())
+
+			forwarder := mdl.GetForwarder("forwarder-1")
+			g.Expect(forwarder).NotTo(BeNil())
+			g.Expect(forwarder.Draining).To(BeFalse())
+		})
+	}
+}
+
+func TestDrainForwarderHealDisabled(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	srv := newForwarderDrainTestManager(mdl, &moveHealProcessor{model: mdl, forwarder: "forwarder-2"})
+	srv.props.HealEnabled = false
+
+	g.Expect(srv.DrainForwarder(context.Background(), "forwarder-1")).NotTo(Succeed())
+	g.Expect(mdl.GetForwarder("forwarder-1").Draining).To(BeFalse())
+	g.Expect(mdl.GetClientConnection("1").ForwarderRegisteredName).To(Equal("forwarder-1"))
+}
+
+func TestDrainForwarder(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	srv := newForwarderDrainTestManager(mdl, &moveHealProcessor{model: mdl, forwarder: "forwarder-2"})
+
+	g.Expect(srv.DrainForwarder(context.Background(), "forwarder-1")).To(Succeed())
+
+	g.Expect(mdl.GetClientConnection("1").ForwarderRegisteredName).To(Equal("forwarder-2"))
+	g.Expect(mdl.GetForwarder("forwarder-1")).To(BeNil())
+}
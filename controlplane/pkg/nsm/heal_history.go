@@ -0,0 +1,127 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+// healWatcherBuffer is the number of heal events a slow watcher could lag behind before the events are dropped for it
+const healWatcherBuffer = 100
+
+// healHistory keeps the recent heals of the connections in memory, the oldest heals are dropped when size is exceeded
+type healHistory struct {
+	mutex    sync.Mutex
+	size     int
+	events   []*nsm.HealEvent
+	watchers map[chan *nsm.HealEvent]struct{}
+}
+
+func newHealHistory(size int) *healHistory {
+	return &healHistory{
+		size:     size,
+		watchers: map[chan *nsm.HealEvent]struct{}{},
+	}
+}
+
+// Events returns copies of the recorded heals from the oldest to the newest
+func (h *healHistory) Events() []*nsm.HealEvent {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	rv := make([]*nsm.HealEvent, 0, len(h.events))
+	for _, event := range h.events {
+		eventCopy := *event
+		rv = append(rv, &eventCopy)
+	}
+	return rv
+}
+
+// Watch returns channel receiving copies of the heals when they are started and finished, channel is closed when
+// ctx is done
+func (h *healHistory) Watch(ctx context.Context) <-chan *nsm.HealEvent {
+	watcher := make(chan *nsm.HealEvent, healWatcherBuffer)
+
+	h.mutex.Lock()
+	h.watchers[watcher] = struct{}{}
+	h.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.mutex.Lock()
+		delete(h.watchers, watcher)
+		close(watcher)
+		h.mutex.Unlock()
+	}()
+	return watcher
+}
+
+// start records the heal of the connection caused by healState
+func (h *healHistory) start(healID string, cc *model.ClientConnection, healState nsm.HealState) *nsm.HealEvent {
+	event := &nsm.HealEvent{
+		ID:             healID,
+		ConnectionID:   cc.GetID(),
+		NetworkService: cc.GetNetworkService(),
+		HealState:      healState,
+		StartTime:      time.Now(),
+		Endpoint:       cc.Endpoint.GetNetworkServiceEndpoint().GetName(),
+		Forwarder:      cc.ForwarderRegisteredName,
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.events = append(h.events, event)
+	if len(h.events) > h.size {
+		h.events = h.events[len(h.events)-h.size:]
+	}
+	h.notify(event)
+	return event
+}
+
+// finish records the outcome of the heal, cc is the healed connection or nil if the connection is closed
+func (h *healHistory) finish(event *nsm.HealEvent, outcome string, cc *model.ClientConnection) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	event.EndTime = time.Now()
+	event.Outcome = outcome
+	event.Endpoint, event.Forwarder = "", ""
+	if cc != nil {
+		event.Endpoint = cc.Endpoint.GetNetworkServiceEndpoint().GetName()
+		event.Forwarder = cc.ForwarderRegisteredName
+	}
+	h.notify(event)
+}
+
+func (h *healHistory) notify(event *nsm.HealEvent) {
+	for watcher := range h.watchers {
+		eventCopy := *event
+		select {
+		case watcher <- &eventCopy:
+		default:
+			logrus.Warnf("Heal history watcher is too slow, dropping heal event %s", event.ID)
+		}
+	}
+}
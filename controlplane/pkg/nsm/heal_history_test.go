@@ -0,0 +1,90 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+func newHealHistoryTestConnection(id, endpoint, forwarder string) *model.ClientConnection {
+	return &model.ClientConnection{
+		ConnectionID: id,
+		Endpoint: &registry.NSERegistration{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+				Name: endpoint,
+			},
+		},
+		ForwarderRegisteredName: forwarder,
+	}
+}
+
+func TestHealHistory(t *testing.T) {
+	g := NewWithT(t)
+
+	history := newHealHistory(2)
+	cc := newHealHistoryTestConnection("1", nse1Name, forwarder1Name)
+	first := history.start("A", cc, nsm.HealStateDstDown)
+	history.finish(first, "healed", newHealHistoryTestConnection("1", nse2Name, forwarder1Name))
+	history.start("B", cc, nsm.HealStateForwarderDown)
+	last := history.start("C", cc, nsm.HealStateDstDrain)
+	history.finish(last, "closed", nil)
+
+	events := history.Events()
+	g.Expect(len(events)).To(Equal(2))
+	g.Expect(events[0].ID).To(Equal("B"))
+	g.Expect(events[0].Outcome).To(BeEmpty())
+	g.Expect(events[0].EndTime.IsZero()).To(BeTrue())
+	g.Expect(events[0].Endpoint).To(Equal(nse1Name))
+	g.Expect(events[1].ID).To(Equal("C"))
+	g.Expect(events[1].Outcome).To(Equal("closed"))
+	g.Expect(events[1].Endpoint).To(BeEmpty())
+	g.Expect(events[1].EndTime.Before(events[1].StartTime)).To(BeFalse())
+
+	g.Expect(first.Endpoint).To(Equal(nse2Name))
+	g.Expect(first.Forwarder).To(Equal(forwarder1Name))
+}
+
+func TestHealHistoryWatch(t *testing.T) {
+	g := NewWithT(t)
+
+	history := newHealHistory(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher := history.Watch(ctx)
+
+	cc := newHealHistoryTestConnection("1", nse1Name, forwarder1Name)
+	event := history.start("A", cc, nsm.HealStateForwarderDown)
+	history.finish(event, "healed", newHealHistoryTestConnection("1", nse1Name, forwarder2Name))
+
+	started := <-watcher
+	g.Expect(started.ID).To(Equal("A"))
+	g.Expect(started.Outcome).To(BeEmpty())
+	g.Expect(started.Forwarder).To(Equal(forwarder1Name))
+	finished := <-watcher
+	g.Expect(finished.Outcome).To(Equal("healed"))
+	g.Expect(finished.Forwarder).To(Equal(forwarder2Name))
+
+	cancel()
+	_, ok := <-watcher
+	g.Expect(ok).To(BeFalse())
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	sdkcommon "github.com/networkservicemesh/networkservicemesh/sdk/common"
+)
+
+// monitorLeases periodically closes client connections with leases expired for more than grace period and refreshes
+// leases of the destinations of the other ones
+func (srv *networkServiceManager) monitorLeases(interval, gracePeriod time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-srv.ctx.Done():
+			return
+		case <-ticker.C:
+			srv.checkLeases(srv.ctx, gracePeriod)
+		}
+	}
+}
+
+func (srv *networkServiceManager) checkLeases(ctx context.Context, gracePeriod time.Duration) {
+	for _, cc := range srv.model.GetAllClientConnections() {
+		if cc.ConnectionState != model.ClientConnectionReady {
+			continue
+		}
+		if sdkcommon.IsLeaseExpired(cc.Request.GetConnection().GetPath(), gracePeriod) {
+			logrus.Infof("NSM: Lease of connection %v is expired, closing it", cc.GetID())
+			if err := srv.CloseConnection(ctx, cc); err != nil {
+				logrus.Errorf("NSM: Failed to close connection %v with expired lease: %v", cc.GetID(), err)
+			}
+			continue
+		}
+		if err := srv.refreshDestinationLease(ctx, cc); err != nil {
+			logrus.Errorf("NSM: Failed to refresh lease of connection %v destination: %v", cc.GetID(), err)
+		}
+	}
+}
+
+// refreshDestinationLease re-requests NSE or Remote NSM with the same destination connection and the refreshed lease
+func (srv *networkServiceManager) refreshDestinationLease(ctx context.Context, cc *model.ClientConnection) error {
+	dst := cc.GetConnectionDestination()
+	if dst == nil || cc.Endpoint == nil {
+		return nil
+	}
+
+	// The path is continued from the last request of the client, so the chain starts with its refreshed token
+	nsmName := srv.model.GetNsm().GetName()
+	refreshConn := dst.Clone()
+	refreshConn.Path = common.AppendPath(cc.Request.GetConnection().GetPath(), nsmName)
+	if !srv.nseManager.IsLocalEndpoint(cc.Endpoint) {
+		refreshConn.Path = common.AppendPath(cc.Request.GetConnection().GetPath(), nsmName, cc.Endpoint.GetNetworkServiceManager().GetName())
+	}
+	if err := common.SignPath(ctx, refreshConn, common.NextHop(srv.nseManager, cc.Endpoint)); err != nil {
+		return err
+	}
+	request := &networkservice.NetworkServiceRequest{
+		Connection:           refreshConn,
+		MechanismPreferences: []*connection.Mechanism{dst.GetMechanism()},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, srv.props.HealRequestTimeout)
+	defer cancel()
+
+	client, err := srv.nseManager.CreateNSEClient(ctx, cc.Endpoint)
+	if err != nil {
+		return errors.Wrap(err, "failed to create NSE client")
+	}
+	defer func() {
+		if cleanupErr := client.Cleanup(); cleanupErr != nil {
+			logrus.Errorf("NSM: Error during NSE client cleanup: %v", cleanupErr)
+		}
+	}()
+
+	_, err = client.Request(ctx, request)
+	return err
+}
+
+// restoreLease gives the connection restored from Forwarder a fresh lease, so its client has time to refresh it after
+// NSMgr restart
+func restoreLease(request *networkservice.NetworkServiceRequest) {
+	if _, ok := sdkcommon.LeaseExpires(request.GetConnection().GetPath()); !ok {
+		return
+	}
+	expires, err := ptypes.TimestampProto(time.Now().Add(sdkcommon.PathTokenLifetime))
+	if err != nil {
+		return
+	}
+	request.Connection = request.GetConnection().Clone()
+	path := request.GetConnection().GetPath()
+	path.GetPathSegments()[path.GetIndex()].Expires = expires
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+func leaseTestConnection(t *testing.T, id string, expires time.Time) *model.ClientConnection {
+	cc := drainTestConnection(id, "nse-1", nil)
+	cc.Endpoint = nil
+	segment := &connection.PathSegment{Name: "nsc"}
+	if !expires.IsZero() {
+		var err error
+		if segment.Expires, err = ptypes.TimestampProto(expires); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cc.Request = &networkservice.NetworkServiceRequest{
+		Connection: &connection.Connection{
+			Id:   id,
+			Path: &connection.Path{PathSegments: []*connection.PathSegment{segment}},
+		},
+	}
+	return cc
+}
+
+func TestCheckLeases(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	recorder := &healRecorder{healed: map[string]nsm.HealState{}}
+	srv := &networkServiceManager{NetworkServiceHealProcessor: recorder, model: mdl}
+
+	mdl.AddClientConnection(context.Background(), leaseTestConnection(t, "expired", time.Now().Add(-time.Hour)))
+	mdl.AddClientConnection(context.Background(), leaseTestConnection(t, "leased", time.Now().Add(time.Hour)))
+	// Client has connected and exited like nsm-init, its connection has no lease
+	mdl.AddClientConnection(context.Background(), leaseTestConnection(t, "unleased", time.Time{}))
+
+	srv.checkLeases(context.Background(), time.Minute)
+
+	g.Expect(recorder.closed).To(Equal([]string{"expired"}))
+}
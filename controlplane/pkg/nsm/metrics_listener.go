@@ -0,0 +1,76 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+// metricsListener reports active client connections and forwarder registration events.
+type metricsListener struct {
+	model.ListenerImpl
+	model model.Model
+}
+
+// ForwarderAdded reports registration of the forwarder
+func (l *metricsListener) ForwarderAdded(ctx context.Context, forwarder *model.Forwarder) {
+	metrics.ObserveForwarderEvent(forwarder.RegisteredName, metrics.ForwarderRegistered)
+}
+
+// ForwarderUpdated reports update of the forwarder
+func (l *metricsListener) ForwarderUpdated(ctx context.Context, old, new *model.Forwarder) {
+	metrics.ObserveForwarderEvent(new.RegisteredName, metrics.ForwarderUpdated)
+}
+
+// ForwarderDeleted reports removal of the forwarder
+func (l *metricsListener) ForwarderDeleted(ctx context.Context, forwarder *model.Forwarder) {
+	metrics.ObserveForwarderEvent(forwarder.RegisteredName, metrics.ForwarderUnregistered)
+}
+
+// ClientConnectionAdded recounts active client connections
+func (l *metricsListener) ClientConnectionAdded(ctx context.Context, clientConnection *model.ClientConnection) {
+	l.updateActiveConnections()
+}
+
+// ClientConnectionUpdated recounts active client connections
+func (l *metricsListener) ClientConnectionUpdated(ctx context.Context, old, new *model.ClientConnection) {
+	l.updateActiveConnections()
+}
+
+// ClientConnectionDeleted recounts active client connections
+func (l *metricsListener) ClientConnectionDeleted(ctx context.Context, clientConnection *model.ClientConnection) {
+	l.updateActiveConnections()
+}
+
+// updateActiveConnections counts ready client connections by network service and forwarder, connections are
+// recounted from the model rather than tracked by events to not drift on healing and restore
+func (l *metricsListener) updateActiveConnections() {
+	counts := map[metrics.ConnectionsKey]int{}
+	for _, cc := range l.model.GetAllClientConnections() {
+		if cc.ConnectionState != model.ClientConnectionReady {
+			continue
+		}
+		counts[metrics.ConnectionsKey{
+			NetworkService: cc.GetNetworkService(),
+			Forwarder:      cc.ForwarderRegisteredName,
+		}]++
+	}
+	metrics.SetActiveConnections(counts)
+}
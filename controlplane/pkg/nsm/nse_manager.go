@@ -15,6 +15,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/selector"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 )
 
@@ -34,7 +35,8 @@ func (nsem *nseManager) GetEndpoint(ctx context.Context, requestConnection *conn
 	span.LogObject("targetEndpoint", targetEndpoint)
 	if len(targetEndpoint) > 0 {
 		endpoint := nsem.model.GetEndpoint(targetEndpoint)
-		if endpoint != nil && ignoreEndpoints[endpoint.Endpoint.GetEndpointNSMName()] == nil {
+		if endpoint != nil && ignoreEndpoints[endpoint.Endpoint.GetEndpointNSMName()] == nil &&
+			!endpoint.Endpoint.GetNetworkServiceEndpoint().IsPaused() {
 			return endpoint.Endpoint, nil
 		}
 	}
@@ -55,30 +57,51 @@ func (nsem *nseManager) GetEndpoint(ctx context.Context, requestConnection *conn
 		span.LogError(err)
 		return nil, err
 	}
-	endpoints := nsem.filterEndpoints(endpointResponse.GetNetworkServiceEndpoints(), endpointResponse.NetworkServiceManagers, ignoreEndpoints)
+	endpoints, saturated := nsem.filterEndpoints(endpointResponse.GetNetworkServiceEndpoints(), endpointResponse.NetworkServiceManagers, ignoreEndpoints)
 
 	if len(endpoints) == 0 {
-		err = errors.Errorf("failed to find NSE for NetworkService %s. Checked: %d of total NSEs: %d",
-			requestConnection.GetNetworkService(), len(ignoreEndpoints), len(endpoints))
+		if saturated > 0 {
+			err = errors.Wrapf(selector.ErrNoCapacity, "failed to find NSE for NetworkService %s. Saturated: %d of total NSEs: %d",
+				requestConnection.GetNetworkService(), saturated, len(endpointResponse.GetNetworkServiceEndpoints()))
+		} else {
+			err = errors.Errorf("failed to find NSE for NetworkService %s. Checked: %d of total NSEs: %d",
+				requestConnection.GetNetworkService(), len(ignoreEndpoints), len(endpoints))
+		}
 		span.LogError(err)
 		return nil, err
 	}
 
-	endpointSelect := func() *registry.NetworkServiceEndpoint {
+	endpointSelect := func() (*registry.NetworkServiceEndpoint, error) {
 		if len(targetEndpoint) > 0 {
 			for _, endpoint := range endpoints {
 				if endpoint.GetName() == targetEndpoint {
-					return endpoint
+					return endpoint, nil
 				}
 			}
 
-			return nil
+			return nil, nil
+		}
+
+		ns := endpointResponse.GetNetworkService()
+		selectConnection := nsem.selectionConnection(requestConnection)
+		// Try more preferred topology groups first, and fall back to less preferred ones only if nothing is selected
+		groups := selector.SplitByTopology(ns.GetTopologyKeys(), nsem.model.GetNsm(), endpointResponse.GetNetworkServiceManagers(), endpoints)
+		for _, group := range groups {
+			endpoint, selectErr := nsem.model.GetSelector().SelectEndpoint(selectConnection, ns, group)
+			if selectErr != nil || endpoint != nil {
+				return endpoint, selectErr
+			}
 		}
 
-		return nsem.model.GetSelector().SelectEndpoint(requestConnection, endpointResponse.GetNetworkService(), endpoints)
+		return nil, nil
 	}
 
-	endpoint := endpointSelect()
+	endpoint, err := endpointSelect()
+	if err != nil {
+		err = errors.Wrapf(err, "failed to select NSE for NetworkService %s", requestConnection.GetNetworkService())
+		span.LogError(err)
+		return nil, err
+	}
 	if endpoint == nil {
 		err = errors.Errorf("failed to select NSE for NetworkService %s. Checked: %d of total NSEs: %d",
 			requestConnection.GetNetworkService(), len(ignoreEndpoints), len(endpoints))
@@ -93,6 +116,22 @@ func (nsem *nseManager) GetEndpoint(ctx context.Context, requestConnection *conn
 	}, nil
 }
 
+// selectionConnection returns the request connection with the labels available to the selector templates. The client is
+// running on the node of the local NSM, so its name is used as the node name if the client does not provide one.
+func (nsem *nseManager) selectionConnection(requestConnection *connection.Connection) *connection.Connection {
+	nodeName := nsem.model.GetNsm().GetName()
+	if _, ok := requestConnection.GetLabels()[connection.NodeNameKey]; ok || nodeName == "" {
+		return requestConnection
+	}
+
+	selectConnection := requestConnection.Clone()
+	if selectConnection.Labels == nil {
+		selectConnection.Labels = map[string]string{}
+	}
+	selectConnection.Labels[connection.NodeNameKey] = nodeName
+	return selectConnection
+}
+
 /**
 ctx - we assume it is big enought to perform connection.
 */
@@ -149,14 +188,27 @@ func (nsem *nseManager) cleanupNSE(ctx context.Context, endpoint *model.Endpoint
 	logrus.Infof("NSM: Remove Endpoint since it is not available... %v", endpoint)
 }
 
-func (nsem *nseManager) filterEndpoints(endpoints []*registry.NetworkServiceEndpoint, managers map[string]*registry.NetworkServiceManager, ignoreEndpoints map[registry.EndpointNSMName]*registry.NSERegistration) []*registry.NetworkServiceEndpoint {
+// filterEndpoints skips ignored and saturated endpoints, returns the rest endpoints and amount of saturated endpoints
+func (nsem *nseManager) filterEndpoints(endpoints []*registry.NetworkServiceEndpoint, managers map[string]*registry.NetworkServiceManager, ignoreEndpoints map[registry.EndpointNSMName]*registry.NSERegistration) ([]*registry.NetworkServiceEndpoint, int) {
 	result := []*registry.NetworkServiceEndpoint{}
+	saturated := 0
+	counts := nsem.model.ConnectionCounts()
 	// Do filter of endpoints
 	for _, candidate := range endpoints {
 		endpointName := registry.NewEndpointNSMName(candidate, managers[candidate.NetworkServiceManagerName])
-		if ignoreEndpoints[endpointName] == nil {
-			result = append(result, candidate)
+		if ignoreEndpoints[endpointName] != nil {
+			continue
+		}
+		if candidate.IsPaused() {
+			logrus.Infof("NSE %v is draining", candidate.GetName())
+			continue
+		}
+		if selector.IsSaturated(counts, candidate) {
+			logrus.Infof("NSE %v is saturated: max connections %d", candidate.GetName(), candidate.GetMaxConnections())
+			saturated++
+			continue
 		}
+		result = append(result, candidate)
 	}
-	return result
+	return result, saturated
 }
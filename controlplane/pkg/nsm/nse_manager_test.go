@@ -0,0 +1,67 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+func TestFilterEndpointsSkipsSaturated(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	mdl.AddClientConnection(context.Background(), &model.ClientConnection{
+		ConnectionID: "1",
+		Xcon: &crossconnect.CrossConnect{
+			Id:          "1",
+			Destination: &connection.Connection{Id: "1", State: connection.State_UP},
+		},
+		Endpoint: &registry.NSERegistration{
+			NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{Name: "nse1"},
+		},
+		ConnectionState: model.ClientConnectionReady,
+	})
+	// nse2 is served by another NSMD
+	mdl.SetRemoteConnectionCounts("nsm2", map[string]int{"nse2": 2})
+
+	nsem := &nseManager{model: mdl}
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "nse1", NetworkServiceManagerName: "nsm1", MaxConnections: 1},
+		{Name: "nse2", NetworkServiceManagerName: "nsm2", MaxConnections: 2},
+		{Name: "nse3", NetworkServiceManagerName: "nsm2", MaxConnections: 1},
+		{Name: "nse4", NetworkServiceManagerName: "nsm2"},
+	}
+
+	managers := map[string]*registry.NetworkServiceManager{
+		"nsm1": {Name: "nsm1"},
+		"nsm2": {Name: "nsm2"},
+	}
+
+	result, saturated := nsem.filterEndpoints(endpoints, managers, nil)
+	g.Expect(saturated).To(Equal(2))
+	g.Expect(result).To(HaveLen(2))
+	g.Expect(result[0].GetName()).To(Equal("nse3"))
+	g.Expect(result[1].GetName()).To(Equal("nse4"))
+}
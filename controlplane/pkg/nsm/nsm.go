@@ -16,6 +16,7 @@ package nsm
 import (
 	"crypto/rand"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/srv6"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
@@ -37,7 +39,9 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/properties"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
+	sdkcommon "github.com/networkservicemesh/networkservicemesh/sdk/common"
 	"github.com/networkservicemesh/networkservicemesh/sdk/monitor/connectionmonitor"
 )
 
@@ -58,6 +62,7 @@ type networkServiceManager struct {
 	stateRestored    chan bool
 	renamedEndpoints map[string]string
 	nseManager       nsm.NetworkServiceEndpointManager
+	healHistory      *healHistory
 
 	remoteService networkservice.NetworkServiceServer
 	ctx           context.Context
@@ -72,6 +77,7 @@ func (srv *networkServiceManager) LocalManager(clientConnection nsm.ClientConnec
 		common.NewRequestValidator(),
 		common.NewMonitorService(clientConnection.(*model.ClientConnection).Monitor),
 		local.NewConnectionService(srv.model),
+		common.NewAuthorizationService(srv.model, srv.serviceRegistry),
 		local.NewForwarderService(srv.model, srv.serviceRegistry),
 		local.NewEndpointSelectorService(srv.nseManager),
 		local.NewEndpointService(srv.nseManager, srv.props, srv.model),
@@ -101,6 +107,10 @@ func (srv *networkServiceManager) GetHealProperties() *properties.Properties {
 	return srv.props
 }
 
+func (srv *networkServiceManager) HealHistory() nsm.HealHistory {
+	return srv.healHistory
+}
+
 // NewNetworkServiceManager creates an instance of NetworkServiceManager
 func NewNetworkServiceManager(ctx context.Context, model model.Model, serviceRegistry serviceregistry.ServiceRegistry) nsm.NetworkServiceManager {
 	properties := properties.NewNsmProperties()
@@ -117,6 +127,7 @@ func NewNetworkServiceManager(ctx context.Context, model model.Model, serviceReg
 		stateRestored:    make(chan bool, 1),
 		renamedEndpoints: make(map[string]string),
 		nseManager:       nseManager,
+		healHistory:      newHealHistory(properties.HealHistorySize),
 		ctx:              ctx,
 	}
 
@@ -126,7 +137,12 @@ func NewNetworkServiceManager(ctx context.Context, model model.Model, serviceReg
 		properties,
 		srv,
 		nseManager,
+		srv.healHistory,
 	)
+	model.AddListener(&wireguardKeyRotationListener{manager: srv})
+	model.AddListener(&metricsListener{model: model})
+	go srv.monitorLeases(sdkcommon.LeaseRefreshInterval, sdkcommon.LeaseGracePeriod())
+	go srv.monitorDrainingEndpoints(DrainCheckIntervalEnv.GetOrDefaultDuration(DrainCheckIntervalDefault))
 
 	return srv
 }
@@ -168,6 +184,7 @@ func (srv *networkServiceManager) RestoreConnections(xcons []*crossconnect.Cross
 	for _, xcon := range xcons {
 		srv.restoreXconnection(span.Context(), xcon, logger, forwarder, manager)
 	}
+	srv.releaseStaleVnis(logger)
 	logger.Infof("All connections are recovered...")
 	// Notify state is restored
 	srv.stateRestored <- true
@@ -211,6 +228,7 @@ func (srv *networkServiceManager) restoreXconnection(ctx context.Context, xcon *
 				},
 			}
 			workspaceName = src.GetMechanism().GetParameters()[mechanismCommon.Workspace]
+			restoreLease(request)
 		}
 
 		monitor := manager.LocalConnectionMonitor(workspaceName)
@@ -340,6 +358,16 @@ func (srv *networkServiceManager) getConnectionParameters(xcon *crossconnect.Cro
 
 		networkServiceName = src.GetNetworkService()
 		endpointName = src.GetNetworkServiceEndpointName()
+
+		// VNI, SIDs and Wireguard port of the remote source are allocated by us, they should not be allocated for another connection.
+		switch src.GetMechanism().GetType() {
+		case vxlan.MECHANISM:
+			srv.restoreVni(xcon.GetId(), src.GetMechanism())
+		case srv6.MECHANISM:
+			srv.restoreSIDs(xcon.GetId(), src.GetMechanism(), srv6.DstBSID, srv6.DstLocalSID)
+		case wireguard.MECHANISM:
+			srv.restoreWireguardPort(xcon.GetId(), src.GetMechanism(), wireguard.DstPort)
+		}
 	} else if dst := xcon.GetDestination(); dst != nil && !dst.IsRemote() {
 		// Local NSE, connection is Ready
 		networkServiceName = dst.GetNetworkService()
@@ -359,24 +387,70 @@ func (srv *networkServiceManager) getConnectionParameters(xcon *crossconnect.Cro
 			vni, err3 := m.VNI()
 			if err != nil || err2 != nil || err3 != nil {
 				logrus.Errorf("Error retrieving SRC/DST IP or VNI from Remote connection %v %v", err, err2)
-			} else {
-				srv.serviceRegistry.VniAllocator().Restore(srcIP, dstIP, vni)
+			} else if err = srv.serviceRegistry.VniAllocator().Restore(xcon.GetId(), srcIP, dstIP, vni); err != nil {
+				logrus.Errorf("Error restoring VNI of Remote connection: %v", err)
 			}
 		case srv6.MECHANISM:
-			m := srv6.ToMechanism(mm)
-			hardwareAddress, err := m.DstHardwareAddress()
-			srcLocalSID, err2 := m.SrcLocalSID()
-			if err != nil || err2 != nil {
-				logrus.Errorf("Error retrieving SRC/DST IP or VNI from Remote connection %v %v", err, err2)
-			} else {
-				srv.serviceRegistry.SIDAllocator().Restore(hardwareAddress, srcLocalSID)
-			}
+			srv.restoreSIDs(xcon.GetId(), mm, srv6.SrcBSID, srv6.SrcLocalSID)
+		case wireguard.MECHANISM:
+			srv.restoreWireguardPort(xcon.GetId(), mm, wireguard.SrcPort)
 			// Add other mechanisms support here
 		}
 	}
 	return connectionState, networkServiceName, endpointName
 }
 
+// restoreVni restores VNI allocated for the remote source VXLAN mechanism
+func (srv *networkServiceManager) restoreVni(connectionID string, mechanism *connection.Mechanism) {
+	vniID, err := vxlan.ToMechanism(mechanism).VNI()
+	if err != nil {
+		logrus.Errorf("Error retrieving VNI from Remote connection %v", err)
+		return
+	}
+	localIP, remoteIP := vni.MechanismPair(mechanism.GetParameters())
+	if err = srv.serviceRegistry.VniAllocator().Restore(connectionID, localIP, remoteIP, vniID); err != nil {
+		logrus.Errorf("Error restoring VNI of Remote connection: %v", err)
+	}
+}
+
+// releaseStaleVnis releases VNIs persisted for the connections which are not restored into the model
+func (srv *networkServiceManager) releaseStaleVnis(logger logrus.FieldLogger) {
+	allocator := srv.serviceRegistry.VniAllocator()
+	for _, connectionID := range allocator.ConnectionIDs() {
+		if srv.model.GetClientConnection(connectionID) == nil {
+			logger.Infof("Releasing VNI of not restored connection %s", connectionID)
+			allocator.Release(connectionID)
+		}
+	}
+}
+
+// restoreSIDs restores SIDs allocated for the SRv6 mechanism, keys are names of the mechanism parameters allocated by us
+// in the order SIDAllocator().SIDs returns them
+func (srv *networkServiceManager) restoreSIDs(connectionID string, mechanism *connection.Mechanism, keys ...string) {
+	for _, key := range keys {
+		sid, ok := mechanism.GetParameters()[key]
+		if !ok {
+			logrus.Errorf("Error retrieving %s from Remote connection", key)
+			continue
+		}
+		if err := srv.serviceRegistry.SIDAllocator().Restore(connectionID, sid); err != nil {
+			logrus.Errorf("Error restoring %s of Remote connection: %v", key, err)
+		}
+	}
+}
+
+// restoreWireguardPort restores Wireguard listen port allocated for the mechanism, key is name of the mechanism parameter allocated by us
+func (srv *networkServiceManager) restoreWireguardPort(connectionID string, mechanism *connection.Mechanism, key string) {
+	port, err := strconv.Atoi(mechanism.GetParameters()[key])
+	if err != nil {
+		logrus.Errorf("Error retrieving %s from Remote connection: %v", key, err)
+		return
+	}
+	if err = srv.serviceRegistry.WireguardPortAllocator().Restore(connectionID, port); err != nil {
+		logrus.Errorf("Error restoring %s of Remote connection: %v", key, err)
+	}
+}
+
 func (srv *networkServiceManager) closeLocalMissingNSE(ctx context.Context, cc nsm.ClientConnection) {
 	logrus.Infof("Local endpoint is not available, so closing local NSE connection %v", cc)
 	err := srv.CloseConnection(ctx, cc)
@@ -17,6 +17,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/common"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
 
 	"github.com/sirupsen/logrus"
 
@@ -34,6 +35,7 @@ type healProcessor struct {
 	healCancellersMutex sync.Mutex
 	manager             nsm.NetworkServiceRequestManager
 	nseManager          nsm.NetworkServiceEndpointManager
+	history             *healHistory
 
 	eventCh chan healEvent
 }
@@ -50,13 +52,15 @@ func newNetworkServiceHealProcessor(
 	model model.Model,
 	properties *properties.Properties,
 	manager nsm.NetworkServiceRequestManager,
-	nseManager nsm.NetworkServiceEndpointManager) nsm.NetworkServiceHealProcessor {
+	nseManager nsm.NetworkServiceEndpointManager,
+	history *healHistory) nsm.NetworkServiceHealProcessor {
 	p := &healProcessor{
 		serviceRegistry: serviceRegistry,
 		model:           model,
 		props:           properties,
 		manager:         manager,
 		nseManager:      nseManager,
+		history:         history,
 		eventCh:         make(chan healEvent, 1),
 		healCancellers:  make(map[string]func()),
 	}
@@ -160,6 +164,9 @@ func (p *healProcessor) serve() {
 			}()
 
 			healed := false
+			outcome := metrics.HealOutcomeHealed
+			metrics.ObserveHealAttempt(e.healState.String())
+			record := p.history.start(e.healID, e.cc, e.healState)
 
 			ctx = common.WithModelConnection(ctx, e.cc)
 
@@ -168,33 +175,61 @@ func (p *healProcessor) serve() {
 				healed = p.healDstDown(ctx, e.cc)
 			case nsm.HealStateForwarderDown:
 				healed = p.healForwarderDown(ctx, e.cc)
-			case nsm.HealStateDstUpdate:
+			case nsm.HealStateDstUpdate, nsm.HealStateWireguardKeyRotation:
 				healed = p.healDstUpdate(ctx, e.cc)
 			case nsm.HealStateDstNmgrDown:
 				healed = p.healDstMgrDown(ctx, e.cc)
+			case nsm.HealStateDstDrain:
+				var moved bool
+				if healed, moved = p.healDstDrain(ctx, e.cc); !moved {
+					outcome = metrics.HealOutcomeKept
+				}
+			case nsm.HealStateForwarderDrain:
+				healed = p.healForwarderDrain(ctx, e.cc)
 			}
 
 			if healed {
-				span.LogValue("status", "healed")
-				logger.Infof("NSM_Heal(%v) Heal: Connection recovered: %v", e.healID, e.cc)
+				span.LogValue("status", outcome)
+				logger.Infof("NSM_Heal(%v) Heal: Connection %s: %v", e.healID, outcome, e.cc)
 				p.healCancellersMutex.Lock()
 				delete(p.healCancellers, e.cc.GetID())
 				p.healCancellersMutex.Unlock()
+				p.finishHeal(record, outcome, p.model.GetClientConnection(e.cc.GetID()))
 			} else {
 				span.LogValue("status", "closing")
 				_ = p.CloseConnection(ctx, e.cc)
+				p.finishHeal(record, metrics.HealOutcomeClosed, nil)
 			}
 		}()
 	}
 }
 
+// finishHeal records the outcome of the heal in the history and metrics, cc is the healed connection or nil if the
+// connection is closed
+func (p *healProcessor) finishHeal(record *nsm.HealEvent, outcome string, cc *model.ClientConnection) {
+	healState := record.HealState.String()
+	metrics.ObserveHealOutcome(healState, outcome, record.StartTime)
+	if cc != nil {
+		if endpoint := cc.Endpoint.GetNetworkServiceEndpoint().GetName(); endpoint != record.Endpoint {
+			metrics.ObserveHealMove(healState, metrics.HealTargetEndpoint)
+		}
+		if cc.ForwarderRegisteredName != record.Forwarder {
+			metrics.ObserveHealMove(healState, metrics.HealTargetForwarder)
+		}
+	}
+	p.history.finish(record, outcome, cc)
+}
+
 func (p *healProcessor) healDstDown(ctx context.Context, cc *model.ClientConnection) bool {
 	span := spanhelper.FromContext(ctx, "healDstDown")
 	defer span.Finish()
 
 	logger := span.Logger()
-	// Update context
-	ctx = span.Context()
+
+	policy := p.healPolicy(cc)
+	span.LogObject("healPolicy", policy)
+	ctx, cancel := withHealDeadline(span.Context(), policy)
+	defer cancel()
 
 	logger.Infof("NSM_Heal(1.1.1) Checking if DST die is NSMD/DST die...")
 	// Check if this is a really HealStateDstDown or HealStateDstNmgrDown
@@ -221,35 +256,18 @@ func (p *healProcessor) healDstDown(ctx context.Context, cc *model.ClientConnect
 	}
 	logger.Infof("NSM_Heal(2.2) Starting DST Heal...")
 	// We are client NSMd, we need to try recover our connection srv.
-	// Wait for NSE not equal to down one, since we know it will be re-registered with new endpoint name.
-	ctx = p.waitForNSEUpdateContext(ctx, cc.Endpoint, cc)
-	// Fallback to heal with choose of new NSE.
-	for attempt := 0; attempt < p.props.HealRetryCount; attempt++ {
-		// If client context is cancelled, we need to stop attempts.
-		if ctx.Err() != nil {
-			logger.Info("Client context is broken, stopping heal attempts")
-			break
-		}
-
-		attemptSpan := spanhelper.FromContext(ctx, fmt.Sprintf("healing-attempt-%v", attempt))
-		requestCtx, requestCancel := context.WithTimeout(attemptSpan.Context(), p.props.HealRequestTimeout)
-		defer requestCancel()
-		defer attemptSpan.Finish()
-
-		logger.Infof("NSM_Heal(2.3.0) Starting Heal by calling request: %v", cc.Request)
+	// By default wait for NSE not equal to down one, since we know it will be re-registered with new endpoint name.
+	ctx = p.waitEndpointContext(ctx, cc, policy, p.nseIsNewAndAvailable)
+	logger.Infof("NSM_Heal(2.3.0) Starting Heal by calling request: %v", cc.Request)
+	if err := p.retryHeal(ctx, policy, func(requestCtx context.Context) error {
 		_, err := p.manager.LocalManager(cc).Request(requestCtx, cc.Request)
+		return err
+	}); err != nil {
+		logger.Errorf("NSM_Heal(2.3.1) Failed to heal connection: %v", err)
 		span.LogError(err)
-		if err == nil {
-			return true
-		}
-		logger.Errorf("NSM_Heal(2.3.1) Failed to heal connection: %v. Delaying: %v", err, p.props.HealRetryDelay)
-		if attempt+1 < p.props.HealRetryCount {
-			attemptSpan.Finish()
-			<-time.After(p.props.HealRetryDelay)
-			continue
-		}
+		return false
 	}
-	return false
+	return true
 }
 
 func (p *healProcessor) healForwarderDown(ctx context.Context, cc *model.ClientConnection) bool {
@@ -257,8 +275,13 @@ func (p *healProcessor) healForwarderDown(ctx context.Context, cc *model.ClientC
 	ctx, cancel = context.WithTimeout(ctx, p.props.HealTimeout)
 	defer cancel()
 
+	policy := p.healPolicy(cc)
+	ctx, cancel = withHealDeadline(ctx, policy)
+	defer cancel()
+
 	span := spanhelper.FromContext(ctx, "healForwarderDown")
 	defer span.Finish()
+	span.LogObject("healPolicy", policy)
 
 	logger := span.Logger()
 	// Forwarder is down, we only need to re-programm forwarder.
@@ -286,7 +309,10 @@ func (p *healProcessor) healForwarderDown(ctx context.Context, cc *model.ClientC
 	request := cc.Request.Clone()
 	request.SetRequestConnection(cc.GetConnectionSource())
 
-	if _, err := p.manager.LocalManager(cc).Request(span.Context(), cc.Request); err != nil {
+	if err := p.retryHeal(span.Context(), policy, func(requestCtx context.Context) error {
+		_, err := p.manager.LocalManager(cc).Request(requestCtx, cc.Request)
+		return err
+	}); err != nil {
 		logger.Errorf("NSM_Heal(3.5) Failed to heal connection: %v", err)
 		return false
 	}
@@ -294,6 +320,76 @@ func (p *healProcessor) healForwarderDown(ctx context.Context, cc *model.ClientC
 	return true
 }
 
+// healForwarderDrain moves the connection from the draining forwarder to another one. It is break-before-make: the
+// forwarders program the same interfaces (kernel interface in the client namespace, VXLAN with the same VNI, memif
+// socket), so the cross connection can't exist on both of them at once. The connection is down from closing the
+// cross connection on the draining forwarder until the other one programs it, the gap is observed by
+// metrics.ObserveForwarderDrainGap
+func (p *healProcessor) healForwarderDrain(ctx context.Context, cc *model.ClientConnection) bool {
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithTimeout(ctx, p.props.HealTimeout)
+	defer cancel()
+
+	span := spanhelper.FromContext(ctx, "healForwarderDrain")
+	defer span.Finish()
+	ctx = span.Context()
+
+	logger := span.Logger()
+	if cc.Request == nil {
+		return false
+	}
+
+	// Forwarders can't program the same interfaces at the same time, so the cross connection should be closed on
+	// the draining one first.
+	logger.Infof("NSM_Heal(8.1) Closing cross connection on the draining Forwarder %v...", cc.ForwarderRegisteredName)
+	brokenAt := time.Now()
+	p.closeForwarderCrossConnection(ctx, cc)
+	p.model.ApplyClientConnectionChanges(ctx, cc.GetID(), func(modelCC *model.ClientConnection) {
+		modelCC.ForwarderState = model.ForwarderStateNone
+	})
+
+	// Draining Forwarder is not selected anymore, so request will program another one.
+	request := cc.Request.Clone()
+	request.SetRequestConnection(cc.GetConnectionSource())
+
+	logger.Infof("NSM_Heal(8.2) Moving connection to another Forwarder: %v", request)
+	if err := p.performRequest(ctx, request, cc); err != nil {
+		span.LogError(err)
+		logger.Errorf("NSM_Heal(8.3) Failed to move connection: %v", err)
+		return false
+	}
+
+	gap := time.Since(brokenAt)
+	logger.Infof("NSM_Heal(8.3) Connection is moved from Forwarder %v, it was down for %v", cc.ForwarderRegisteredName, gap)
+	metrics.ObserveForwarderDrainGap(cc.ForwarderRegisteredName, gap)
+	return true
+}
+
+func (p *healProcessor) closeForwarderCrossConnection(ctx context.Context, cc *model.ClientConnection) {
+	span := spanhelper.FromContext(ctx, "closeForwarderCrossConnection")
+	defer span.Finish()
+
+	forwarder := p.model.GetForwarder(cc.ForwarderRegisteredName)
+	if forwarder == nil || cc.ForwarderState == model.ForwarderStateNone {
+		return
+	}
+	closeCtx, closeCancel := context.WithTimeout(span.Context(), p.props.CloseTimeout)
+	defer closeCancel()
+
+	forwarderClient, conn, err := p.serviceRegistry.ForwarderConnection(closeCtx, forwarder)
+	if err != nil {
+		span.LogError(err)
+		return
+	}
+	if conn != nil {
+		defer func() { _ = conn.Close() }()
+	}
+
+	if _, err = forwarderClient.Close(closeCtx, cc.Xcon); err != nil {
+		span.LogError(err)
+	}
+}
+
 func (p *healProcessor) healDstUpdate(ctx context.Context, cc *model.ClientConnection) bool {
 	span := spanhelper.FromContext(ctx, "healDstUpdate")
 	defer span.Finish()
@@ -340,44 +436,87 @@ func (p *healProcessor) performRequest(ctx context.Context, request *networkserv
 func (p *healProcessor) healDstMgrDown(ctx context.Context, cc *model.ClientConnection) bool {
 	span := spanhelper.FromContext(ctx, "healDstNsmgrDown")
 	defer span.Finish()
-	ctx = span.Context()
 	logger := span.Logger()
 	logger.Infof("NSM_Heal(6.1) Starting DST + NSMGR Heal...")
 
-	var endpointName string
-	// Wait for exact same NSE to be available with NSMD connection alive.
-	if cc.Endpoint != nil {
-		endpointName = cc.Endpoint.GetNetworkServiceEndpoint().GetName()
-		waitCtx, waitCancel := context.WithTimeout(ctx, p.props.HealTimeout*3)
-		defer waitCancel()
-		if !p.waitNSE(waitCtx, endpointName, cc.GetNetworkService(), p.nseIsSameAndAvailable) {
-			span.LogValue("waitNSE", "failed to find endpoint by name with timeout")
-			ctx = common.WithIgnoredEndpoints(ctx, map[registry.EndpointNSMName]*registry.NSERegistration{
-				cc.Endpoint.GetEndpointNSMName(): cc.Endpoint,
-			})
-		}
+	policy := p.healPolicy(cc)
+	span.LogObject("healPolicy", policy)
+	ctx, cancel := withHealDeadline(span.Context(), policy)
+	defer cancel()
+
+	// By default wait for exact same NSE to be available with NSMD connection alive.
+	ctx = p.waitEndpointContext(ctx, cc, policy, p.nseIsSameAndAvailable)
+	if err := p.retryHeal(ctx, policy, func(requestCtx context.Context) error {
+		return p.performRequest(requestCtx, cc.Request, cc)
+	}); err != nil {
+		span.LogError(errors.Wrap(err, "heal(6.2.3) Failed to heal connection"))
 	}
-	for attempt := 0; attempt < p.props.HealRetryCount; attempt++ {
-		attemptSpan := spanhelper.FromContext(ctx, fmt.Sprintf("healing-attempt-%v", attempt))
-		requestCtx, requestCancel := context.WithTimeout(attemptSpan.Context(), p.props.HealRequestTimeout)
-		defer requestCancel()
-		defer attemptSpan.Finish()
-		err := p.performRequest(requestCtx, cc.Request, cc)
-		if err == nil {
-			attemptSpan.LogObject("state", "healed")
-			return true
-		}
-		err = errors.Errorf("heal(6.2.3) Failed to heal connection: %v. Delaying: %v", err, p.props.HealRetryDelay)
+
+	return true
+}
+
+// healDstDrain moves the connection from the draining endpoint to another one, moved is false if there is no other
+// endpoint and the connection is kept on the draining one
+func (p *healProcessor) healDstDrain(ctx context.Context, cc *model.ClientConnection) (healed, moved bool) {
+	span := spanhelper.FromContext(ctx, "healDstDrain")
+	defer span.Finish()
+	ctx = span.Context()
+	logger := span.Logger()
+
+	drainingEndpoint := cc.Endpoint
+	drainingDst := cc.Xcon.GetDestination()
+
+	logger.Infof("NSM_Heal(7.1) Waiting for another NSE to move connection from the draining one...")
+	waitCtx, waitCancel := context.WithTimeout(ctx, p.props.HealTimeout*3)
+	defer waitCancel()
+	if cc.Request == nil || !p.waitNSE(waitCtx, drainingEndpoint.GetNetworkServiceEndpoint().GetName(), cc.GetNetworkService(), p.nseIsNewAndAvailable) {
+		// There is nowhere to move, so keep connection on the draining NSE while it is still alive.
+		logger.Infof("NSM_Heal(7.2) No NSE is available, connection is kept on the draining NSE")
+		p.model.ApplyClientConnectionChanges(ctx, cc.GetID(), func(modelCC *model.ClientConnection) {
+			modelCC.ConnectionState = model.ClientConnectionReady
+		})
+		return true, false
+	}
+
+	// Request will select another NSE and re-program Forwarder with it, connection to the draining NSE is still alive.
+	logger.Infof("NSM_Heal(7.3) Moving connection to another NSE: %v", cc.Request)
+	ctx = common.WithIgnoredEndpoints(ctx, map[registry.EndpointNSMName]*registry.NSERegistration{
+		drainingEndpoint.GetEndpointNSMName(): drainingEndpoint,
+	})
+	requestCtx, requestCancel := context.WithTimeout(ctx, p.props.HealRequestTimeout)
+	defer requestCancel()
+	if _, err := p.manager.LocalManager(cc).Request(requestCtx, cc.Request); err != nil {
 		span.LogError(err)
-		attemptSpan.Finish()
-		if attempt+1 < p.props.HealRetryCount {
-			attemptSpan.Finish()
-			<-time.After(p.props.HealRetryDelay)
-			continue
-		}
+		logger.Errorf("NSM_Heal(7.4) Failed to move connection: %v", err)
+		return false, false
 	}
 
-	return true
+	// Forwarder doesn't use the draining NSE anymore, so it is safe to close the old connection now.
+	logger.Infof("NSM_Heal(7.5) Closing connection to the draining NSE...")
+	p.closeDrainedDestination(ctx, drainingEndpoint, drainingDst)
+	return true, true
+}
+
+func (p *healProcessor) closeDrainedDestination(ctx context.Context, endpoint *registry.NSERegistration, dst *connection.Connection) {
+	span := spanhelper.FromContext(ctx, "closeDrainedDestination")
+	defer span.Finish()
+
+	if dst == nil {
+		return
+	}
+	closeCtx, closeCancel := context.WithTimeout(span.Context(), p.props.CloseTimeout)
+	defer closeCancel()
+
+	client, err := p.nseManager.CreateNSEClient(closeCtx, endpoint)
+	if err != nil {
+		span.LogError(err)
+		return
+	}
+	defer func() { _ = client.Cleanup() }()
+
+	if err = client.Close(closeCtx, dst); err != nil {
+		span.LogError(err)
+	}
 }
 
 type nseValidator func(ctx context.Context, endpoint string, reg *registry.NSERegistration) bool
@@ -387,6 +526,10 @@ func (p *healProcessor) nseIsNewAndAvailable(ctx context.Context, endpointName s
 		// Skip ignored endpoint
 		return false
 	}
+	if reg.GetNetworkServiceEndpoint().IsPaused() {
+		// Skip draining endpoint
+		return false
+	}
 
 	// Check remote is accessible.
 	if p.nseManager.CheckUpdateNSE(ctx, reg) {
@@ -440,47 +583,189 @@ func (p *healProcessor) waitNSE(ctx context.Context, endpointName, networkServic
 		logger.Infof("Complete Waiting for Remote NSE/NSMD with network service %s. Since elapsed: %v", networkService, time.Since(st))
 	}()
 
-	for {
+	validate := func(endpointResponse *registry.FindNetworkServiceResponse) bool {
+		for _, ep := range endpointResponse.NetworkServiceEndpoints {
+			reg := &registry.NSERegistration{
+				NetworkServiceManager:  endpointResponse.GetNetworkServiceManagers()[ep.GetNetworkServiceManagerName()],
+				NetworkServiceEndpoint: ep,
+				NetworkService:         endpointResponse.GetNetworkService(),
+			}
+
+			if nseValidator(ctx, endpointName, reg) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Network service may be already updated before the watch is started
+	endpointResponse, findErr := discoveryClient.FindNetworkService(ctx, nseRequest)
+	if findErr == nil && ctx.Err() == nil && validate(endpointResponse) {
+		return true
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, p.props.HealDSTNSEWaitTimeout)
+	defer waitCancel()
+
+	for endpointResponse = range p.networkServiceUpdates(waitCtx, discoveryClient, nseRequest, endpointResponse) {
 		logger.Infof("NSM: RemoteNSE: Waiting for NSE with network service %s. Since elapsed: %v", networkService, time.Since(st))
+		if validate(endpointResponse) {
+			return true
+		}
+	}
 
-		// If client context was cancelled, we need to stop waiting.
-		if ctx.Err() != nil {
-			logger.Infof("Client context is cancelled, stop waiting for network service %s", networkService)
+	// If client context was cancelled, we need to stop waiting.
+	if ctx.Err() != nil {
+		logger.Infof("Client context is cancelled, stop waiting for network service %s", networkService)
+		return false
+	}
+	span.LogError(errors.Errorf("timeout waiting for NetworkService: %v timeout: %v", networkService, time.Since(st)))
+	return false
+}
+
+// networkServiceUpdates - returns channel of the network service updates streamed by the registry watch, falls back to
+// polling the registry every HealDSTNSEWaitTick if it is not able to watch. The latest response is sent again every
+// HealDSTNSEWaitTick, since its endpoint can become available without the network service update: it may be registered
+// before it is added to the model, or its NSMD may be not reachable yet. The channel is closed when ctx is done.
+func (p *healProcessor) networkServiceUpdates(ctx context.Context, discoveryClient registry.NetworkServiceDiscoveryClient, nseRequest *registry.FindNetworkServiceRequest, latest *registry.FindNetworkServiceResponse) <-chan *registry.FindNetworkServiceResponse {
+	updates := make(chan *registry.FindNetworkServiceResponse)
+	send := func(response *registry.FindNetworkServiceResponse) bool {
+		select {
+		case updates <- response:
+			return true
+		case <-ctx.Done():
 			return false
 		}
+	}
+	go func() {
+		defer close(updates)
 
-		endpointResponse, err := discoveryClient.FindNetworkService(ctx, nseRequest)
+		stream, err := discoveryClient.WatchNetworkService(ctx, nseRequest)
 		if err == nil {
-			for _, ep := range endpointResponse.NetworkServiceEndpoints {
-				reg := &registry.NSERegistration{
-					NetworkServiceManager:  endpointResponse.GetNetworkServiceManagers()[ep.GetNetworkServiceManagerName()],
-					NetworkServiceEndpoint: ep,
-					NetworkService:         endpointResponse.GetNetworkService(),
+			watched := make(chan *registry.FindNetworkServiceResponse)
+			go func() {
+				defer close(watched)
+				for {
+					response, recvErr := stream.Recv()
+					if recvErr != nil {
+						err = recvErr
+						return
+					}
+					select {
+					case watched <- response:
+					case <-ctx.Done():
+						return
+					}
 				}
+			}()
 
-				if nseValidator(ctx, endpointName, reg) {
-					return true
+			for watching := true; watching; {
+				select {
+				case <-ctx.Done():
+					return
+				case response, ok := <-watched:
+					if watching = ok; ok {
+						latest = response
+					}
+				case <-time.After(p.props.HealDSTNSEWaitTick):
+				}
+				if watching && latest != nil && !send(latest) {
+					return
 				}
 			}
 		}
+		if ctx.Err() != nil {
+			return
+		}
+		logrus.Warnf("Failed to watch network service %s, polling it: %v", nseRequest.GetNetworkServiceName(), err)
 
-		if time.Since(st) > p.props.HealDSTNSEWaitTimeout {
-			span.LogError(errors.Errorf("timeout waiting for NetworkService: %v timeout: %v", networkService, time.Since(st)))
-			return false
+		for {
+			if response, findErr := discoveryClient.FindNetworkService(ctx, nseRequest); findErr == nil && !send(response) {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(p.props.HealDSTNSEWaitTick):
+			}
 		}
-		// Wait a bit
-		<-time.After(p.props.HealDSTNSEWaitTick)
-	}
+	}()
+	return updates
 }
 
-func (p *healProcessor) waitForNSEUpdateContext(ctx context.Context, endpoint *registry.NSERegistration, cc *model.ClientConnection) context.Context {
+// waitEndpointContext - waits for the endpoint to heal the connection to, returns context ignoring the connection
+// endpoint if the heal policy re-selects endpoints or no endpoint satisfying defaultValidator has appeared
+func (p *healProcessor) waitEndpointContext(ctx context.Context, cc *model.ClientConnection, policy *properties.HealPolicy, defaultValidator nseValidator) context.Context {
+	if cc.Endpoint == nil {
+		return ctx
+	}
+	endpointName := cc.Endpoint.GetNetworkServiceEndpoint().GetName()
+	// Mark endpoint as ignored.
+	ignoreCtx := common.WithIgnoredEndpoints(ctx, map[registry.EndpointNSMName]*registry.NSERegistration{
+		cc.Endpoint.GetEndpointNSMName(): cc.Endpoint,
+	})
+
 	waitCtx, waitCancel := context.WithTimeout(ctx, p.props.HealTimeout*3)
 	defer waitCancel()
-	if !p.waitNSE(waitCtx, endpoint.NetworkServiceEndpoint.Name, cc.GetNetworkService(), p.nseIsNewAndAvailable) {
-		// Mark endpoint as ignored.
-		return common.WithIgnoredEndpoints(ctx, map[registry.EndpointNSMName]*registry.NSERegistration{
-			endpoint.GetEndpointNSMName(): cc.Endpoint,
-		})
+	switch policy.Endpoint {
+	case properties.HealEndpointReselect:
+		p.waitNSE(waitCtx, endpointName, cc.GetNetworkService(), p.nseIsNewAndAvailable)
+		return ignoreCtx
+	case properties.HealEndpointWaitSame:
+		// Heal attempts are retried to the same endpoint even if it has not appeared yet.
+		p.waitNSE(waitCtx, endpointName, cc.GetNetworkService(), p.nseIsSameAndAvailable)
+		return ctx
+	}
+	if !p.waitNSE(waitCtx, endpointName, cc.GetNetworkService(), defaultValidator) {
+		return ignoreCtx
 	}
 	return ctx
 }
+
+// healPolicy - returns the heal policy of the Network Service of the connection
+func (p *healProcessor) healPolicy(cc *model.ClientConnection) *properties.HealPolicy {
+	return p.props.NetworkServiceHealPolicy(cc.Endpoint.GetNetworkService())
+}
+
+// retryHeal - calls request until it succeeds, the heal policy runs out of attempts or ctx is done
+func (p *healProcessor) retryHeal(ctx context.Context, policy *properties.HealPolicy, request func(ctx context.Context) error) error {
+	span := spanhelper.FromContext(ctx, "retryHeal")
+	defer span.Finish()
+	ctx = span.Context()
+
+	logger := span.Logger()
+	err := errors.New("heal policy allows no attempts")
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			delay := policy.Backoff(attempt - 1)
+			logger.Errorf("NSM_Heal Attempt %v failed to heal connection: %v. Delaying: %v", attempt-1, err, delay)
+			select {
+			case <-ctx.Done():
+			case <-time.After(delay):
+			}
+		}
+		// If client context is cancelled or heal deadline is exceeded, we need to stop attempts.
+		if ctx.Err() != nil {
+			return errors.Wrapf(ctx.Err(), "heal is stopped after %v attempts, last error: %v", attempt, err)
+		}
+
+		attemptSpan := spanhelper.FromContext(ctx, fmt.Sprintf("healing-attempt-%v", attempt))
+		requestCtx, requestCancel := context.WithTimeout(attemptSpan.Context(), p.props.HealRequestTimeout)
+		err = request(requestCtx)
+		requestCancel()
+		attemptSpan.LogError(err)
+		attemptSpan.Finish()
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Wrapf(err, "all %v heal attempts failed", policy.MaxAttempts)
+}
+
+// withHealDeadline - limits ctx with the deadline of the heal policy if it is set
+func withHealDeadline(ctx context.Context, policy *properties.HealPolicy) (context.Context, context.CancelFunc) {
+	if policy.Deadline > 0 {
+		return context.WithTimeout(ctx, policy.Deadline)
+	}
+	return context.WithCancel(ctx)
+}
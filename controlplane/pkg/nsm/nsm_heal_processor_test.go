@@ -7,6 +7,7 @@ import (
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/properties"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 
 	unified "github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
@@ -27,6 +28,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 	test_utils "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/tests/utils"
+	forwarderapi "github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarder"
 )
 
 const (
@@ -36,6 +38,7 @@ const (
 	remoteNSMName = "nsm-remote"
 
 	forwarder1Name = "forwarder-1"
+	forwarder2Name = "forwarder-2"
 
 	nse1Name = "nse-1"
 	nse2Name = "nse-2"
@@ -94,6 +97,7 @@ func newHealTestData() *healTestData {
 		discoveryClient: &discoveryClientStub{
 			response: data.createFindNetworkServiceResponse(),
 		},
+		forwarderClient: &forwarderClientStub{},
 	}
 	data.connectionManager = &connectionManagerStub{
 		model: data.model,
@@ -112,6 +116,7 @@ func newHealTestData() *healTestData {
 			HealEnabled:               true,
 			HealRetryCount:            1,
 			HealRequestConnectTimeout: 15 * time.Second,
+			HealDSTNSEWaitTick:        10 * time.Millisecond,
 		},
 		nseManager: data.nseManager,
 		manager:    data.connectionManager,
@@ -182,6 +187,62 @@ func TestHealDstDown_LocalClientLocalEndpoint(t *testing.T) {
 		Verify(t)
 }
 
+func TestHealDstDown_LocalClientLocalEndpoint_WatchNSE(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealDSTNSEWaitTimeout = time.Second
+
+	nse1 := data.createEndpoint(nse1Name, localNSMName)
+
+	nse2 := data.createEndpoint(nse2Name, localNSMName)
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse2,
+	})
+
+	xcon := data.createCrossConnection(false, false, "src", "dst")
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, localNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	updates := make(chan *registry.FindNetworkServiceResponse)
+	data.serviceRegistry.discoveryClient.updates = updates
+	data.connectionManager.nse = nse2
+	go func() {
+		updates <- data.createFindNetworkServiceResponse()
+		updates <- data.createFindNetworkServiceResponse(nse2)
+	}()
+
+	healed := data.healProcessor.healDstDown(context.Background(), data.cloneClientConnection(connection))
+	g.Expect(healed).To(BeTrue())
+
+	test_utils.NewModelVerifier(data.model).
+		EndpointNotExists(nse1Name).
+		EndpointExists(nse2Name, localNSMName).
+		ClientConnectionExists("id", "src", "dst", localNSMName, nse2Name, forwarder1Name).
+		ForwarderExists(forwarder1Name).
+		Verify(t)
+}
+
+func TestWaitNSE_WatchNSENotAvailableYet(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealDSTNSEWaitTimeout = time.Second
+
+	// NSE is registered, but the first check fails as it is not added to the model yet
+	nse2 := data.createEndpoint(nse2Name, localNSMName)
+	data.nseManager.nses = append(data.nseManager.nses, nse2)
+	data.nseManager.checkFailures = 1
+
+	updates := make(chan *registry.FindNetworkServiceResponse)
+	data.serviceRegistry.discoveryClient.updates = updates
+	go func() {
+		updates <- data.createFindNetworkServiceResponse(nse2)
+	}()
+
+	g.Expect(data.healProcessor.waitNSE(context.Background(), nse1Name, networkServiceName, data.healProcessor.nseIsNewAndAvailable)).To(BeTrue())
+	g.Expect(data.nseManager.checkFailures).To(BeZero())
+}
+
 func TestHealDstDown_LocalClientLocalEndpoint_NoNSEFound(t *testing.T) {
 	g := NewWithT(t)
 	data := newHealTestData()
@@ -231,6 +292,58 @@ func TestHealDstDown_LocalClientLocalEndpoint_RequestFailed(t *testing.T) {
 		Verify(t)
 }
 
+func TestHealDstDown_LocalClientLocalEndpoint_HealPolicyAttempts(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+
+	nse1 := data.createEndpoint(nse1Name, localNSMName)
+	nse1.NetworkService.HealPolicy = &registry.HealPolicy{
+		InitialBackoff: ptypes.DurationProto(time.Millisecond),
+		MaxAttempts:    3,
+	}
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse1,
+	})
+
+	xcon := data.createCrossConnection(false, false, "src", "dst")
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, localNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	data.connectionManager.requestError = errors.New("request error")
+
+	healed := data.healProcessor.healDstDown(context.Background(), data.cloneClientConnection(connection))
+	g.Expect(healed).To(BeFalse())
+	g.Expect(data.connectionManager.requests).To(Equal(3))
+}
+
+func TestHealDstDown_LocalClientLocalEndpoint_HealPolicyWaitSame(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+
+	nse1 := data.createEndpoint(nse1Name, localNSMName)
+	nse1.NetworkService.HealPolicy = &registry.HealPolicy{
+		Endpoint: properties.HealEndpointWaitSame,
+	}
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse1,
+	})
+
+	xcon := data.createCrossConnection(false, false, "src", "dst")
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, localNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	healed := data.healProcessor.healDstDown(context.Background(), data.cloneClientConnection(connection))
+	g.Expect(healed).To(BeTrue())
+
+	test_utils.NewModelVerifier(data.model).
+		EndpointExists(nse1Name, localNSMName).
+		ClientConnectionExists("id", "src", "dst", localNSMName, nse1Name, forwarder1Name).
+		ForwarderExists(forwarder1Name).
+		Verify(t)
+}
+
 func TestHealDstDown_LocalClientRemoteEndpoint(t *testing.T) {
 	g := NewWithT(t)
 	data := newHealTestData()
@@ -285,9 +398,212 @@ func TestHealDstDown_LocalClientRemoteEndpoint_NoNSEFound(t *testing.T) {
 		Verify(t)
 }
 
+func TestHealDstDrain_LocalClientLocalEndpoint(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealTimeout = time.Second
+
+	nse1 := data.createEndpoint(nse1Name, localNSMName)
+	nse1.NetworkServiceEndpoint.State = registry.EndpointStatePaused
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse1,
+	})
+
+	nse2 := data.createEndpoint(nse2Name, localNSMName)
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse2,
+	})
+	data.nseManager.nses = append(data.nseManager.nses, nse2)
+
+	xcon := data.createCrossConnection(false, false, "src", "dst")
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, localNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	data.serviceRegistry.discoveryClient.response = data.createFindNetworkServiceResponse(nse1, nse2)
+	data.connectionManager.nse = nse2
+
+	healed, moved := data.healProcessor.healDstDrain(context.Background(), data.cloneClientConnection(connection))
+	g.Expect(healed).To(BeTrue())
+	g.Expect(moved).To(BeTrue())
+
+	g.Expect(data.nseManager.nseClients[nse1Name].closed.GetId()).To(Equal("dst"))
+	g.Expect(data.nseManager.nseClients[nse1Name].cleanedUp).To(BeTrue())
+
+	test_utils.NewModelVerifier(data.model).
+		EndpointExists(nse1Name, localNSMName).
+		EndpointExists(nse2Name, localNSMName).
+		ClientConnectionExists("id", "src", "dst", localNSMName, nse2Name, forwarder1Name).
+		ForwarderExists(forwarder1Name).
+		Verify(t)
+}
+
+func TestHealDstDrain_LocalClientLocalEndpoint_NoNSEFound(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealTimeout = time.Second
+
+	nse1 := data.createEndpoint(nse1Name, localNSMName)
+	nse1.NetworkServiceEndpoint.State = registry.EndpointStatePaused
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse1,
+	})
+	data.nseManager.nses = append(data.nseManager.nses, nse1)
+
+	xcon := data.createCrossConnection(false, false, "src", "dst")
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, localNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	data.serviceRegistry.discoveryClient.response = data.createFindNetworkServiceResponse(nse1)
+
+	healed, moved := data.healProcessor.healDstDrain(context.Background(), data.cloneClientConnection(connection))
+	g.Expect(healed).To(BeTrue())
+	g.Expect(moved).To(BeFalse())
+
+	g.Expect(data.nseManager.nseClients[nse1Name]).To(BeNil())
+	g.Expect(data.model.GetClientConnection("id").ConnectionState).To(Equal(model.ClientConnectionReady))
+
+	test_utils.NewModelVerifier(data.model).
+		EndpointExists(nse1Name, localNSMName).
+		ClientConnectionExists("id", "src", "dst", localNSMName, nse1Name, forwarder1Name).
+		ForwarderExists(forwarder1Name).
+		Verify(t)
+}
+
+func TestHealDstDrain_LocalClientLocalEndpoint_RequestFailed(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealTimeout = time.Second
+
+	nse1 := data.createEndpoint(nse1Name, localNSMName)
+	nse1.NetworkServiceEndpoint.State = registry.EndpointStatePaused
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse1,
+	})
+
+	nse2 := data.createEndpoint(nse2Name, localNSMName)
+	data.nseManager.nses = append(data.nseManager.nses, nse2)
+
+	xcon := data.createCrossConnection(false, false, "src", "dst")
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, localNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	data.serviceRegistry.discoveryClient.response = data.createFindNetworkServiceResponse(nse1, nse2)
+	data.connectionManager.requestError = errors.New("request error")
+
+	healed, moved := data.healProcessor.healDstDrain(context.Background(), data.cloneClientConnection(connection))
+	g.Expect(healed).To(BeFalse())
+	g.Expect(moved).To(BeFalse())
+
+	g.Expect(data.nseManager.nseClients[nse1Name]).To(BeNil())
+}
+
+func TestHealForwarderDrain_LocalClientLocalEndpoint(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealTimeout = time.Second
+	data.healProcessor.props.CloseTimeout = time.Second
+
+	data.model.UpdateForwarder(context.Background(), &model.Forwarder{
+		RegisteredName:       forwarder1Name,
+		MechanismsConfigured: true,
+		Draining:             true,
+	})
+	data.model.AddForwarder(context.Background(), &model.Forwarder{
+		RegisteredName:       forwarder2Name,
+		MechanismsConfigured: true,
+	})
+
+	nse1 := data.createEndpoint(nse1Name, localNSMName)
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse1,
+	})
+
+	xcon := data.createCrossConnection(false, false, "id", "dst")
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, localNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	data.connectionManager.forwarder = forwarder2Name
+
+	healed := data.healProcessor.healForwarderDrain(context.Background(), data.cloneClientConnection(connection))
+	g.Expect(healed).To(BeTrue())
+
+	g.Expect(data.serviceRegistry.forwarderClient.closed.GetSource().GetId()).To(Equal("id"))
+
+	// Break-before-make: the connection is down from the close on the draining forwarder until the request
+	// programs another one
+	closedAt, requestedAt := data.serviceRegistry.forwarderClient.closedAt, data.connectionManager.requestedAt
+	g.Expect(closedAt.After(requestedAt)).To(BeFalse())
+	gap := requestedAt.Sub(closedAt)
+	t.Logf("connection is down for %v while moved from the draining forwarder", gap)
+	g.Expect(gap).To(BeNumerically("<", data.healProcessor.props.HealTimeout))
+
+	test_utils.NewModelVerifier(data.model).
+		EndpointExists(nse1Name, localNSMName).
+		ClientConnectionExists("id", "id", "dst", localNSMName, nse1Name, forwarder2Name).
+		ForwarderExists(forwarder1Name).
+		ForwarderExists(forwarder2Name).
+		Verify(t)
+}
+
+func TestHealForwarderDrain_LocalClientLocalEndpoint_RequestFailed(t *testing.T) {
+	g := NewWithT(t)
+	data := newHealTestData()
+	data.healProcessor.props.HealTimeout = time.Second
+	data.healProcessor.props.CloseTimeout = time.Second
+
+	nse1 := data.createEndpoint(nse1Name, localNSMName)
+	data.model.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint: nse1,
+	})
+
+	xcon := data.createCrossConnection(false, false, "id", "dst")
+	request := data.createRequest(false)
+	connection := data.createClientConnection("id", xcon, nse1, localNSMName, forwarder1Name, request)
+	data.model.AddClientConnection(context.Background(), connection)
+
+	data.connectionManager.requestError = errors.New("request error")
+
+	healed := data.healProcessor.healForwarderDrain(context.Background(), data.cloneClientConnection(connection))
+	g.Expect(healed).To(BeFalse())
+
+	g.Expect(data.serviceRegistry.forwarderClient.closed.GetSource().GetId()).To(Equal("id"))
+	g.Expect(data.model.GetClientConnection("id").ForwarderState).To(Equal(model.ForwarderStateNone))
+}
+
 type discoveryClientStub struct {
 	response *registry.FindNetworkServiceResponse
 	error    error
+	updates  chan *registry.FindNetworkServiceResponse
+}
+
+func (stub *discoveryClientStub) WatchNetworkService(ctx net_context.Context, in *registry.FindNetworkServiceRequest, opts ...grpc.CallOption) (registry.NetworkServiceDiscovery_WatchNetworkServiceClient, error) {
+	if stub.updates == nil {
+		return nil, errors.New("watch is not supported")
+	}
+	return &watchClientStub{
+		ctx:     ctx,
+		updates: stub.updates,
+	}, nil
+}
+
+type watchClientStub struct {
+	ctx     context.Context
+	updates chan *registry.FindNetworkServiceResponse
+
+	grpc.ClientStream
+}
+
+func (stub *watchClientStub) Recv() (*registry.FindNetworkServiceResponse, error) {
+	select {
+	case <-stub.ctx.Done():
+		return nil, stub.ctx.Err()
+	case response := <-stub.updates:
+		return response, nil
+	}
 }
 
 func (stub *discoveryClientStub) FindNetworkService(ctx net_context.Context, in *registry.FindNetworkServiceRequest, opts ...grpc.CallOption) (*registry.FindNetworkServiceResponse, error) {
@@ -299,6 +615,7 @@ func (stub *discoveryClientStub) FindNetworkService(ctx net_context.Context, in
 
 type serviceRegistryStub struct {
 	discoveryClient *discoveryClientStub
+	forwarderClient *forwarderClientStub
 	error           error
 
 	serviceregistry.ServiceRegistry
@@ -308,15 +625,35 @@ func (stub *serviceRegistryStub) DiscoveryClient(ctx context.Context) (registry.
 	return stub.discoveryClient, stub.error
 }
 
+func (stub *serviceRegistryStub) ForwarderConnection(ctx context.Context, forwarder *model.Forwarder) (forwarderapi.ForwarderClient, *grpc.ClientConn, error) {
+	return stub.forwarderClient, nil, stub.error
+}
+
 func (stub *serviceRegistryStub) WaitForForwarderAvailable(ctx context.Context, model model.Model, timeout time.Duration) error {
 	return nsmd.NewServiceRegistry().WaitForForwarderAvailable(ctx, model, timeout)
 }
 
+type forwarderClientStub struct {
+	closed   *crossconnect.CrossConnect
+	closedAt time.Time
+
+	forwarderapi.ForwarderClient
+}
+
+func (stub *forwarderClientStub) Close(ctx context.Context, xcon *crossconnect.CrossConnect, opts ...grpc.CallOption) (*empty.Empty, error) {
+	stub.closed = xcon
+	stub.closedAt = time.Now()
+	return &empty.Empty{}, nil
+}
+
 type connectionManagerStub struct {
 	model model.Model
 
 	requestError error
+	requests     int
+	requestedAt  time.Time
 	nse          *registry.NSERegistration
+	forwarder    string
 
 	closeError error
 }
@@ -334,6 +671,8 @@ func (stub *connectionManagerStub) RemoteManager() networkservice.NetworkService
 }
 
 func (stub *connectionManagerStub) request(ctx context.Context, request *networkservice.NetworkServiceRequest, existingConnection *model.ClientConnection) (*connection.Connection, error) {
+	stub.requests++
+	stub.requestedAt = time.Now()
 	if stub.requestError != nil {
 		return nil, stub.requestError
 	}
@@ -360,6 +699,10 @@ func (stub *connectionManagerStub) request(ctx context.Context, request *network
 		}
 	}
 
+	if stub.forwarder != "" {
+		existingConnection.ForwarderRegisteredName = stub.forwarder
+	}
+
 	existingConnection.ConnectionState = model.ClientConnectionReady
 	existingConnection.ForwarderState = model.ForwarderStateReady
 	stub.model.UpdateClientConnection(context.Background(), existingConnection)
@@ -387,10 +730,16 @@ func (stub *connectionManagerStub) Close(ctx context.Context, clientConnection n
 
 type nseClientStub struct {
 	cleanedUp bool
+	closed    *connection.Connection
 
 	nsm.NetworkServiceClient
 }
 
+func (stub *nseClientStub) Close(ctx context.Context, connection *connection.Connection) error {
+	stub.closed = connection
+	return nil
+}
+
 func (stub *nseClientStub) Cleanup() error {
 	stub.cleanedUp = true
 	return nil
@@ -402,7 +751,8 @@ type nseManagerStub struct {
 	clientError error
 	nseClients  map[string]*nseClientStub
 
-	nses []*registry.NSERegistration
+	nses          []*registry.NSERegistration
+	checkFailures int
 }
 
 func (stub *nseManagerStub) GetEndpoint(ctx net_context.Context, requestConnection *connection.Connection, ignoreEndpoints map[registry.EndpointNSMName]*registry.NSERegistration) (*registry.NSERegistration, error) {
@@ -427,6 +777,11 @@ func (stub *nseManagerStub) IsLocalEndpoint(endpoint *registry.NSERegistration)
 }
 
 func (stub *nseManagerStub) CheckUpdateNSE(ctx context.Context, reg *registry.NSERegistration) bool {
+	if stub.checkFailures > 0 {
+		stub.checkFailures--
+		return false
+	}
+
 	for _, nse := range stub.nses {
 		if nse.GetNetworkServiceEndpoint().GetName() == reg.GetNetworkServiceEndpoint().GetName() {
 			return true
@@ -507,6 +862,7 @@ func (data *healTestData) cloneClientConnection(connection *model.ClientConnecti
 	id := connection.GetID()
 	xcon := proto.Clone(connection.Xcon).(*crossconnect.CrossConnect)
 	nse := data.createEndpoint(connection.Endpoint.GetNetworkServiceEndpoint().GetName(), connection.Endpoint.GetNetworkServiceManager().GetName())
+	nse.NetworkService.HealPolicy = proto.Clone(connection.Endpoint.GetNetworkService().GetHealPolicy()).(*registry.HealPolicy)
 	nsm := connection.RemoteNsm.GetName()
 	forwarder := connection.ForwarderRegisteredName
 	request := data.createRequest(connection.GetConnectionSource().IsRemote())
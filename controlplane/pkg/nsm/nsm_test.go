@@ -0,0 +1,59 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
+)
+
+type vniServiceRegistryStub struct {
+	serviceregistry.ServiceRegistry
+	vniAllocator vni.VniAllocator
+}
+
+func (stub *vniServiceRegistryStub) VniAllocator() vni.VniAllocator {
+	return stub.vniAllocator
+}
+
+func TestReleaseStaleVnis(t *testing.T) {
+	g := NewWithT(t)
+
+	allocator := vni.NewVniAllocator()
+	for _, id := range []string{"1", "2", "3"} {
+		_, err := allocator.Vni(id, "10.0.0.1", "10.0.0.2")
+		g.Expect(err).To(BeNil())
+	}
+
+	mdl := model.NewModel()
+	mdl.AddClientConnection(context.Background(), &model.ClientConnection{ConnectionID: "2"})
+
+	srv := &networkServiceManager{
+		model:           mdl,
+		serviceRegistry: &vniServiceRegistryStub{vniAllocator: allocator},
+	}
+	srv.releaseStaleVnis(logrus.StandardLogger())
+
+	g.Expect(allocator.ConnectionIDs()).To(Equal([]string{"2"}))
+}
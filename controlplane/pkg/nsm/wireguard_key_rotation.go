@@ -0,0 +1,81 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+// wireguardKeyRotationListener re-requests Wireguard connections of the Forwarder when it rotates its key.
+// Connections with local source are re-requested, Remote NSM updates its side with the fresh key of its
+// Forwarder while handling the request. For connections with remote source the source is set down, so the
+// Remote NSM heals the connection by re-requesting it and both sides get the fresh key.
+type wireguardKeyRotationListener struct {
+	model.ListenerImpl
+	manager *networkServiceManager
+}
+
+// ForwarderUpdated checks if Wireguard key of the Forwarder is rotated
+func (l *wireguardKeyRotationListener) ForwarderUpdated(ctx context.Context, old, new *model.Forwarder) {
+	oldKey, newKey := wireguardPublicKey(old), wireguardPublicKey(new)
+	if oldKey == "" || oldKey == newKey {
+		return
+	}
+	logrus.Infof("NSM: Wireguard key of forwarder %v is rotated, re-requesting its Wireguard connections", new.RegisteredName)
+
+	for _, cc := range l.manager.model.GetAllClientConnections() {
+		if cc.ForwarderRegisteredName != new.RegisteredName || cc.ConnectionState != model.ClientConnectionReady {
+			continue
+		}
+		if src := cc.Xcon.GetRemoteSource(); src != nil {
+			if src.GetMechanism().GetType() == wireguard.MECHANISM {
+				l.requestRemoteSource(ctx, cc)
+			}
+			continue
+		}
+		if cc.Xcon.GetRemoteDestination().GetMechanism().GetType() != wireguard.MECHANISM {
+			continue
+		}
+		l.manager.Heal(ctx, cc, nsm.HealStateWireguardKeyRotation)
+	}
+}
+
+// requestRemoteSource sets remote source of the connection down, Remote NSM receives the update by monitoring the
+// connection and re-requests it
+func (l *wireguardKeyRotationListener) requestRemoteSource(ctx context.Context, cc *model.ClientConnection) {
+	logrus.Infof("NSM: Notifying Remote NSM %v to re-request connection %v", cc.GetConnectionSource().GetSourceNetworkServiceManagerName(), cc.GetID())
+	l.manager.model.ApplyClientConnectionChanges(ctx, cc.GetID(), func(modelCC *model.ClientConnection) {
+		modelCC.Xcon.Source.State = connection.State_DOWN
+	})
+}
+
+// wireguardPublicKey returns public key advertised by the Forwarder for the Wireguard mechanism
+func wireguardPublicKey(forwarder *model.Forwarder) string {
+	for _, m := range forwarder.RemoteMechanisms {
+		if m.GetType() == wireguard.MECHANISM {
+			return m.GetParameters()[wireguard.SrcPublicKey]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsm
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+type healRecorder struct {
+	sync.Mutex
+	healed map[string]nsm.HealState
+	closed []string
+}
+
+func (r *healRecorder) Heal(_ context.Context, cc nsm.ClientConnection, healState nsm.HealState) {
+	r.Lock()
+	defer r.Unlock()
+	r.healed[cc.GetID()] = healState
+}
+
+func (r *healRecorder) CloseConnection(_ context.Context, cc nsm.ClientConnection) error {
+	r.Lock()
+	defer r.Unlock()
+	r.closed = append(r.closed, cc.GetID())
+	return nil
+}
+
+func wireguardForwarder(key string) *model.Forwarder {
+	return &model.Forwarder{
+		RegisteredName: "forwarder",
+		RemoteMechanisms: []*connection.Mechanism{
+			{
+				Type:       wireguard.MECHANISM,
+				Parameters: map[string]string{wireguard.SrcPublicKey: key},
+			},
+		},
+	}
+}
+
+func wireguardConnection(id string, src, dst *connection.Connection) *model.ClientConnection {
+	return &model.ClientConnection{
+		ConnectionID:            id,
+		Xcon:                    crossconnect.NewCrossConnect(id, "IP", src, dst),
+		ForwarderRegisteredName: "forwarder",
+		ConnectionState:         model.ClientConnectionReady,
+	}
+}
+
+func TestWireguardKeyRotationDestinationOnly(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	recorder := &healRecorder{healed: map[string]nsm.HealState{}}
+	listener := &wireguardKeyRotationListener{
+		manager: &networkServiceManager{NetworkServiceHealProcessor: recorder, model: mdl},
+	}
+
+	// This NSM is destination of the Wireguard connection, its source is on the Remote NSM
+	remotePath := &connection.Path{PathSegments: []*connection.PathSegment{{Name: "nsm-src"}, {Name: "nsm-dst"}}}
+	mdl.AddClientConnection(context.Background(), wireguardConnection("1",
+		&connection.Connection{
+			Id:        "1",
+			State:     connection.State_UP,
+			Mechanism: &connection.Mechanism{Type: wireguard.MECHANISM},
+			Path:      remotePath,
+		},
+		&connection.Connection{Id: "2", State: connection.State_UP},
+	))
+
+	listener.ForwarderUpdated(context.Background(), wireguardForwarder("old-key"), wireguardForwarder("new-key"))
+
+	g.Expect(recorder.healed).To(BeEmpty())
+	g.Expect(mdl.GetClientConnection("1").GetConnectionSource().GetState()).To(Equal(connection.State_DOWN))
+}
+
+func TestWireguardKeyRotationLocalSource(t *testing.T) {
+	g := NewWithT(t)
+
+	mdl := model.NewModel()
+	recorder := &healRecorder{healed: map[string]nsm.HealState{}}
+	listener := &wireguardKeyRotationListener{
+		manager: &networkServiceManager{NetworkServiceHealProcessor: recorder, model: mdl},
+	}
+
+	remotePath := &connection.Path{PathSegments: []*connection.PathSegment{{Name: "nsm-src"}, {Name: "nsm-dst"}}}
+	mdl.AddClientConnection(context.Background(), wireguardConnection("1",
+		&connection.Connection{Id: "1", State: connection.State_UP},
+		&connection.Connection{
+			Id:        "2",
+			State:     connection.State_UP,
+			Mechanism: &connection.Mechanism{Type: wireguard.MECHANISM},
+			Path:      remotePath,
+		},
+	))
+
+	// Key is not changed
+	listener.ForwarderUpdated(context.Background(), wireguardForwarder("key"), wireguardForwarder("key"))
+	g.Expect(recorder.healed).To(BeEmpty())
+
+	listener.ForwarderUpdated(context.Background(), wireguardForwarder("key"), wireguardForwarder("new-key"))
+	g.Expect(recorder.healed).To(Equal(map[string]nsm.HealState{"1": nsm.HealStateWireguardKeyRotation}))
+	g.Expect(mdl.GetClientConnection("1").GetConnectionSource().GetState()).To(Equal(connection.State_UP))
+}
@@ -0,0 +1,257 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmd
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/admin"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/authz"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
+	"github.com/networkservicemesh/networkservicemesh/utils"
+)
+
+// AdminSpiffeIDsEnv sets space separated path.Match patterns of SPIFFE IDs allowed to use the administrative API,
+// the API is denied to everyone if not set
+const AdminSpiffeIDsEnv utils.EnvVar = "NSM_ADMIN_SPIFFE_IDS"
+
+// adminHealStates - heal states the administrative API can start heal with, the other ones are caused by NSMgr itself
+var adminHealStates = map[admin.HealState]nsm.HealState{
+	admin.HealState_DEFAULT:        nsm.HealStateDstDown,
+	admin.HealState_DST_DOWN:       nsm.HealStateDstDown,
+	admin.HealState_FORWARDER_DOWN: nsm.HealStateForwarderDown,
+	admin.HealState_DST_UPDATE:     nsm.HealStateDstUpdate,
+	admin.HealState_DST_NMGR_DOWN:  nsm.HealStateDstNmgrDown,
+}
+
+type adminServer struct {
+	model      model.Model
+	manager    nsm.NetworkServiceManager
+	authorizer authz.Authorizer
+}
+
+// NewAdminServer creates administrative API server to inspect and manage the model of NSMgr
+func NewAdminServer(model model.Model, manager nsm.NetworkServiceManager, authorizer authz.Authorizer) admin.AdminServer {
+	return &adminServer{
+		model:      model,
+		manager:    manager,
+		authorizer: authorizer,
+	}
+}
+
+func (s *adminServer) ListConnections(ctx context.Context, _ *empty.Empty) (*admin.ClientConnectionList, error) {
+	if err := s.authorize(ctx, "ListConnections"); err != nil {
+		return nil, err
+	}
+	return &admin.ClientConnectionList{
+		Connections: s.connections(),
+	}, nil
+}
+
+func (s *adminServer) ListForwarders(ctx context.Context, _ *empty.Empty) (*admin.ForwarderList, error) {
+	if err := s.authorize(ctx, "ListForwarders"); err != nil {
+		return nil, err
+	}
+	return &admin.ForwarderList{
+		Forwarders: s.forwarders(),
+	}, nil
+}
+
+func (s *adminServer) ListEndpoints(ctx context.Context, _ *empty.Empty) (*admin.EndpointList, error) {
+	if err := s.authorize(ctx, "ListEndpoints"); err != nil {
+		return nil, err
+	}
+	return &admin.EndpointList{
+		Endpoints: s.endpoints(),
+	}, nil
+}
+
+func (s *adminServer) CloseConnection(ctx context.Context, request *admin.CloseConnectionRequest) (*empty.Empty, error) {
+	if err := s.authorize(ctx, "CloseConnection"); err != nil {
+		return nil, err
+	}
+	cc := s.model.GetClientConnection(request.GetId())
+	if cc == nil {
+		return nil, status.Errorf(codes.NotFound, "no client connection with id %s", request.GetId())
+	}
+
+	logrus.Infof("Admin: closing client connection %s", cc.GetID())
+	if err := s.manager.CloseConnection(ctx, cc); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to close client connection %s: %v", cc.GetID(), err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) HealConnection(ctx context.Context, request *admin.HealConnectionRequest) (*empty.Empty, error) {
+	if err := s.authorize(ctx, "HealConnection"); err != nil {
+		return nil, err
+	}
+	healState, ok := adminHealStates[request.GetHealState()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "connection can't be healed with %v", request.GetHealState())
+	}
+	cc := s.model.GetClientConnection(request.GetId())
+	if cc == nil {
+		return nil, status.Errorf(codes.NotFound, "no client connection with id %s", request.GetId())
+	}
+	if cc.ConnectionState != model.ClientConnectionReady {
+		return nil, status.Errorf(codes.FailedPrecondition, "client connection %s is %v, only ready connections can be healed",
+			cc.GetID(), cc.ConnectionState)
+	}
+
+	logrus.Infof("Admin: healing client connection %s with %v", cc.GetID(), healState)
+	s.manager.Heal(ctx, cc, healState)
+	return &empty.Empty{}, nil
+}
+
+func (s *adminServer) DumpModel(ctx context.Context, _ *empty.Empty) (*admin.ModelDump, error) {
+	if err := s.authorize(ctx, "DumpModel"); err != nil {
+		return nil, err
+	}
+	snapshot := &admin.Model{
+		Nsm:         s.model.GetNsm(),
+		Endpoints:   s.endpoints(),
+		Forwarders:  s.forwarders(),
+		Connections: s.connections(),
+	}
+	marshaler := &jsonpb.Marshaler{Indent: "  "}
+	dump, err := marshaler.MarshalToString(snapshot)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to marshal model: %v", err)
+	}
+	return &admin.ModelDump{
+		Json: dump,
+	}, nil
+}
+
+func (s *adminServer) ListHeals(ctx context.Context, _ *empty.Empty) (*admin.HealEventList, error) {
+	if err := s.authorize(ctx, "ListHeals"); err != nil {
+		return nil, err
+	}
+	events := s.manager.HealHistory().Events()
+	rv := &admin.HealEventList{
+		Events: make([]*admin.HealEvent, 0, len(events)),
+	}
+	for _, event := range events {
+		rv.Events = append(rv.Events, healEvent(event))
+	}
+	return rv, nil
+}
+
+// WatchHeals sends the recorded heals first, and then the heals when they are started and finished
+func (s *adminServer) WatchHeals(_ *empty.Empty, stream admin.Admin_WatchHealsServer) error {
+	if err := s.authorize(stream.Context(), "WatchHeals"); err != nil {
+		return err
+	}
+	history := s.manager.HealHistory()
+	// Start watching before sending the recorded heals to not miss the ones finished in between
+	watcher := history.Watch(stream.Context())
+	for _, event := range history.Events() {
+		if err := stream.Send(healEvent(event)); err != nil {
+			return err
+		}
+	}
+	for event := range watcher {
+		if err := stream.Send(healEvent(event)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authorize checks if the caller is allowed to use the administrative API, the caller is identified by SPIFFE ID of
+// its X.509 SVID
+func (s *adminServer) authorize(ctx context.Context, method string) error {
+	spiffeID := security.PeerSpiffeID(ctx)
+	if err := s.authorizer.Authorize(&authz.Request{SpiffeID: spiffeID}); err != nil {
+		logrus.Errorf("Admin: %s is denied: %v", method, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	logrus.Infof("Admin: %s is called by %q", method, spiffeID)
+	return nil
+}
+
+func (s *adminServer) connections() []*admin.ClientConnection {
+	var rv []*admin.ClientConnection
+	for _, cc := range s.model.GetAllClientConnections() {
+		rv = append(rv, &admin.ClientConnection{
+			Id:             cc.GetID(),
+			State:          cc.ConnectionState.String(),
+			NetworkService: cc.GetNetworkService(),
+			Forwarder:      cc.ForwarderRegisteredName,
+			ForwarderState: cc.ForwarderState.String(),
+			Endpoint:       cc.Endpoint.GetNetworkServiceEndpoint().GetName(),
+			RemoteNsm:      cc.RemoteNsm.GetName(),
+			Path:           cc.GetConnectionSource().GetPath(),
+			Xcon:           cc.Xcon,
+		})
+	}
+	return rv
+}
+
+func (s *adminServer) forwarders() []*admin.Forwarder {
+	var rv []*admin.Forwarder
+	for _, fwd := range s.model.SelectForwarders(nil) {
+		rv = append(rv, &admin.Forwarder{
+			Name:             fwd.RegisteredName,
+			SocketLocation:   fwd.SocketLocation,
+			LocalMechanisms:  fwd.LocalMechanisms,
+			RemoteMechanisms: fwd.RemoteMechanisms,
+			Connections:      uint32(s.model.ForwarderConnectionCount(fwd.RegisteredName)),
+		})
+	}
+	return rv
+}
+
+func (s *adminServer) endpoints() []*admin.Endpoint {
+	var rv []*admin.Endpoint
+	for _, endp := range s.model.GetAllEndpoints() {
+		rv = append(rv, &admin.Endpoint{
+			Registration:   endp.Endpoint,
+			SocketLocation: endp.SocketLocation,
+			Workspace:      endp.Workspace,
+			Connections:    uint32(s.model.ConnectionCount(endp.EndpointName())),
+		})
+	}
+	return rv
+}
+
+func healEvent(event *nsm.HealEvent) *admin.HealEvent {
+	rv := &admin.HealEvent{
+		Id:             event.ID,
+		ConnectionId:   event.ConnectionID,
+		NetworkService: event.NetworkService,
+		HealState:      event.HealState.String(),
+		Outcome:        event.Outcome,
+		Endpoint:       event.Endpoint,
+		Forwarder:      event.Forwarder,
+	}
+	rv.StartTime, _ = ptypes.TimestampProto(event.StartTime)
+	if !event.EndTime.IsZero() {
+		rv.EndTime, _ = ptypes.TimestampProto(event.EndTime)
+	}
+	return rv
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/admin"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/authz"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+)
+
+func newAdminTestModel() model.Model {
+	m := model.NewModel()
+	m.AddForwarder(context.Background(), &model.Forwarder{
+		RegisteredName: "forwarder",
+		SocketLocation: "/forwarder.sock",
+	})
+	endpoint := &registry.NSERegistration{
+		NetworkService: &registry.NetworkService{
+			Name: "icmp-responder",
+		},
+		NetworkServiceEndpoint: &registry.NetworkServiceEndpoint{
+			Name: "nse",
+		},
+	}
+	m.AddEndpoint(context.Background(), &model.Endpoint{
+		Endpoint:       endpoint,
+		SocketLocation: "/nse.sock",
+		Workspace:      "nse-ws",
+	})
+	m.AddClientConnection(context.Background(), &model.ClientConnection{
+		ConnectionID:            "1",
+		Endpoint:                endpoint,
+		ForwarderRegisteredName: "forwarder",
+		ForwarderState:          model.ForwarderStateReady,
+		ConnectionState:         model.ClientConnectionReady,
+		Xcon: &crossconnect.CrossConnect{
+			Id: "1",
+			Source: &connection.Connection{
+				Id:             "1",
+				NetworkService: "icmp-responder",
+			},
+		},
+	})
+	return m
+}
+
+func TestAdminServerList(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	srv := NewAdminServer(newAdminTestModel(), nil, authz.NewAdminAuthorizer([]string{"*"}))
+
+	connections, err := srv.ListConnections(context.Background(), &empty.Empty{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(len(connections.GetConnections())).To(gomega.Equal(1))
+	cc := connections.GetConnections()[0]
+	g.Expect(cc.GetId()).To(gomega.Equal("1"))
+	g.Expect(cc.GetState()).To(gomega.Equal("ready"))
+	g.Expect(cc.GetNetworkService()).To(gomega.Equal("icmp-responder"))
+	g.Expect(cc.GetForwarder()).To(gomega.Equal("forwarder"))
+	g.Expect(cc.GetEndpoint()).To(gomega.Equal("nse"))
+
+	forwarders, err := srv.ListForwarders(context.Background(), &empty.Empty{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(len(forwarders.GetForwarders())).To(gomega.Equal(1))
+	g.Expect(forwarders.GetForwarders()[0].GetConnections()).To(gomega.Equal(uint32(1)))
+
+	endpoints, err := srv.ListEndpoints(context.Background(), &empty.Empty{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(len(endpoints.GetEndpoints())).To(gomega.Equal(1))
+	g.Expect(endpoints.GetEndpoints()[0].GetWorkspace()).To(gomega.Equal("nse-ws"))
+	g.Expect(endpoints.GetEndpoints()[0].GetConnections()).To(gomega.Equal(uint32(1)))
+}
+
+func TestAdminServerDenied(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	srv := NewAdminServer(newAdminTestModel(), nil, authz.NewAdminAuthorizer(nil))
+
+	_, err := srv.ListConnections(context.Background(), &empty.Empty{})
+	g.Expect(status.Code(err)).To(gomega.Equal(codes.PermissionDenied))
+	_, err = srv.DumpModel(context.Background(), &empty.Empty{})
+	g.Expect(status.Code(err)).To(gomega.Equal(codes.PermissionDenied))
+	_, err = srv.CloseConnection(context.Background(), &admin.CloseConnectionRequest{Id: "1"})
+	g.Expect(status.Code(err)).To(gomega.Equal(codes.PermissionDenied))
+}
+
+func TestAdminServerNotFound(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	srv := NewAdminServer(newAdminTestModel(), nil, authz.NewAdminAuthorizer([]string{"*"}))
+
+	_, err := srv.CloseConnection(context.Background(), &admin.CloseConnectionRequest{Id: "2"})
+	g.Expect(status.Code(err)).To(gomega.Equal(codes.NotFound))
+	_, err = srv.HealConnection(context.Background(), &admin.HealConnectionRequest{Id: "2"})
+	g.Expect(status.Code(err)).To(gomega.Equal(codes.NotFound))
+}
+
+func TestAdminServerHealInvalidState(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	srv := NewAdminServer(newAdminTestModel(), nil, authz.NewAdminAuthorizer([]string{"*"}))
+
+	for _, healState := range []admin.HealState{admin.HealState_SRC_DOWN, admin.HealState(42)} {
+		_, err := srv.HealConnection(context.Background(), &admin.HealConnectionRequest{Id: "1", HealState: healState})
+		g.Expect(status.Code(err)).To(gomega.Equal(codes.InvalidArgument))
+	}
+}
+
+func TestAdminServerDumpModel(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	srv := NewAdminServer(newAdminTestModel(), nil, authz.NewAdminAuthorizer([]string{"*"}))
+
+	dump, err := srv.DumpModel(context.Background(), &empty.Empty{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(dump.GetJson()).To(gomega.ContainSubstring(`"socketLocation": "/forwarder.sock"`))
+	g.Expect(dump.GetJson()).To(gomega.ContainSubstring(`"networkService": "icmp-responder"`))
+}
+
+type healHistoryStub struct {
+	events []*nsm.HealEvent
+}
+
+func (h *healHistoryStub) Events() []*nsm.HealEvent {
+	return h.events
+}
+
+func (h *healHistoryStub) Watch(ctx context.Context) <-chan *nsm.HealEvent {
+	watcher := make(chan *nsm.HealEvent)
+	close(watcher)
+	return watcher
+}
+
+type healHistoryManagerStub struct {
+	history nsm.HealHistory
+
+	nsm.NetworkServiceManager
+}
+
+func (m *healHistoryManagerStub) HealHistory() nsm.HealHistory {
+	return m.history
+}
+
+func TestAdminServerListHeals(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	start := time.Now()
+	manager := &healHistoryManagerStub{
+		history: &healHistoryStub{
+			events: []*nsm.HealEvent{
+				{
+					ID:             "A",
+					ConnectionID:   "1",
+					NetworkService: "icmp-responder",
+					HealState:      nsm.HealStateDstDown,
+					StartTime:      start,
+					EndTime:        start.Add(time.Second),
+					Outcome:        "healed",
+					Endpoint:       "nse",
+					Forwarder:      "forwarder",
+				},
+				{
+					ID:           "B",
+					ConnectionID: "2",
+					HealState:    nsm.HealStateForwarderDown,
+					StartTime:    start,
+				},
+			},
+		},
+	}
+	srv := NewAdminServer(newAdminTestModel(), manager, authz.NewAdminAuthorizer([]string{"*"}))
+
+	heals, err := srv.ListHeals(context.Background(), &empty.Empty{})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(len(heals.GetEvents())).To(gomega.Equal(2))
+	healed := heals.GetEvents()[0]
+	g.Expect(healed.GetId()).To(gomega.Equal("A"))
+	g.Expect(healed.GetHealState()).To(gomega.Equal("dst_down"))
+	g.Expect(healed.GetOutcome()).To(gomega.Equal("healed"))
+	g.Expect(healed.GetEndpoint()).To(gomega.Equal("nse"))
+	g.Expect(healed.GetEndTime().GetSeconds() - healed.GetStartTime().GetSeconds()).To(gomega.Equal(int64(1)))
+	inProgress := heals.GetEvents()[1]
+	g.Expect(inProgress.GetHealState()).To(gomega.Equal("forwarder_down"))
+	g.Expect(inProgress.GetEndTime()).To(gomega.BeNil())
+}
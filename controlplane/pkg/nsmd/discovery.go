@@ -37,3 +37,23 @@ func (n networkServiceDiscoveryServer) FindNetworkService(ctx context.Context, f
 	}
 	return client.FindNetworkService(ctx, find)
 }
+
+func (n networkServiceDiscoveryServer) WatchNetworkService(find *registry.FindNetworkServiceRequest, stream registry.NetworkServiceDiscovery_WatchNetworkServiceServer) error {
+	client, err := n.serviceRegistry.DiscoveryClient(stream.Context())
+	if err != nil {
+		return err
+	}
+	watchClient, err := client.WatchNetworkService(stream.Context(), find)
+	if err != nil {
+		return err
+	}
+	for {
+		response, recvErr := watchClient.Recv()
+		if recvErr != nil {
+			return recvErr
+		}
+		if sendErr := stream.Send(response); sendErr != nil {
+			return sendErr
+		}
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+const (
+	// discoveryWatchIdleTimeout - time the registry watch of a Network Service is kept without lookups and watchers
+	discoveryWatchIdleTimeout = 5 * time.Minute
+	// discoveryMaxWatches - maximum number of the registry watches, Network Services over it are looked up directly
+	discoveryMaxWatches = 256
+)
+
+// discoveryCache - NetworkServiceDiscoveryClient keeping Network Services found by NSMD up to date with the registry
+// WatchNetworkService streams instead of asking the registry on every lookup. A watch is started for a Network Service
+// found by the registry or watched by NSMD, it is stopped after idleTimeout without lookups and watchers
+type discoveryCache struct {
+	sync.Mutex
+	client      registry.NetworkServiceDiscoveryClient
+	ctx         context.Context
+	cancel      context.CancelFunc
+	unsupported bool
+	services    map[string]*serviceWatch
+	idleTimeout time.Duration
+	maxWatches  int
+}
+
+// serviceWatch - the latest response of the Network Service registry watch and the local watchers of it
+type serviceWatch struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	response *registry.FindNetworkServiceResponse
+	watchers map[chan *registry.FindNetworkServiceResponse]struct{}
+	lastUsed time.Time
+}
+
+func newDiscoveryCache(client registry.NetworkServiceDiscoveryClient) *discoveryCache {
+	return newDiscoveryCacheWithLimits(client, discoveryWatchIdleTimeout, discoveryMaxWatches)
+}
+
+func newDiscoveryCacheWithLimits(client registry.NetworkServiceDiscoveryClient, idleTimeout time.Duration, maxWatches int) *discoveryCache {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &discoveryCache{
+		client:      client,
+		ctx:         ctx,
+		cancel:      cancel,
+		services:    make(map[string]*serviceWatch),
+		idleTimeout: idleTimeout,
+		maxWatches:  maxWatches,
+	}
+	go c.stopIdleWatches()
+	return c
+}
+
+// FindNetworkService - returns the latest watched response if it has endpoints, asks the registry otherwise and starts
+// the watch if the registry finds the Network Service
+func (c *discoveryCache) FindNetworkService(ctx context.Context, in *registry.FindNetworkServiceRequest, opts ...grpc.CallOption) (*registry.FindNetworkServiceResponse, error) {
+	networkServiceName := in.GetNetworkServiceName()
+	var response *registry.FindNetworkServiceResponse
+	c.Lock()
+	w := c.services[networkServiceName]
+	if w != nil {
+		w.lastUsed = time.Now()
+		response = w.response
+	}
+	c.Unlock()
+
+	if len(response.GetNetworkServiceEndpoints()) > 0 {
+		return proto.Clone(response).(*registry.FindNetworkServiceResponse), nil
+	}
+	// Endpoints may be registered before the watch is started or updated
+	response, err := c.client.FindNetworkService(ctx, in, opts...)
+	if err == nil && w == nil {
+		c.Lock()
+		c.watchLocked(networkServiceName)
+		c.Unlock()
+	}
+	return response, err
+}
+
+// WatchNetworkService - returns stream of the Network Service responses of the shared registry watch
+func (c *discoveryCache) WatchNetworkService(ctx context.Context, in *registry.FindNetworkServiceRequest, opts ...grpc.CallOption) (registry.NetworkServiceDiscovery_WatchNetworkServiceClient, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	w := c.watchLocked(in.GetNetworkServiceName())
+	if w == nil {
+		return c.client.WatchNetworkService(ctx, in, opts...)
+	}
+
+	watcher := make(chan *registry.FindNetworkServiceResponse, 1)
+	w.watchers[watcher] = struct{}{}
+	if w.response != nil {
+		watcher <- w.response
+	}
+	go func() {
+		<-ctx.Done()
+		c.Lock()
+		defer c.Unlock()
+		delete(w.watchers, watcher)
+		w.lastUsed = time.Now()
+	}()
+	return &cachedWatchClient{
+		ctx:     ctx,
+		watcher: watcher,
+	}, nil
+}
+
+// Stop - stops all the Network Service registry watches
+func (c *discoveryCache) Stop() {
+	c.cancel()
+}
+
+// watchLocked - returns the watch of the Network Service, starts it if it is not started yet. Returns nil if the
+// registry is not able to watch Network Services or there are too many watches already.
+func (c *discoveryCache) watchLocked(networkServiceName string) *serviceWatch {
+	if c.unsupported || c.ctx.Err() != nil {
+		return nil
+	}
+	w, ok := c.services[networkServiceName]
+	if !ok {
+		if len(c.services) >= c.maxWatches {
+			logrus.Warnf("Network Service %s is not watched, %d Network Services are watched already", networkServiceName, len(c.services))
+			return nil
+		}
+		ctx, cancel := context.WithCancel(c.ctx)
+		w = &serviceWatch{
+			ctx:      ctx,
+			cancel:   cancel,
+			watchers: make(map[chan *registry.FindNetworkServiceResponse]struct{}),
+		}
+		c.services[networkServiceName] = w
+		go c.run(networkServiceName, w)
+	}
+	w.lastUsed = time.Now()
+	return w
+}
+
+func (c *discoveryCache) run(networkServiceName string, w *serviceWatch) {
+	defer c.remove(networkServiceName, w)
+
+	logrus.Infof("Starting watch of Network Service %s", networkServiceName)
+	stream, err := c.client.WatchNetworkService(w.ctx, &registry.FindNetworkServiceRequest{
+		NetworkServiceName: networkServiceName,
+	})
+	for err == nil {
+		var response *registry.FindNetworkServiceResponse
+		if response, err = stream.Recv(); err == nil {
+			c.update(w, response)
+		}
+	}
+
+	if status.Code(err) == codes.Unimplemented {
+		logrus.Warnf("Registry is not able to watch Network Services, falling back to find requests: %v", err)
+		c.Lock()
+		c.unsupported = true
+		c.Unlock()
+		return
+	}
+	if w.ctx.Err() == nil {
+		logrus.Errorf("Watch of Network Service %s is closed: %v", networkServiceName, err)
+	}
+}
+
+func (c *discoveryCache) update(w *serviceWatch, response *registry.FindNetworkServiceResponse) {
+	c.Lock()
+	defer c.Unlock()
+
+	w.response = response
+	for watcher := range w.watchers {
+		// Watchers are interested in the latest response only
+		select {
+		case <-watcher:
+		default:
+		}
+		watcher <- response
+	}
+}
+
+// remove - removes the closed watch, so the next lookup starts a new one
+func (c *discoveryCache) remove(networkServiceName string, w *serviceWatch) {
+	c.Lock()
+	defer c.Unlock()
+
+	w.cancel()
+	if c.services[networkServiceName] == w {
+		delete(c.services, networkServiceName)
+	}
+	for watcher := range w.watchers {
+		close(watcher)
+		delete(w.watchers, watcher)
+	}
+}
+
+// stopIdleWatches - periodically stops the watches having no watchers and not looked up for idleTimeout
+func (c *discoveryCache) stopIdleWatches() {
+	ticker := time.NewTicker(c.idleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		c.Lock()
+		for networkServiceName, w := range c.services {
+			if len(w.watchers) == 0 && time.Since(w.lastUsed) >= c.idleTimeout {
+				logrus.Infof("Stopping idle watch of Network Service %s", networkServiceName)
+				w.cancel()
+				delete(c.services, networkServiceName)
+			}
+		}
+		c.Unlock()
+	}
+}
+
+// cachedWatchClient - NetworkServiceDiscovery_WatchNetworkServiceClient receiving responses of the shared registry
+// watch
+type cachedWatchClient struct {
+	ctx     context.Context
+	watcher <-chan *registry.FindNetworkServiceResponse
+}
+
+func (s *cachedWatchClient) Recv() (*registry.FindNetworkServiceResponse, error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case response, ok := <-s.watcher:
+		if !ok {
+			return nil, errors.New("watch of Network Service is closed")
+		}
+		return proto.Clone(response).(*registry.FindNetworkServiceResponse), nil
+	}
+}
+
+func (s *cachedWatchClient) Header() (metadata.MD, error) {
+	return metadata.MD{}, nil
+}
+
+func (s *cachedWatchClient) Trailer() metadata.MD {
+	return metadata.MD{}
+}
+
+func (s *cachedWatchClient) CloseSend() error {
+	return nil
+}
+
+func (s *cachedWatchClient) Context() context.Context {
+	return s.ctx
+}
+
+func (s *cachedWatchClient) SendMsg(m interface{}) error {
+	return errors.New("sending to Network Service watch is not supported")
+}
+
+func (s *cachedWatchClient) RecvMsg(m interface{}) error {
+	out, ok := m.(*registry.FindNetworkServiceResponse)
+	if !ok {
+		return errors.Errorf("unexpected message type: %T", m)
+	}
+	response, err := s.Recv()
+	if err != nil {
+		return err
+	}
+	out.Reset()
+	proto.Merge(out, response)
+	return nil
+}
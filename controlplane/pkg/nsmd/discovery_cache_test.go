@@ -0,0 +1,270 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nsmd
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+const cacheTestNetworkService = "icmp-responder"
+
+type discoveryClientStub struct {
+	finds    int32
+	watches  int32
+	findErr  error
+	watchErr error
+	updates  chan *registry.FindNetworkServiceResponse
+}
+
+func (stub *discoveryClientStub) FindNetworkService(ctx context.Context, in *registry.FindNetworkServiceRequest, opts ...grpc.CallOption) (*registry.FindNetworkServiceResponse, error) {
+	atomic.AddInt32(&stub.finds, 1)
+	if stub.findErr != nil {
+		return nil, stub.findErr
+	}
+	return &registry.FindNetworkServiceResponse{}, nil
+}
+
+func (stub *discoveryClientStub) WatchNetworkService(ctx context.Context, in *registry.FindNetworkServiceRequest, opts ...grpc.CallOption) (registry.NetworkServiceDiscovery_WatchNetworkServiceClient, error) {
+	atomic.AddInt32(&stub.watches, 1)
+	return &watchClientStub{
+		ctx:     ctx,
+		err:     stub.watchErr,
+		updates: stub.updates,
+	}, nil
+}
+
+type watchClientStub struct {
+	ctx     context.Context
+	err     error
+	updates chan *registry.FindNetworkServiceResponse
+
+	grpc.ClientStream
+}
+
+func (stub *watchClientStub) Recv() (*registry.FindNetworkServiceResponse, error) {
+	if stub.err != nil {
+		return nil, stub.err
+	}
+	select {
+	case <-stub.ctx.Done():
+		return nil, stub.ctx.Err()
+	case response := <-stub.updates:
+		return response, nil
+	}
+}
+
+func newCacheTestResponse(endpoints ...string) *registry.FindNetworkServiceResponse {
+	response := &registry.FindNetworkServiceResponse{
+		NetworkService: &registry.NetworkService{
+			Name: cacheTestNetworkService,
+		},
+	}
+	for _, endpoint := range endpoints {
+		response.NetworkServiceEndpoints = append(response.NetworkServiceEndpoints, &registry.NetworkServiceEndpoint{
+			Name:               endpoint,
+			NetworkServiceName: cacheTestNetworkService,
+		})
+	}
+	return response
+}
+
+func TestDiscoveryCacheFindNetworkService(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	client := &discoveryClientStub{
+		updates: make(chan *registry.FindNetworkServiceResponse),
+	}
+	cache := newDiscoveryCache(client)
+	defer cache.Stop()
+
+	request := &registry.FindNetworkServiceRequest{NetworkServiceName: cacheTestNetworkService}
+	response, err := cache.FindNetworkService(context.Background(), request)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(response.GetNetworkServiceEndpoints()).To(gomega.BeEmpty())
+	g.Expect(atomic.LoadInt32(&client.finds)).To(gomega.Equal(int32(1)))
+
+	client.updates <- newCacheTestResponse("nse-1")
+	g.Eventually(func() int {
+		response, _ = cache.FindNetworkService(context.Background(), request)
+		return len(response.GetNetworkServiceEndpoints())
+	}, time.Second).Should(gomega.Equal(1))
+
+	finds := atomic.LoadInt32(&client.finds)
+	response, err = cache.FindNetworkService(context.Background(), request)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(response.GetNetworkServiceEndpoints()[0].GetName()).To(gomega.Equal("nse-1"))
+	g.Expect(atomic.LoadInt32(&client.finds)).To(gomega.Equal(finds))
+	g.Expect(atomic.LoadInt32(&client.watches)).To(gomega.Equal(int32(1)))
+}
+
+func TestDiscoveryCacheWatchNetworkService(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	client := &discoveryClientStub{
+		updates: make(chan *registry.FindNetworkServiceResponse),
+	}
+	cache := newDiscoveryCache(client)
+	defer cache.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request := &registry.FindNetworkServiceRequest{NetworkServiceName: cacheTestNetworkService}
+	stream1, err := cache.WatchNetworkService(ctx, request)
+	g.Expect(err).To(gomega.BeNil())
+	stream2, err := cache.WatchNetworkService(ctx, request)
+	g.Expect(err).To(gomega.BeNil())
+
+	client.updates <- newCacheTestResponse()
+	client.updates <- newCacheTestResponse("nse-1", "nse-2")
+
+	g.Eventually(func() int {
+		response, recvErr := stream1.Recv()
+		g.Expect(recvErr).To(gomega.BeNil())
+		return len(response.GetNetworkServiceEndpoints())
+	}, time.Second).Should(gomega.Equal(2))
+	g.Eventually(func() int {
+		response, recvErr := stream2.Recv()
+		g.Expect(recvErr).To(gomega.BeNil())
+		return len(response.GetNetworkServiceEndpoints())
+	}, time.Second).Should(gomega.Equal(2))
+	g.Expect(atomic.LoadInt32(&client.watches)).To(gomega.Equal(int32(1)))
+
+	cancel()
+	_, err = stream1.Recv()
+	g.Expect(err).To(gomega.Equal(context.Canceled))
+}
+
+func TestDiscoveryCacheWatchUnsupported(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	client := &discoveryClientStub{
+		watchErr: status.Error(codes.Unimplemented, "method WatchNetworkService not implemented"),
+	}
+	cache := newDiscoveryCache(client)
+	defer cache.Stop()
+
+	request := &registry.FindNetworkServiceRequest{NetworkServiceName: cacheTestNetworkService}
+	_, err := cache.FindNetworkService(context.Background(), request)
+	g.Expect(err).To(gomega.BeNil())
+	g.Eventually(func() bool {
+		cache.Lock()
+		defer cache.Unlock()
+		return cache.unsupported
+	}, time.Second).Should(gomega.BeTrue())
+
+	_, err = cache.FindNetworkService(context.Background(), request)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(atomic.LoadInt32(&client.finds)).To(gomega.Equal(int32(2)))
+	g.Expect(atomic.LoadInt32(&client.watches)).To(gomega.Equal(int32(1)))
+
+	stream, err := cache.WatchNetworkService(context.Background(), request)
+	g.Expect(err).To(gomega.BeNil())
+	_, err = stream.Recv()
+	g.Expect(status.Code(err)).To(gomega.Equal(codes.Unimplemented))
+}
+
+func TestDiscoveryCacheIdleWatch(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	client := &discoveryClientStub{
+		updates: make(chan *registry.FindNetworkServiceResponse),
+	}
+	cache := newDiscoveryCacheWithLimits(client, 100*time.Millisecond, discoveryMaxWatches)
+	defer cache.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	request := &registry.FindNetworkServiceRequest{NetworkServiceName: cacheTestNetworkService}
+	_, err := cache.WatchNetworkService(ctx, request)
+	g.Expect(err).To(gomega.BeNil())
+
+	// Watch having watchers is not idle
+	time.Sleep(300 * time.Millisecond)
+	g.Expect(watchesCount(cache)).To(gomega.Equal(1))
+
+	cancel()
+	g.Eventually(func() int {
+		return watchesCount(cache)
+	}, time.Second).Should(gomega.Equal(0))
+
+	_, err = cache.FindNetworkService(context.Background(), request)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(watchesCount(cache)).To(gomega.Equal(1))
+	g.Eventually(func() int32 {
+		return atomic.LoadInt32(&client.watches)
+	}, time.Second).Should(gomega.Equal(int32(2)))
+}
+
+func TestDiscoveryCacheMaxWatches(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	client := &discoveryClientStub{
+		updates: make(chan *registry.FindNetworkServiceResponse),
+	}
+	cache := newDiscoveryCacheWithLimits(client, discoveryWatchIdleTimeout, 2)
+	defer cache.Stop()
+
+	for _, networkServiceName := range []string{"ns-1", "ns-2", "ns-3"} {
+		_, err := cache.FindNetworkService(context.Background(), &registry.FindNetworkServiceRequest{
+			NetworkServiceName: networkServiceName,
+		})
+		g.Expect(err).To(gomega.BeNil())
+	}
+	g.Expect(watchesCount(cache)).To(gomega.Equal(2))
+
+	// Network Services over the limit are looked up and watched directly
+	_, err := cache.FindNetworkService(context.Background(), &registry.FindNetworkServiceRequest{NetworkServiceName: "ns-3"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(atomic.LoadInt32(&client.finds)).To(gomega.Equal(int32(4)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err = cache.WatchNetworkService(ctx, &registry.FindNetworkServiceRequest{NetworkServiceName: "ns-3"})
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(watchesCount(cache)).To(gomega.Equal(2))
+}
+
+func TestDiscoveryCacheNotFound(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	client := &discoveryClientStub{
+		findErr: status.Error(codes.NotFound, "network service is not found"),
+	}
+	cache := newDiscoveryCache(client)
+	defer cache.Stop()
+
+	request := &registry.FindNetworkServiceRequest{NetworkServiceName: cacheTestNetworkService}
+	_, err := cache.FindNetworkService(context.Background(), request)
+	g.Expect(status.Code(err)).To(gomega.Equal(codes.NotFound))
+	g.Expect(watchesCount(cache)).To(gomega.Equal(0))
+	g.Expect(atomic.LoadInt32(&client.watches)).To(gomega.Equal(int32(0)))
+}
+
+func watchesCount(cache *discoveryCache) int {
+	cache.Lock()
+	defer cache.Unlock()
+	return len(cache.services)
+}
@@ -29,6 +29,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	forwarderapi "github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarder"
 	forwarderregistrarapi "github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarderregistrar"
@@ -47,6 +48,7 @@ const (
 // ForwarderRegistrarServer - NSMgr registration service
 type ForwarderRegistrarServer struct {
 	model                        model.Model
+	manager                      nsm.NetworkServiceManager
 	grpcServer                   *grpc.Server
 	forwarderRegistrarSocketPath string
 	sock                         net.Listener
@@ -88,6 +90,10 @@ func forwarderMonitor(model model.Model, forwarderName string) {
 			return
 		}
 		logrus.Infof("Forwarder %s informed of its parameters changes, applying new parameters %+v", forwarderName, updates.RemoteMechanisms)
+		// Forwarder could be changed in the model since the last update, f.e. it could start draining
+		if current := model.GetForwarder(forwarderName); current != nil {
+			forwarder = current
+		}
 		// TODO: this is not good -- direct model changes
 		forwarder.SetRemoteMechanisms(updates.RemoteMechanisms)
 		forwarder.SetLocalMechanisms(updates.LocalMechanisms)
@@ -145,6 +151,19 @@ func (r *ForwarderRegistrarServer) RequestForwarderUnRegistration(ctx context.Co
 	return &forwarderregistrarapi.ForwarderUnRegistrationReply{UnRegistered: true}, nil
 }
 
+// RequestForwarderDrain - request forwarder to be drained: its connections are moved to other forwarders and then it
+// is unregistered
+func (r *ForwarderRegistrarServer) RequestForwarderDrain(ctx context.Context, req *forwarderregistrarapi.ForwarderDrainRequest) (*forwarderregistrarapi.ForwarderDrainReply, error) {
+	logrus.Infof("Received forwarder drain requests from %s", req.ForwarderName)
+
+	if err := r.manager.DrainForwarder(ctx, req.ForwarderName); err != nil {
+		logrus.Errorf("failed to drain forwarder %s: %v", req.ForwarderName, err)
+		return &forwarderregistrarapi.ForwarderDrainReply{Drained: false}, err
+	}
+
+	return &forwarderregistrarapi.ForwarderDrainReply{Drained: true}, nil
+}
+
 // startForwarderServer starts for a server listening for local NSEs advertise/remove
 // forwarder registrar calls
 func (r *ForwarderRegistrarServer) startForwarderRegistrarServer(ctx context.Context) error {
@@ -169,6 +188,8 @@ func (r *ForwarderRegistrarServer) startForwarderRegistrarServer(ctx context.Con
 	forwarderregistrarapi.RegisterForwarderRegistrationServer(r.grpcServer, r)
 	// Plugging forwarder registrar operations methods
 	forwarderregistrarapi.RegisterForwarderUnRegistrationServer(r.grpcServer, r)
+	// Plugging forwarder drain operations methods
+	forwarderregistrarapi.RegisterForwarderDrainServer(r.grpcServer, r)
 
 	span.Logger().Infof("Starting Forwarder Registrar gRPC server listening on socket: %s", forwarderRegistrar)
 	go func() {
@@ -198,7 +219,7 @@ func (r *ForwarderRegistrarServer) Stop() {
 
 // StartForwarderRegistrarServer -  registers and starts gRPC server which is listening for
 // Network Service Forwarder Registrar requests.
-func StartForwarderRegistrarServer(ctx context.Context, model model.Model) (*ForwarderRegistrarServer, error) {
+func StartForwarderRegistrarServer(ctx context.Context, model model.Model, manager nsm.NetworkServiceManager) (*ForwarderRegistrarServer, error) {
 	span := spanhelper.FromContext(ctx, "ForwarderRegistrarServer")
 	defer span.Finish()
 	server := tools.NewServer(span.Context())
@@ -207,6 +228,7 @@ func StartForwarderRegistrarServer(ctx context.Context, model model.Model) (*For
 		grpcServer:                   server,
 		forwarderRegistrarSocketPath: path.Join(ForwarderRegistrarSocketBaseDir, ForwarderRegistrarSocket),
 		model:                        model,
+		manager:                      manager,
 	}
 
 	var err error
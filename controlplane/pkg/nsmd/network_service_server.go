@@ -13,9 +13,12 @@ func NewNetworkServiceServer(model model.Model, ws *Workspace,
 	nsmManager nsm.NetworkServiceManager) networkservice.NetworkServiceServer {
 	return common.NewCompositeService("Local",
 		common.NewRequestValidator(),
+		common.NewPathVerifier(model),
 		common.NewMonitorService(ws.MonitorConnectionServer()),
 		local.NewWorkspaceService(ws.Name()),
 		local.NewConnectionService(model),
+		common.NewAuthorizationService(model, nsmManager.ServiceRegistry()),
+		common.NewMetricsService("local"),
 		local.NewForwarderService(model, nsmManager.ServiceRegistry()),
 		local.NewEndpointSelectorService(nsmManager.NseManager()),
 		common.NewExcludedPrefixesService(),
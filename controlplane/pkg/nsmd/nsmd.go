@@ -24,11 +24,13 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/pkg/probes"
 	"github.com/networkservicemesh/networkservicemesh/pkg/probes/health"
 
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/admin"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
 	unified "github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/authz"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nseregistry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
@@ -534,7 +536,7 @@ func (nsm *nsmServer) initMonitorServers() {
 
 func (nsm *nsmServer) StartForwarderRegistratorServer(ctx context.Context) error {
 	var err error
-	nsm.regServer, err = StartForwarderRegistrarServer(ctx, nsm.model)
+	nsm.regServer, err = StartForwarderRegistrarServer(ctx, nsm.model, nsm.manager)
 	return err
 }
 
@@ -582,6 +584,10 @@ func (nsm *nsmServer) StartAPIServerAt(ctx context.Context, sock net.Listener, p
 	// Register Remote NetworkServiceManager
 	unified.RegisterNetworkServiceServer(grpcServer, nsm.remoteServer)
 
+	// Register administrative API, callers are authorized by SPIFFE ID
+	adminAuthorizer := authz.NewAdminAuthorizer(AdminSpiffeIDsEnv.GetStringListValueOrDefault())
+	admin.RegisterAdminServer(grpcServer, NewAdminServer(nsm.model, nsm.manager, adminAuthorizer))
+
 	// TODO: Add more public API services here.
 	go func() {
 		if err := grpcServer.Serve(sock); err != nil {
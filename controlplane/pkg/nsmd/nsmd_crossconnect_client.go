@@ -34,6 +34,7 @@ import (
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/crossconnect"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/services"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
@@ -451,6 +452,8 @@ func (client *NsmMonitorCrossConnectClient) remotePeerConnectionMonitor(ctx cont
 		})
 	}
 
+	go client.remotePeerCrossConnectMonitor(ctx, remoteNsm)
+
 	err := client.monitor(
 		ctx,
 		peerLogFormat, peerLogWithParamFormat, remoteNsm.Name,
@@ -473,6 +476,48 @@ func (client *NsmMonitorCrossConnectClient) remotePeerConnectionMonitor(ctx cont
 	remotePeer.Unlock()
 }
 
+// remotePeerCrossConnectMonitor counts active connections to the endpoints of the remote peer using the crossconnect
+// monitor of the remote NSMD. The counts are stored in the model and used to select endpoints across NSMDs.
+func (client *NsmMonitorCrossConnectClient) remotePeerCrossConnectMonitor(ctx context.Context, remoteNsm *registry.NetworkServiceManager) {
+	grpcConnectionSupplier := func() (*grpc.ClientConn, error) {
+		return tools.DialContextTCP(ctx, remoteNsm.GetUrl())
+	}
+
+	// Handlers are called sequentially from the monitor loop
+	endpointNames := map[string]string{}
+	entityHandler := func(entity monitor.Entity, eventType monitor.EventType, _ map[string]string) error {
+		xcon, ok := entity.(*crossconnect.CrossConnect)
+		if !ok {
+			return errors.Errorf("unable to cast %v to CrossConnect", entity)
+		}
+		dst := xcon.GetLocalDestination()
+		if eventType == monitor.EventTypeDelete || dst == nil || dst.GetState() == connection.State_DOWN {
+			delete(endpointNames, xcon.GetId())
+			return nil
+		}
+		endpointNames[xcon.GetId()] = dst.GetNetworkServiceEndpointName()
+		return nil
+	}
+	eventHandler := func(_ monitor.Event, _ map[string]string) error {
+		counts := make(map[string]int, len(endpointNames))
+		for _, name := range endpointNames {
+			counts[name]++
+		}
+		client.model.SetRemoteConnectionCounts(remoteNsm.GetName(), counts)
+		return nil
+	}
+
+	err := client.monitor(
+		ctx,
+		peerLogFormat, peerLogWithParamFormat, remoteNsm.GetName(),
+		grpcConnectionSupplier, monitor_crossconnect.NewMonitorClient,
+		entityHandler, eventHandler, nil)
+	if err != nil {
+		logrus.Warnf(peerLogWithParamFormat, remoteNsm.GetName(), "Connection counts are not available", err)
+	}
+	client.model.SetRemoteConnectionCounts(remoteNsm.GetName(), nil)
+}
+
 func (client *NsmMonitorCrossConnectClient) handleRemoteConnection(entity monitor.Entity, eventType monitor.EventType, parameters map[string]string) error {
 	remoteConnection, ok := entity.(*connection.Connection)
 
@@ -160,6 +160,33 @@ func (es *registryServer) RemoveNSE(ctx context.Context, request *registry.Remov
 	return &empty.Empty{}, nil
 }
 
+// DrainNSE marks the endpoint as draining in the upstream registry and moves its connections to other endpoints
+func (es *registryServer) DrainNSE(ctx context.Context, request *registry.DrainNSERequest) (*empty.Empty, error) {
+	span := spanhelper.FromContext(ctx, "DrainNSE")
+	defer span.Finish()
+
+	span.LogObject("request", request)
+	span.Logger().Infof("Received Endpoint Drain request: %+v", request)
+
+	client, err := es.nsm.serviceRegistry.NseRegistryClient(span.Context())
+	if err != nil {
+		err = errors.Wrap(err, "attempt to pass through from nsm to upstream registry failed with")
+		span.LogError(err)
+		return nil, err
+	}
+	_, err = client.DrainNSE(span.Context(), request)
+	if err != nil {
+		err = errors.Wrap(err, "attempt to pass through from nsm to upstream registry failed")
+		span.LogError(err)
+		return nil, err
+	}
+	if err = es.nsm.manager.DrainEndpoint(span.Context(), request.GetNetworkServiceEndpointName()); err != nil {
+		span.LogError(err)
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
 func (es *registryServer) Close() {
 
 }
@@ -21,10 +21,13 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/authz"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/metrics"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/sid"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/wgport"
 	forwarderapi "github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarder"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 )
@@ -37,6 +40,15 @@ const (
 	registryConnectTimeout = time.Second * 30
 	// PublicAPIAddressEnv sets nsmd public API address
 	PublicAPIAddressEnv utils.EnvVar = "NSMD_PUBLIC_API"
+	// VniStateFileEnv sets file to persist allocated VNIs across nsmd restarts, VNIs are kept in memory only if not set
+	VniStateFileEnv utils.EnvVar = "NSM_VNI_STATE_FILE"
+	// SRv6LocatorEnv sets SRv6 locator prefix of the node SIDs are allocated from
+	SRv6LocatorEnv utils.EnvVar = "NSM_SRV6_LOCATOR"
+	// WireguardPortRangeEnv sets range of UDP ports of the node Wireguard interfaces listen on, e.g. 51820-52819
+	WireguardPortRangeEnv utils.EnvVar = "NSM_WIREGUARD_PORT_RANGE"
+	// AuthzPolicyFileEnv sets JSON file with the policy of who may connect to which network service, everything is
+	// allowed if not set
+	AuthzPolicyFileEnv utils.EnvVar = "NSM_AUTHZ_POLICY_FILE"
 )
 
 type apiRegistry struct {
@@ -64,7 +76,12 @@ type nsmdServiceRegistry struct {
 	stopRedial               bool
 	vniAllocator             vni.VniAllocator
 	sidAllocator             sid.Allocator
+	wgPortAllocator          wgport.Allocator
+	authorizer               authz.Authorizer
 	registryAddress          string
+	watchDiscovery           bool
+	discoveryCache           *discoveryCache
+	discoveryConnection      *grpc.ClientConn
 }
 
 func (impl *nsmdServiceRegistry) NewWorkspaceProvider() serviceregistry.WorkspaceLocationProvider {
@@ -163,7 +180,17 @@ func (impl *nsmdServiceRegistry) DiscoveryClient(ctx context.Context) (registry.
 	defer cancel()
 	impl.initRegistryClient(ctx)
 	if impl.registryClientConnection != nil {
-		return registry.NewNetworkServiceDiscoveryClient(impl.registryClientConnection), nil
+		if !impl.watchDiscovery {
+			return registry.NewNetworkServiceDiscoveryClient(impl.registryClientConnection), nil
+		}
+		if impl.discoveryConnection != impl.registryClientConnection {
+			if impl.discoveryCache != nil {
+				impl.discoveryCache.Stop()
+			}
+			impl.discoveryCache = newDiscoveryCache(registry.NewNetworkServiceDiscoveryClient(impl.registryClientConnection))
+			impl.discoveryConnection = impl.registryClientConnection
+		}
+		return impl.discoveryCache, nil
 	}
 	return nil, errors.New("Connection to Network Registry Server is not available")
 }
@@ -189,7 +216,8 @@ func (impl *nsmdServiceRegistry) initRegistryClient(ctx context.Context) {
 		}
 		span.Logger().Println("Registry port now available, attempting to connect...")
 
-		conn, err := tools.DialContextTCP(span.Context(), impl.registryAddress)
+		conn, err := tools.DialContextTCP(span.Context(), impl.registryAddress,
+			grpc.WithChainUnaryInterceptor(metrics.RegistryClientInterceptor()))
 		if err != nil {
 			span.Logger().Errorf("Failed to dial Network Service Registry at %s: %s", impl.registryAddress, err)
 			continue
@@ -208,6 +236,9 @@ func (impl *nsmdServiceRegistry) Stop() {
 	impl.RWMutex.Lock()
 	defer impl.RWMutex.Unlock()
 
+	if impl.discoveryCache != nil {
+		impl.discoveryCache.Stop()
+	}
 	if impl.registryClientConnection != nil {
 		impl.registryClientConnection.Close()
 	}
@@ -220,18 +251,78 @@ func NewServiceRegistry() serviceregistry.ServiceRegistry {
 		registryAddress = "127.0.0.1:5000"
 	}
 
-	return NewServiceRegistryAt(registryAddress)
+	impl := newNsmdServiceRegistry(registryAddress)
+	// Long living NSMD keeps found Network Services up to date with the registry watches
+	impl.watchDiscovery = true
+	return impl
 }
 
 func NewServiceRegistryAt(nsmAddress string) serviceregistry.ServiceRegistry {
+	return newNsmdServiceRegistry(nsmAddress)
+}
+
+func newNsmdServiceRegistry(nsmAddress string) *nsmdServiceRegistry {
 	return &nsmdServiceRegistry{
 		stopRedial:      true,
-		vniAllocator:    vni.NewVniAllocator(),
-		sidAllocator:    sid.NewSIDAllocator(),
+		vniAllocator:    newVniAllocator(),
+		sidAllocator:    newSIDAllocator(),
+		wgPortAllocator: newWireguardPortAllocator(),
+		authorizer:      newAuthorizer(),
 		registryAddress: nsmAddress,
 	}
 }
 
+func newSIDAllocator() sid.Allocator {
+	locator := SRv6LocatorEnv.StringValue()
+	if locator == "" {
+		logrus.Infof("%s is not set, SRv6 SIDs are not allocated", SRv6LocatorEnv.Name())
+		return sid.NewDisabledSIDAllocator()
+	}
+	allocator, err := sid.NewSIDAllocatorWithLocator(locator)
+	if err != nil {
+		logrus.Errorf("Failed to create SID allocator, SRv6 SIDs are not allocated: %v", err)
+		return sid.NewDisabledSIDAllocator()
+	}
+	return allocator
+}
+
+func newWireguardPortAllocator() wgport.Allocator {
+	portRange := WireguardPortRangeEnv.GetStringOrDefault(wgport.DefaultRange)
+	allocator, err := wgport.NewPortAllocatorWithRange(portRange)
+	if err != nil {
+		logrus.Errorf("Failed to create Wireguard port allocator, using default range %s: %v", wgport.DefaultRange, err)
+		return wgport.NewPortAllocator()
+	}
+	return allocator
+}
+
+func newAuthorizer() authz.Authorizer {
+	policyFile := AuthzPolicyFileEnv.StringValue()
+	if policyFile == "" {
+		return authz.NewPolicyAuthorizer(nil)
+	}
+	policy, err := authz.LoadPolicy(policyFile)
+	if err != nil {
+		logrus.Errorf("Failed to load authorization policy, denying all requests: %v", err)
+		return authz.NewDenyAllAuthorizer()
+	}
+	logrus.Infof("Authorization policy is loaded from %s", policyFile)
+	return authz.NewPolicyAuthorizer(policy)
+}
+
+func newVniAllocator() vni.VniAllocator {
+	statePath := VniStateFileEnv.StringValue()
+	if statePath == "" {
+		return vni.NewVniAllocator()
+	}
+	allocator, err := vni.NewPersistentVniAllocator(statePath)
+	if err != nil {
+		logrus.Errorf("Failed to restore VNI allocator state, starting from scratch: %v", err)
+		return vni.NewVniAllocator()
+	}
+	return allocator
+}
+
 func (impl *nsmdServiceRegistry) WaitForForwarderAvailable(ctx context.Context, mdl model.Model, timeout time.Duration) error {
 	span := spanhelper.FromContext(ctx, "wait-forwarder")
 	defer span.Finish()
@@ -239,7 +330,7 @@ func (impl *nsmdServiceRegistry) WaitForForwarderAvailable(ctx context.Context,
 
 	st := time.Now()
 	checkConfigured := func(dp *model.Forwarder) bool {
-		return dp.MechanismsConfigured
+		return dp.MechanismsConfigured && !dp.Draining
 	}
 	for ; true; <-time.After(100 * time.Millisecond) {
 		if dp, _ := mdl.SelectForwarder(checkConfigured); dp != nil {
@@ -262,6 +353,14 @@ func (impl *nsmdServiceRegistry) SIDAllocator() sid.Allocator {
 	return impl.sidAllocator
 }
 
+func (impl *nsmdServiceRegistry) WireguardPortAllocator() wgport.Allocator {
+	return impl.wgPortAllocator
+}
+
+func (impl *nsmdServiceRegistry) Authorizer() authz.Authorizer {
+	return impl.authorizer
+}
+
 type defaultWorkspaceProvider struct {
 	hostBaseDir     string
 	nsmBaseDir      string
@@ -0,0 +1,141 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import (
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+const (
+	// HealEndpointReselect - heal selects another endpoint for the connection
+	HealEndpointReselect = "reselect"
+	// HealEndpointWaitSame - heal waits for the same endpoint to become available again
+	HealEndpointWaitSame = "wait-same"
+)
+
+// HealPolicy - controls how the heal of the connection is retried
+type HealPolicy struct {
+	InitialBackoff    time.Duration `yaml:"initialBackoff"`
+	MaxBackoff        time.Duration `yaml:"maxBackoff"`
+	BackoffMultiplier float64       `yaml:"backoffMultiplier"`
+	// Jitter is a fraction of the backoff the delay is randomly spread by
+	Jitter      float64 `yaml:"jitter"`
+	MaxAttempts int     `yaml:"maxAttempts"`
+	// Deadline limits the total time of the heal, zero means no limit
+	Deadline time.Duration `yaml:"deadline"`
+	// Endpoint is one of HealEndpointReselect, HealEndpointWaitSame, empty keeps the default of the heal case
+	Endpoint string `yaml:"endpoint"`
+}
+
+// LoadHealPolicy - reads and validates YAML heal policy from the file, the fields not set in the file keep the values of defaults
+func LoadHealPolicy(policyFile string, defaults *HealPolicy) (*HealPolicy, error) {
+	data, err := ioutil.ReadFile(policyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read heal policy from %s", policyFile)
+	}
+	policy := *defaults
+	if err = yaml.Unmarshal(data, &policy); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse heal policy from %s", policyFile)
+	}
+	if err = policy.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid heal policy %s", policyFile)
+	}
+	return &policy, nil
+}
+
+// WithOverrides - returns a copy of the policy with the fields set in the heal policy of the Network Service
+func (p *HealPolicy) WithOverrides(overrides *registry.HealPolicy) (*HealPolicy, error) {
+	policy := *p
+	if overrides == nil {
+		return &policy, nil
+	}
+	var err error
+	if policy.InitialBackoff, err = overrideDuration(policy.InitialBackoff, overrides.GetInitialBackoff()); err != nil {
+		return nil, errors.Wrap(err, "invalid initial backoff")
+	}
+	if policy.MaxBackoff, err = overrideDuration(policy.MaxBackoff, overrides.GetMaxBackoff()); err != nil {
+		return nil, errors.Wrap(err, "invalid max backoff")
+	}
+	if policy.Deadline, err = overrideDuration(policy.Deadline, overrides.GetDeadline()); err != nil {
+		return nil, errors.Wrap(err, "invalid deadline")
+	}
+	if overrides.GetBackoffMultiplier() != 0 {
+		policy.BackoffMultiplier = overrides.GetBackoffMultiplier()
+	}
+	if overrides.GetJitter() != 0 {
+		policy.Jitter = overrides.GetJitter()
+	}
+	if overrides.GetMaxAttempts() != 0 {
+		policy.MaxAttempts = int(overrides.GetMaxAttempts())
+	}
+	if overrides.GetEndpoint() != "" {
+		policy.Endpoint = overrides.GetEndpoint()
+	}
+	if err = policy.validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Backoff - returns the delay before the next heal attempt, attempt is counted from 0
+func (p *HealPolicy) Backoff(attempt int) time.Duration {
+	backoff := float64(p.InitialBackoff) * math.Pow(p.BackoffMultiplier, float64(attempt))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	// Spread the attempts of the connections healing at the same time
+	backoff *= 1 + p.Jitter*(2*rand.Float64()-1)
+	return time.Duration(backoff)
+}
+
+func (p *HealPolicy) validate() error {
+	if p.InitialBackoff < 0 || p.MaxBackoff < 0 || p.Deadline < 0 {
+		return errors.New("durations should not be negative")
+	}
+	if p.BackoffMultiplier < 1 {
+		return errors.Errorf("backoff multiplier should not be less than 1: %v", p.BackoffMultiplier)
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return errors.Errorf("jitter should be in [0, 1]: %v", p.Jitter)
+	}
+	if p.MaxAttempts < 1 {
+		return errors.Errorf("max attempts should be positive: %v", p.MaxAttempts)
+	}
+	switch p.Endpoint {
+	case "", HealEndpointReselect, HealEndpointWaitSame:
+		return nil
+	default:
+		return errors.Errorf("unknown endpoint heal mode: %q", p.Endpoint)
+	}
+}
+
+func overrideDuration(value time.Duration, override *duration.Duration) (time.Duration, error) {
+	if override == nil {
+		return value, nil
+	}
+	return ptypes.Duration(override)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package properties
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+const testHealPolicy = `
+initialBackoff: 1s
+maxBackoff: 30s
+backoffMultiplier: 2
+jitter: 0.2
+endpoint: reselect
+`
+
+func TestLoadHealPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, err := ioutil.TempDir("", "heal")
+	g.Expect(err).To(BeNil())
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	policyFile := filepath.Join(dir, "heal.yaml")
+	g.Expect(ioutil.WriteFile(policyFile, []byte(testHealPolicy), 0600)).To(Succeed())
+
+	defaults := (&Properties{HealRetryCount: 10, HealRetryDelay: 5 * time.Second}).retryHealPolicy()
+	policy, err := LoadHealPolicy(policyFile, defaults)
+	g.Expect(err).To(BeNil())
+	g.Expect(policy).To(Equal(&HealPolicy{
+		InitialBackoff:    time.Second,
+		MaxBackoff:        30 * time.Second,
+		BackoffMultiplier: 2,
+		Jitter:            0.2,
+		MaxAttempts:       10,
+		Endpoint:          HealEndpointReselect,
+	}))
+}
+
+func TestLoadHealPolicy_Invalid(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, err := ioutil.TempDir("", "heal")
+	g.Expect(err).To(BeNil())
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	policyFile := filepath.Join(dir, "heal.yaml")
+	g.Expect(ioutil.WriteFile(policyFile, []byte("endpoint: somewhere"), 0600)).To(Succeed())
+
+	defaults := (&Properties{HealRetryCount: 10, HealRetryDelay: 5 * time.Second}).retryHealPolicy()
+	_, err = LoadHealPolicy(policyFile, defaults)
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestHealPolicyBackoff(t *testing.T) {
+	g := NewWithT(t)
+
+	policy := &HealPolicy{
+		InitialBackoff:    time.Second,
+		MaxBackoff:        5 * time.Second,
+		BackoffMultiplier: 2,
+		MaxAttempts:       10,
+	}
+	g.Expect(policy.Backoff(0)).To(Equal(time.Second))
+	g.Expect(policy.Backoff(1)).To(Equal(2 * time.Second))
+	g.Expect(policy.Backoff(2)).To(Equal(4 * time.Second))
+	g.Expect(policy.Backoff(3)).To(Equal(5 * time.Second))
+
+	policy.Jitter = 0.5
+	for attempt := 0; attempt < 10; attempt++ {
+		g.Expect(policy.Backoff(0)).To(BeNumerically(">=", 500*time.Millisecond))
+		g.Expect(policy.Backoff(0)).To(BeNumerically("<=", 1500*time.Millisecond))
+	}
+}
+
+func TestNetworkServiceHealPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	props := &Properties{HealRetryCount: 10, HealRetryDelay: 5 * time.Second}
+	g.Expect(props.NetworkServiceHealPolicy(&registry.NetworkService{})).To(Equal(props.retryHealPolicy()))
+
+	ns := &registry.NetworkService{
+		Name: "golden_network",
+		HealPolicy: &registry.HealPolicy{
+			MaxBackoff:        ptypes.DurationProto(time.Minute),
+			BackoffMultiplier: 3,
+			MaxAttempts:       3,
+			Deadline:          ptypes.DurationProto(10 * time.Minute),
+			Endpoint:          HealEndpointWaitSame,
+		},
+	}
+	g.Expect(props.NetworkServiceHealPolicy(ns)).To(Equal(&HealPolicy{
+		InitialBackoff:    5 * time.Second,
+		MaxBackoff:        time.Minute,
+		BackoffMultiplier: 3,
+		MaxAttempts:       3,
+		Deadline:          10 * time.Minute,
+		Endpoint:          HealEndpointWaitSame,
+	}))
+
+	ns.HealPolicy.Jitter = 2
+	g.Expect(props.NetworkServiceHealPolicy(ns)).To(Equal(props.retryHealPolicy()))
+}
@@ -21,6 +21,8 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 )
 
 const (
@@ -30,6 +32,10 @@ const (
 	NsmdHealDSTWaitTimeout = "NSMD_HEAL_DST_TIMEOUTs" // Wait timeout for DST in seconds
 	// NsmdHealRetryCount - amount of times healing will retry
 	NsmdHealRetryCount = "NSMD_HEAL_RETRY_COUNT"
+	// NsmdHealPolicyFile - environment variable name - YAML file with the default heal policy
+	NsmdHealPolicyFile = "NSMD_HEAL_POLICY_FILE"
+	// NsmdHealHistorySize - environment variable name - number of the recent heals NSMD keeps
+	NsmdHealHistorySize = "NSMD_HEAL_HISTORY_SIZE"
 )
 
 // Properties - holds properties of NSM connection events processing
@@ -48,6 +54,11 @@ type Properties struct {
 	HealDSTNSEWaitTick    time.Duration
 
 	HealEnabled bool
+
+	// HealPolicy is the default heal policy, Network Services could override it
+	HealPolicy *HealPolicy
+	// HealHistorySize is the number of the recent heals kept in the history
+	HealHistorySize int
 }
 
 // NewNsmProperties creates NsmProperties with defined default values and reading values from environment variables
@@ -66,6 +77,7 @@ func NewNsmProperties() *Properties {
 		HealDSTNSEWaitTimeout: time.Second * 30,       // Maximum time to wait for NSMD/NSE to re-appear
 		HealDSTNSEWaitTick:    500 * time.Millisecond, // Wait timeout to appear of NSE
 		HealEnabled:           true,
+		HealHistorySize:       100,
 	}
 
 	// Parse few Environment variables.
@@ -92,5 +104,52 @@ func NewNsmProperties() *Properties {
 		values.HealRetryCount = int(value)
 	}
 
+	if historySize := os.Getenv(NsmdHealHistorySize); historySize != "" {
+		value, err := strconv.ParseInt(historySize, 10, 32)
+		switch {
+		case err != nil:
+			logrus.Errorf("Failed to parse heal history size value... %v", err)
+		case value < 1:
+			logrus.Errorf("Heal history size should be positive: %v", value)
+		default:
+			values.HealHistorySize = int(value)
+		}
+	}
+
+	values.HealPolicy = values.retryHealPolicy()
+	if policyFile := os.Getenv(NsmdHealPolicyFile); policyFile != "" {
+		policy, err := LoadHealPolicy(policyFile, values.HealPolicy)
+		if err != nil {
+			logrus.Errorf("Failed to load heal policy, using the default one: %v", err)
+		} else {
+			logrus.Infof("Override heal policy: %+v", policy)
+			values.HealPolicy = policy
+		}
+	}
+
 	return values
 }
+
+// NetworkServiceHealPolicy - returns the default heal policy overridden by the heal policy of the Network Service
+func (p *Properties) NetworkServiceHealPolicy(ns *registry.NetworkService) *HealPolicy {
+	policy := p.HealPolicy
+	if policy == nil {
+		policy = p.retryHealPolicy()
+	}
+	overridden, err := policy.WithOverrides(ns.GetHealPolicy())
+	if err != nil {
+		logrus.Errorf("Invalid heal policy of Network Service %s, using the default one: %v", ns.GetName(), err)
+		return policy
+	}
+	return overridden
+}
+
+// retryHealPolicy - retries HealRetryCount times with the fixed HealRetryDelay
+func (p *Properties) retryHealPolicy() *HealPolicy {
+	return &HealPolicy{
+		InitialBackoff:    p.HealRetryDelay,
+		MaxBackoff:        p.HealRetryDelay,
+		BackoffMultiplier: 1,
+		MaxAttempts:       p.HealRetryCount,
+	}
+}
@@ -59,6 +59,10 @@ func (cce *connectionService) Request(ctx context.Context, request *networkservi
 			span.LogError(err)
 			return nil, err
 		}
+		// Lease refresh doesn't change the connection, so there is no need to pass it through the chain
+		if common.IsLeaseRefresh(clientConnection, request) {
+			return common.RefreshLease(ctx, cce.model, clientConnection, request), nil
+		}
 
 		request.Connection.Id = clientConnection.GetID()
 		clientConnection = cce.updateClientConnection(ctx, span.Logger(), id, clientConnection)
@@ -93,6 +93,9 @@ func (cce *endpointService) Request(ctx context.Context, request *networkservice
 	}()
 
 	message := cce.createLocalNSERequest(endpoint, dp, request.Connection, clientConnection)
+	if err = common.SignPath(ctx, message.GetConnection(), endpoint.GetNetworkServiceEndpoint().GetName()); err != nil {
+		return nil, errors.Errorf("NSM:(7.2.6.2) Failed to sign request path. %v", err)
+	}
 	logger.Infof("NSM:(7.2.6.2) Requesting NSE with request %v", message)
 
 	span := spanhelper.FromContext(ctx, "nse.request")
@@ -146,7 +149,7 @@ func (cce *endpointService) createLocalNSERequest(endpoint *registry.NSERegistra
 					NetworkService: localDst.NetworkService,
 					Context:        localDst.GetContext(),
 					Labels:         localDst.GetLabels(),
-					Path:           common.Strings2Path(cce.model.GetNsm().GetName()),
+					Path:           common.AppendPath(requestConn.GetPath(), cce.model.GetNsm().GetName()),
 				},
 				MechanismPreferences: localM,
 			}
@@ -157,7 +160,7 @@ func (cce *endpointService) createLocalNSERequest(endpoint *registry.NSERegistra
 		Connection: &connection.Connection{
 			Id:             cce.model.ConnectionID(), //NSMgr assign ID for local Endpoint connections
 			NetworkService: endpoint.GetNetworkService().GetName(),
-			Path:           common.Strings2Path(cce.model.GetNsm().GetName()),
+			Path:           common.AppendPath(requestConn.GetPath(), cce.model.GetNsm().GetName()),
 			Context:        requestConn.GetContext(),
 			Labels:         requestConn.GetLabels(),
 		},
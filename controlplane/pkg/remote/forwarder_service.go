@@ -23,7 +23,6 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
-	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/srv6"
@@ -34,6 +33,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/common"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
 	"github.com/networkservicemesh/networkservicemesh/utils"
 )
@@ -59,6 +59,9 @@ type forwarderService struct {
 
 func (cce *forwarderService) selectForwarder(request *networkservice.NetworkServiceRequest) (*model.Forwarder, error) {
 	dp, err := cce.model.SelectForwarder(func(dp *model.Forwarder) bool {
+		if dp.Draining {
+			return false
+		}
 		for _, m := range request.GetRequestMechanismPreferences() {
 			if cce.findMechanism(dp.RemoteMechanisms, m.GetType()) != nil {
 				return true
@@ -78,101 +81,96 @@ func (cce *forwarderService) findMechanism(mechanismPreferences []*connection.Me
 }
 
 func (cce *forwarderService) selectRemoteMechanism(request *networkservice.NetworkServiceRequest, dp *model.Forwarder) (*connection.Mechanism, error) {
-	var mechanism *connection.Mechanism
-	var dpMechanism *connection.Mechanism
-
+	// The preferred mechanism goes first, the rest in the order of the request preferences
+	preferences := request.GetRequestMechanismPreferences()
 	if preferredMechanismName := PreferredRemoteMechanism.StringValue(); len(preferredMechanismName) > 0 {
-		for _, m := range request.GetRequestMechanismPreferences() {
-			if m.GetType() == preferredMechanismName {
-				if dpm := cce.findMechanism(dp.RemoteMechanisms, m.GetType()); dpm != nil {
-					mechanism = m
-					dpMechanism = dpm
-					break
-				}
-			}
+		if preferred := cce.findMechanism(preferences, preferredMechanismName); preferred != nil {
+			preferences = append([]*connection.Mechanism{preferred}, preferences...)
 		}
 	}
 
-	if mechanism == nil {
-		for _, m := range request.GetRequestMechanismPreferences() {
-			dpm := cce.findMechanism(dp.RemoteMechanisms, m.GetType())
-			if dpm != nil {
-				mechanism = m
-				dpMechanism = dpm
-				break
-			}
+	connectionID := request.GetConnection().GetId()
+	for _, mechanism := range preferences {
+		dpMechanism := cce.findMechanism(dp.RemoteMechanisms, mechanism.GetType())
+		if dpMechanism == nil {
+			continue
 		}
+		cce.releaseUnselectedMechanisms(connectionID, mechanism.GetType())
+		if err := cce.configureParameters(connectionID, mechanism, dpMechanism); err != nil {
+			// Try the next mechanism, e.g. SRv6 is not selected if SIDs can't be allocated on this node
+			logrus.Errorf("NSM:(5.1) Failed to configure remote mechanism %v: %v", mechanism.GetType(), err)
+			continue
+		}
+		logrus.Infof("NSM:(5.1) Remote mechanism selected %v", mechanism)
+		return mechanism, nil
 	}
+	return nil, errors.Errorf("failed to select mechanism, no matched mechanisms found")
+}
 
-	if mechanism == nil || dpMechanism == nil {
-		return nil, errors.Errorf("failed to select mechanism, no matched mechanisms found")
-	}
-
-	connectionID := request.GetConnection().GetId()
+// configureParameters allocates VNI, SIDs or Wireguard port for the mechanism and sets the parameters of our side
+func (cce *forwarderService) configureParameters(connectionID string, mechanism, dpMechanism *connection.Mechanism) error {
 	parameters := mechanism.GetParameters()
 	dpParameters := dpMechanism.GetParameters()
-
 	switch mechanism.GetType() {
 	case vxlan.MECHANISM:
-		cce.configureVXLANParameters(parameters, dpParameters)
-
+		return cce.configureVXLANParameters(connectionID, parameters, dpParameters)
 	case srv6.MECHANISM:
-		cce.configureSRv6Parameters(connectionID, parameters, dpParameters)
-
+		return cce.configureSRv6Parameters(connectionID, parameters, dpParameters)
 	case wireguard.MECHANISM:
-		cce.configureWireguardParameters(connectionID, parameters, dpParameters)
+		return cce.configureWireguardParameters(connectionID, parameters, dpParameters)
 	}
-
-	logrus.Infof("NSM:(5.1) Remote mechanism selected %v", mechanism)
-	return mechanism, nil
+	return nil
 }
 
-func (cce *forwarderService) configureVXLANParameters(parameters, dpParameters map[string]string) {
-	parameters[vxlan.DstIP] = dpParameters[vxlan.SrcIP]
-
-	extSrcIP := parameters[vxlan.SrcIP]
-	extDstIP := dpParameters[vxlan.SrcIP]
-	srcIP := parameters[vxlan.SrcIP]
-	dstIP := dpParameters[vxlan.SrcIP]
-
-	if ip, ok := parameters[vxlan.SrcOriginalIP]; ok {
-		srcIP = ip
+// releaseUnselectedMechanisms releases VNI, SIDs and Wireguard port allocated for the mechanism previously selected
+// for the connection, if another mechanism is selected now
+func (cce *forwarderService) releaseUnselectedMechanisms(connectionID, selected string) {
+	if selected != vxlan.MECHANISM {
+		cce.serviceRegistry.VniAllocator().Release(connectionID)
 	}
-
-	if ip, ok := parameters[vxlan.DstExternalIP]; ok {
-		extDstIP = ip
+	if selected != srv6.MECHANISM {
+		cce.serviceRegistry.SIDAllocator().Release(connectionID)
+	}
+	if selected != wireguard.MECHANISM {
+		cce.serviceRegistry.WireguardPortAllocator().Release(connectionID)
 	}
+}
 
-	var vni uint32
-	if extDstIP != extSrcIP {
-		vni = cce.serviceRegistry.VniAllocator().Vni(extDstIP, extSrcIP)
-	} else {
-		vni = cce.serviceRegistry.VniAllocator().Vni(dstIP, srcIP)
+func (cce *forwarderService) configureVXLANParameters(connectionID string, parameters, dpParameters map[string]string) error {
+	parameters[vxlan.DstIP] = dpParameters[vxlan.SrcIP]
+
+	localIP, remoteIP := vni.MechanismPair(parameters)
+	vniID, err := cce.serviceRegistry.VniAllocator().Vni(connectionID, localIP, remoteIP)
+	if err != nil {
+		return err
 	}
 
-	parameters[vxlan.VNI] = strconv.FormatUint(uint64(vni), 10)
+	parameters[vxlan.VNI] = strconv.FormatUint(uint64(vniID), 10)
+	return nil
 }
 
-func (cce *forwarderService) configureSRv6Parameters(connectionID string, parameters, dpParameters map[string]string) {
+func (cce *forwarderService) configureSRv6Parameters(connectionID string, parameters, dpParameters map[string]string) error {
+	bsid, localSID, err := cce.serviceRegistry.SIDAllocator().SIDs(connectionID)
+	if err != nil {
+		return err
+	}
 	parameters[srv6.DstHardwareAddress] = dpParameters[srv6.SrcHardwareAddress]
 	parameters[srv6.DstHostIP] = dpParameters[srv6.SrcHostIP]
 	parameters[srv6.DstHostLocalSID] = dpParameters[srv6.SrcHostLocalSID]
-	parameters[srv6.DstBSID] = cce.serviceRegistry.SIDAllocator().SID(connectionID)
-	parameters[srv6.DstLocalSID] = cce.serviceRegistry.SIDAllocator().SID(connectionID)
+	parameters[srv6.DstBSID] = bsid
+	parameters[srv6.DstLocalSID] = localSID
+	return nil
 }
 
-func (cce *forwarderService) configureWireguardParameters(connectionID string, parameters, dpParameters map[string]string) {
-	parameters[wireguard.DstIP] = dpParameters[wireguard.SrcIP]
-
-	key, err := wgtypes.GeneratePrivateKey()
+func (cce *forwarderService) configureWireguardParameters(connectionID string, parameters, dpParameters map[string]string) error {
+	port, err := cce.serviceRegistry.WireguardPortAllocator().Port(connectionID)
 	if err != nil {
-		return
+		return err
 	}
-
-	parameters[wireguard.DstPrivateKey] = key.String()
-	parameters[wireguard.DstPublicKey] = key.PublicKey().String()
-
-	parameters[wireguard.DstPort] = wireguard.AssignPort(connectionID)
+	parameters[wireguard.DstIP] = dpParameters[wireguard.SrcIP]
+	parameters[wireguard.DstPublicKey] = dpParameters[wireguard.SrcPublicKey]
+	parameters[wireguard.DstPort] = strconv.Itoa(port)
+	return nil
 }
 
 func (cce *forwarderService) updateMechanism(request *networkservice.NetworkServiceRequest, dp *model.Forwarder) error {
@@ -262,6 +260,10 @@ func (cce *forwarderService) Close(ctx context.Context, conn *connection.Connect
 }
 
 func (cce *forwarderService) performClose(ctx context.Context, cc *model.ClientConnection, logger logrus.FieldLogger) error {
+	// Release VNI, SIDs and Wireguard port allocated for the connection
+	cce.serviceRegistry.VniAllocator().Release(cc.GetID())
+	cce.serviceRegistry.SIDAllocator().Release(cc.GetID())
+	cce.serviceRegistry.WireguardPortAllocator().Release(cc.GetID())
 	// Close endpoints, etc
 	if cc.ForwarderState != model.ForwarderStateNone {
 		logger.Info("NSM.Forwarder: Closing cross connection on forwarder...")
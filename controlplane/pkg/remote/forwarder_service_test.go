@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/srv6"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/sid"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/wgport"
+)
+
+type testServiceRegistry struct {
+	serviceregistry.ServiceRegistry
+	vniAllocator  vni.VniAllocator
+	sidAllocator  sid.Allocator
+	portAllocator wgport.Allocator
+}
+
+func (r *testServiceRegistry) VniAllocator() vni.VniAllocator {
+	return r.vniAllocator
+}
+
+func (r *testServiceRegistry) SIDAllocator() sid.Allocator {
+	return r.sidAllocator
+}
+
+func (r *testServiceRegistry) WireguardPortAllocator() wgport.Allocator {
+	return r.portAllocator
+}
+
+func TestSelectRemoteMechanismWithoutSRv6Locator(t *testing.T) {
+	g := NewWithT(t)
+
+	PreferredRemoteMechanism.Set(srv6.MECHANISM)
+	defer func() { _ = os.Unsetenv(PreferredRemoteMechanism.Name()) }()
+
+	srv := &forwarderService{
+		serviceRegistry: &testServiceRegistry{
+			vniAllocator:  vni.NewVniAllocator(),
+			sidAllocator:  sid.NewDisabledSIDAllocator(),
+			portAllocator: wgport.NewPortAllocator(),
+		},
+	}
+	request := &networkservice.NetworkServiceRequest{
+		Connection: &connection.Connection{Id: "1"},
+		MechanismPreferences: []*connection.Mechanism{
+			{Type: srv6.MECHANISM, Parameters: map[string]string{}},
+			{Type: vxlan.MECHANISM, Parameters: map[string]string{vxlan.SrcIP: "10.0.0.1"}},
+		},
+	}
+	dp := &model.Forwarder{
+		RemoteMechanisms: []*connection.Mechanism{
+			{Type: srv6.MECHANISM, Parameters: map[string]string{}},
+			{Type: vxlan.MECHANISM, Parameters: map[string]string{vxlan.SrcIP: "10.0.0.2"}},
+		},
+	}
+
+	mechanism, err := srv.selectRemoteMechanism(request, dp)
+	g.Expect(err).To(BeNil())
+	g.Expect(mechanism.GetType()).To(Equal(vxlan.MECHANISM))
+	g.Expect(mechanism.GetParameters()).To(HaveKey(vxlan.VNI))
+
+	// No mechanism left if SRv6 is the only one
+	request.MechanismPreferences = request.MechanismPreferences[:1]
+	_, err = srv.selectRemoteMechanism(request, dp)
+	g.Expect(err).NotTo(BeNil())
+}
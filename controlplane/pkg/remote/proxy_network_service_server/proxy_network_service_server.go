@@ -179,7 +179,7 @@ func (srv *proxyNetworkServiceServer) getRemoteNsrPort() string {
 func (srv *proxyNetworkServiceServer) Close(ctx context.Context, connection *connection.Connection) (*empty.Empty, error) {
 	logrus.Infof("ProxyNSMD: Proxy closing connection: %v", *connection)
 
-	destNsmName := connection.GetPath().GetPathSegments()[1].GetName()
+	destNsmName := connection.GetDestinationNetworkServiceManagerName()
 	dNsmName, dNsmAddress, err := interdomain.ParseNsmURL(destNsmName)
 	if err != nil {
 		return nil, errors.Errorf("ProxyNSMD: Failed to extract destination nsm address")
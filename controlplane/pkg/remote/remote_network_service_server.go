@@ -25,8 +25,11 @@ import (
 func NewRemoteNetworkServiceServer(manager nsm.NetworkServiceManager, connectionMonitor connectionmonitor.MonitorServer) networkservice.NetworkServiceServer {
 	return common.NewCompositeService("Remote",
 		common.NewRequestValidator(),
+		common.NewRemotePathVerifier(manager.Model()),
 		common.NewMonitorService(connectionMonitor),
 		NewConnectionService(manager.Model()),
+		common.NewAuthorizationService(manager.Model(), manager.ServiceRegistry()),
+		common.NewMetricsService("remote"),
 		NewForwarderService(manager.Model(), manager.ServiceRegistry()),
 		NewEndpointSelectorService(manager.NseManager(), manager.Model()),
 		common.NewExcludedPrefixesService(),
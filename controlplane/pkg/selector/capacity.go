@@ -0,0 +1,35 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// ErrNoCapacity is returned when all the endpoints are serving their maximum number of connections
+var ErrNoCapacity = errors.New("no capacity")
+
+// IsSaturated checks if the endpoint is serving its maximum number of connections. counts are active connections per
+// endpoint across NSMDs as returned by ConnectionCounter. Endpoints not advertising max connections are never saturated.
+func IsSaturated(counts map[string]int, nse *registry.NetworkServiceEndpoint) bool {
+	if nse.GetMaxConnections() == 0 {
+		return false
+	}
+	return counts[nse.GetName()] >= int(nse.GetMaxConnections())
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func TestIsSaturated(t *testing.T) {
+	counts := map[string]int{"nse1": 2, "nse2": 2}
+	tests := []struct {
+		name   string
+		counts map[string]int
+		nse    *registry.NetworkServiceEndpoint
+		want   bool
+	}{
+		{"unlimited", counts, &registry.NetworkServiceEndpoint{Name: "nse1"}, false},
+		{"below max", counts, &registry.NetworkServiceEndpoint{Name: "nse1", MaxConnections: 3}, false},
+		{"at max", counts, &registry.NetworkServiceEndpoint{Name: "nse2", MaxConnections: 2}, true},
+		{"no connections", counts, &registry.NetworkServiceEndpoint{Name: "nse3", MaxConnections: 1}, false},
+		{"no counts", nil, &registry.NetworkServiceEndpoint{Name: "nse2", MaxConnections: 2}, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsSaturated(tt.counts, tt.nse); got != tt.want {
+				t.Errorf("IsSaturated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
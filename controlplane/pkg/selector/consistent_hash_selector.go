@@ -0,0 +1,116 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// virtualNodes is a number of points every endpoint takes on the hash ring
+const virtualNodes = 100
+
+// DefaultAffinityLabels are hashed for the Network Services not specifying affinity labels
+var DefaultAffinityLabels = []string{connection.NamespaceKey, connection.PodNameKey}
+
+type ringPoint struct {
+	hash     uint64
+	endpoint *registry.NetworkServiceEndpoint
+}
+
+type consistentHashSelector struct {
+	roundRobin *roundRobinSelector
+}
+
+// NewConsistentHashSelector creates a new selector hashing affinity labels of the request connection
+// onto the ring of the endpoints. Requests with the same affinity labels are selecting the same endpoint
+// while it is available, and only a minimal fraction of the requests moves when the endpoints are added or removed.
+func NewConsistentHashSelector() Selector {
+	return &consistentHashSelector{
+		roundRobin: newRoundRobinSelector(),
+	}
+}
+
+func (c *consistentHashSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if len(networkServiceEndpoints) == 0 {
+		return nil, nil
+	}
+	key, ok := affinityKey(requestConnection.GetLabels(), ns.GetAffinityLabels())
+	if !ok {
+		// Nothing to keep affinity on
+		return c.roundRobin.SelectEndpoint(requestConnection, ns, networkServiceEndpoints)
+	}
+	ring := newHashRing(networkServiceEndpoints)
+	hash := hashOf(key)
+	idx := sort.Search(len(ring), func(i int) bool {
+		return ring[i].hash >= hash
+	})
+	if idx == len(ring) {
+		idx = 0
+	}
+	endpoint := ring[idx].endpoint
+	logrus.Infof("ConsistentHash selected %v for %q", endpoint.GetName(), key)
+	return endpoint, nil
+}
+
+// affinityKey builds the key from the affinity labels of the connection, returns false if none of them is set
+func affinityKey(labels map[string]string, affinityLabels []string) (string, bool) {
+	if len(affinityLabels) == 0 {
+		affinityLabels = DefaultAffinityLabels
+	}
+	found := false
+	var sb strings.Builder
+	for _, name := range affinityLabels {
+		value, ok := labels[name]
+		found = found || ok
+		sb.WriteString(name)
+		sb.WriteString("=")
+		sb.WriteString(value)
+		sb.WriteString(";")
+	}
+	return sb.String(), found
+}
+
+// newHashRing places virtualNodes points of every endpoint onto the ring sorted by hash
+func newHashRing(networkServiceEndpoints []*registry.NetworkServiceEndpoint) []ringPoint {
+	ring := make([]ringPoint, 0, len(networkServiceEndpoints)*virtualNodes)
+	for _, nse := range networkServiceEndpoints {
+		for i := 0; i < virtualNodes; i++ {
+			ring = append(ring, ringPoint{
+				hash:     hashOf(nse.GetName() + "#" + strconv.Itoa(i)),
+				endpoint: nse,
+			})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool {
+		return ring[i].hash < ring[j].hash
+	})
+	return ring
+}
+
+func hashOf(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func makeEndpoints(count int) []*registry.NetworkServiceEndpoint {
+	endpoints := make([]*registry.NetworkServiceEndpoint, 0, count)
+	for i := 0; i < count; i++ {
+		endpoints = append(endpoints, &registry.NetworkServiceEndpoint{Name: fmt.Sprintf("nse%d", i)})
+	}
+	return endpoints
+}
+
+func podConnection(i int) *connection.Connection {
+	return &connection.Connection{
+		Labels: map[string]string{
+			connection.NamespaceKey: "default",
+			connection.PodNameKey:   fmt.Sprintf("pod%d", i),
+		},
+	}
+}
+
+func selectByPods(t *testing.T, s Selector, ns *registry.NetworkService, endpoints []*registry.NetworkServiceEndpoint, pods int) []string {
+	result := make([]string, 0, pods)
+	for i := 0; i < pods; i++ {
+		result = append(result, mustSelectEndpoint(t, s, podConnection(i), ns, endpoints).GetName())
+	}
+	return result
+}
+
+func TestConsistentHashSelectorAffinity(t *testing.T) {
+	s := NewConsistentHashSelector()
+	ns := &registry.NetworkService{Name: "ns"}
+	endpoints := makeEndpoints(5)
+	first := selectByPods(t, s, ns, endpoints, 100)
+
+	reversed := make([]*registry.NetworkServiceEndpoint, 0, len(endpoints))
+	for i := len(endpoints) - 1; i >= 0; i-- {
+		reversed = append(reversed, endpoints[i])
+	}
+	second := selectByPods(t, s, ns, reversed, 100)
+
+	used := map[string]bool{}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("pod%d selected %v, then %v", i, first[i], second[i])
+		}
+		used[first[i]] = true
+	}
+	if len(used) != len(endpoints) {
+		t.Errorf("selected endpoints %v, want all of %d endpoints", used, len(endpoints))
+	}
+}
+
+func TestConsistentHashSelectorMinimalMoves(t *testing.T) {
+	s := NewConsistentHashSelector()
+	ns := &registry.NetworkService{Name: "ns"}
+	const pods = 1000
+	endpoints := makeEndpoints(5)
+	before := selectByPods(t, s, ns, endpoints, pods)
+
+	// Remove nse0: only clients of nse0 should move
+	after := selectByPods(t, s, ns, endpoints[1:], pods)
+	for i := range before {
+		if before[i] != "nse0" && before[i] != after[i] {
+			t.Errorf("pod%d moved from %v to %v after nse0 was removed", i, before[i], after[i])
+		}
+	}
+
+	// Add nse5: clients should move only to nse5, roughly 1/6 of them
+	after = selectByPods(t, s, ns, makeEndpoints(6), pods)
+	moved := 0
+	for i := range before {
+		if before[i] != after[i] {
+			if after[i] != "nse5" {
+				t.Errorf("pod%d moved from %v to %v after nse5 was added", i, before[i], after[i])
+			}
+			moved++
+		}
+	}
+	if moved == 0 || moved > pods/3 {
+		t.Errorf("%d of %d clients moved after nse5 was added", moved, pods)
+	}
+}
+
+func TestConsistentHashSelectorAffinityLabels(t *testing.T) {
+	s := NewConsistentHashSelector()
+	ns := &registry.NetworkService{
+		Name:           "ns",
+		AffinityLabels: []string{"app"},
+	}
+	endpoints := makeEndpoints(5)
+	app := func(pod, name string) *connection.Connection {
+		return &connection.Connection{Labels: map[string]string{connection.PodNameKey: pod, "app": name}}
+	}
+	expected := mustSelectEndpoint(t, s, app("pod0", "vpn"), ns, endpoints)
+	for i := 1; i < 10; i++ {
+		if nse := mustSelectEndpoint(t, s, app(fmt.Sprintf("pod%d", i), "vpn"), ns, endpoints); nse != expected {
+			t.Errorf("SelectEndpoint() = %v, want %v", nse.GetName(), expected.GetName())
+		}
+	}
+}
+
+func TestConsistentHashSelectorNoLabels(t *testing.T) {
+	s := NewConsistentHashSelector()
+	ns := &registry.NetworkService{Name: "ns"}
+	endpoints := makeEndpoints(2)
+	// Without affinity labels endpoints are round robined
+	for _, want := range []string{"nse0", "nse1", "nse0"} {
+		if nse := mustSelectEndpoint(t, s, &connection.Connection{}, ns, endpoints); nse.GetName() != want {
+			t.Errorf("SelectEndpoint() = %v, want %v", nse.GetName(), want)
+		}
+	}
+	if nse := mustSelectEndpoint(t, s, podConnection(0), ns, nil); nse != nil {
+		t.Errorf("SelectEndpoint() = %v, want nil", nse)
+	}
+}
+
+func TestMatchSelectorConsistentHashStrategy(t *testing.T) {
+	s := NewMatchSelector(NewRegistry(nil))
+	ns := &registry.NetworkService{
+		Name:              "ns",
+		SelectionStrategy: ConsistentHash,
+	}
+	endpoints := makeEndpoints(5)
+	expected := selectByPods(t, s, ns, endpoints, 20)
+	for i := 0; i < 3; i++ {
+		for pod, got := range selectByPods(t, s, ns, endpoints, 20) {
+			if got != expected[pod] {
+				t.Errorf("pod%d selected %v, want %v", pod, got, expected[pod])
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+type leastConnectionsSelector struct {
+	counter    ConnectionCounter
+	roundRobin *roundRobinSelector
+}
+
+// NewLeastConnectionsSelector creates a new selector choosing endpoint with the fewest active connections reported by counter
+func NewLeastConnectionsSelector(counter ConnectionCounter) Selector {
+	return newLeastConnectionsSelector(counter)
+}
+
+func newLeastConnectionsSelector(counter ConnectionCounter) *leastConnectionsSelector {
+	return &leastConnectionsSelector{
+		counter:    counter,
+		roundRobin: newRoundRobinSelector(),
+	}
+}
+
+func (lc *leastConnectionsSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	if lc == nil {
+		return nil, nil
+	}
+	return lc.selectByKey(ns.GetName(), networkServiceEndpoints), nil
+}
+
+// selectByKey selects endpoint with the fewest connections, endpoints with equal amount of connections are round robined
+func (lc *leastConnectionsSelector) selectByKey(key string, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	if lc.counter == nil {
+		return lc.roundRobin.selectByKey(key, networkServiceEndpoints)
+	}
+
+	counts := lc.counter.ConnectionCounts()
+	var candidates []*registry.NetworkServiceEndpoint
+	minCount := -1
+	for _, nse := range networkServiceEndpoints {
+		count := counts[nse.GetName()]
+		switch {
+		case minCount < 0 || count < minCount:
+			minCount = count
+			candidates = []*registry.NetworkServiceEndpoint{nse}
+		case count == minCount:
+			candidates = append(candidates, nse)
+		}
+	}
+
+	endpoint := lc.roundRobin.selectByKey(key, candidates)
+	if endpoint != nil {
+		logrus.Infof("LeastConnections selected %v with %d active connections", endpoint.GetName(), minCount)
+	}
+	return endpoint
+}
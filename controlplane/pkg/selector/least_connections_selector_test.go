@@ -0,0 +1,143 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+type testConnectionCounter map[string]int
+
+func (c testConnectionCounter) ConnectionCounts() map[string]int {
+	counts := make(map[string]int, len(c))
+	for name, count := range c {
+		counts[name] = count
+	}
+	return counts
+}
+
+func leastConnectionsEndpoints(names ...string) []*registry.NetworkServiceEndpoint {
+	endpoints := []*registry.NetworkServiceEndpoint{}
+	for _, name := range names {
+		endpoints = append(endpoints, &registry.NetworkServiceEndpoint{Name: name})
+	}
+	return endpoints
+}
+
+func TestLeastConnectionsSelectsLeastLoaded(t *testing.T) {
+	counter := testConnectionCounter{"nse1": 3, "nse2": 0, "nse3": 1}
+	s := NewLeastConnectionsSelector(counter)
+	ns := &registry.NetworkService{Name: "ns"}
+	endpoints := leastConnectionsEndpoints("nse1", "nse2", "nse3")
+
+	for i := 0; i < 5; i++ {
+		nse := mustSelectEndpoint(t, s, &connection.Connection{}, ns, endpoints)
+		if nse.GetName() == "nse1" {
+			t.Fatalf("SelectEndpoint() = nse1 with %v", counter)
+		}
+		counter[nse.GetName()]++
+	}
+
+	for _, name := range []string{"nse1", "nse2", "nse3"} {
+		if counter[name] != 3 {
+			t.Errorf("connections are not balanced: %v", counter)
+		}
+	}
+}
+
+func TestLeastConnectionsRoundRobinOnTie(t *testing.T) {
+	s := NewLeastConnectionsSelector(testConnectionCounter{"nse1": 1, "nse2": 1, "nse3": 2})
+	ns := &registry.NetworkService{Name: "ns"}
+	endpoints := leastConnectionsEndpoints("nse1", "nse2", "nse3")
+
+	counts := map[string]int{}
+	for i := 0; i < 10; i++ {
+		counts[mustSelectEndpoint(t, s, &connection.Connection{}, ns, endpoints).GetName()]++
+	}
+	if counts["nse1"] != 5 || counts["nse2"] != 5 || counts["nse3"] != 0 {
+		t.Errorf("SelectEndpoint() distribution = %v, want nse1: 5, nse2: 5", counts)
+	}
+}
+
+func TestLeastConnectionsWithoutCounter(t *testing.T) {
+	s := NewLeastConnectionsSelector(nil)
+	ns := &registry.NetworkService{Name: "ns"}
+	endpoints := leastConnectionsEndpoints("nse1", "nse2")
+
+	if nse := mustSelectEndpoint(t, s, &connection.Connection{}, ns, endpoints); nse.GetName() != "nse1" {
+		t.Errorf("SelectEndpoint() = %v, want nse1", nse.GetName())
+	}
+	if nse := mustSelectEndpoint(t, s, &connection.Connection{}, ns, endpoints); nse.GetName() != "nse2" {
+		t.Errorf("SelectEndpoint() = %v, want nse2", nse.GetName())
+	}
+	if nse := mustSelectEndpoint(t, s, &connection.Connection{}, ns, nil); nse != nil {
+		t.Errorf("SelectEndpoint() = %v, want nil", nse)
+	}
+}
+
+func TestMatchSelectorLeastConnectionsStrategy(t *testing.T) {
+	counter := testConnectionCounter{"nse1": 5, "nse2": 2, "nse3": 0}
+	s := NewMatchSelector(NewRegistry(counter))
+	ns := &registry.NetworkService{
+		Name:              "ns",
+		SelectionStrategy: LeastConnections,
+		Matches: []*registry.Match{
+			{
+				Routes: []*registry.Destination{
+					{DestinationSelector: map[string]string{"app": "firewall"}},
+				},
+			},
+		},
+	}
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "nse1", Labels: map[string]string{"app": "firewall"}},
+		{Name: "nse2", Labels: map[string]string{"app": "firewall"}},
+		{Name: "nse3", Labels: map[string]string{"app": "vpn"}},
+	}
+
+	for i := 0; i < 3; i++ {
+		if nse := mustSelectEndpoint(t, s, &connection.Connection{}, ns, endpoints); nse.GetName() != "nse2" {
+			t.Fatalf("SelectEndpoint() = %v, want nse2", nse.GetName())
+		}
+	}
+}
+
+type callCountingCounter struct {
+	testConnectionCounter
+	calls int
+}
+
+func (c *callCountingCounter) ConnectionCounts() map[string]int {
+	c.calls++
+	return c.testConnectionCounter.ConnectionCounts()
+}
+
+func TestLeastConnectionsCountsOncePerSelection(t *testing.T) {
+	counter := &callCountingCounter{testConnectionCounter: testConnectionCounter{"nse1": 2, "nse2": 1}}
+	s := NewLeastConnectionsSelector(counter)
+	ns := &registry.NetworkService{Name: "ns"}
+
+	if nse := mustSelectEndpoint(t, s, &connection.Connection{}, ns, leastConnectionsEndpoints("nse1", "nse2", "nse3")); nse.GetName() != "nse3" {
+		t.Errorf("SelectEndpoint() = %v, want nse3", nse.GetName())
+	}
+	if counter.calls != 1 {
+		t.Errorf("ConnectionCounts() called %d times, want 1", counter.calls)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// Operators of the label selector requirements
+const (
+	OperatorIn           = "In"
+	OperatorNotIn        = "NotIn"
+	OperatorExists       = "Exists"
+	OperatorDoesNotExist = "DoesNotExist"
+)
+
+// matchesExpressions checks if labels satisfy all the requirements
+func matchesExpressions(labels map[string]string, requirements []*registry.LabelSelectorRequirement) bool {
+	for _, requirement := range requirements {
+		if !matchesRequirement(labels, requirement) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesRequirement(labels map[string]string, requirement *registry.LabelSelectorRequirement) bool {
+	value, ok := labels[requirement.GetKey()]
+	switch requirement.GetOperator() {
+	case OperatorIn:
+		return ok && contains(requirement.GetValues(), value)
+	case OperatorNotIn:
+		return !ok || !contains(requirement.GetValues(), value)
+	case OperatorExists:
+		return ok
+	case OperatorDoesNotExist:
+		return !ok
+	default:
+		logrus.Errorf("Unknown label selector operator %q for key %q", requirement.GetOperator(), requirement.GetKey())
+		return false
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func Test_matchesExpressions(t *testing.T) {
+	labels := map[string]string{
+		"version": "v2",
+		"tier":    "prod",
+		"gpu":     "",
+	}
+	tests := []struct {
+		name         string
+		requirements []*registry.LabelSelectorRequirement
+		want         bool
+	}{
+		{
+			name: "no requirements",
+			want: true,
+		},
+		{
+			name:         "in",
+			requirements: []*registry.LabelSelectorRequirement{{Key: "version", Operator: OperatorIn, Values: []string{"v2", "v3"}}},
+			want:         true,
+		},
+		{
+			name:         "in not matching",
+			requirements: []*registry.LabelSelectorRequirement{{Key: "version", Operator: OperatorIn, Values: []string{"v3"}}},
+			want:         false,
+		},
+		{
+			name:         "in missing label",
+			requirements: []*registry.LabelSelectorRequirement{{Key: "zone", Operator: OperatorIn, Values: []string{"a"}}},
+			want:         false,
+		},
+		{
+			name:         "not in",
+			requirements: []*registry.LabelSelectorRequirement{{Key: "tier", Operator: OperatorNotIn, Values: []string{"debug"}}},
+			want:         true,
+		},
+		{
+			name:         "not in matching",
+			requirements: []*registry.LabelSelectorRequirement{{Key: "tier", Operator: OperatorNotIn, Values: []string{"prod"}}},
+			want:         false,
+		},
+		{
+			name:         "not in missing label",
+			requirements: []*registry.LabelSelectorRequirement{{Key: "zone", Operator: OperatorNotIn, Values: []string{"a"}}},
+			want:         true,
+		},
+		{
+			name:         "exists",
+			requirements: []*registry.LabelSelectorRequirement{{Key: "gpu", Operator: OperatorExists}},
+			want:         true,
+		},
+		{
+			name:         "exists missing label",
+			requirements: []*registry.LabelSelectorRequirement{{Key: "zone", Operator: OperatorExists}},
+			want:         false,
+		},
+		{
+			name:         "does not exist",
+			requirements: []*registry.LabelSelectorRequirement{{Key: "zone", Operator: OperatorDoesNotExist}},
+			want:         true,
+		},
+		{
+			name:         "does not exist present label",
+			requirements: []*registry.LabelSelectorRequirement{{Key: "gpu", Operator: OperatorDoesNotExist}},
+			want:         false,
+		},
+		{
+			name:         "unknown operator",
+			requirements: []*registry.LabelSelectorRequirement{{Key: "gpu", Operator: "Gt"}},
+			want:         false,
+		},
+		{
+			name: "all requirements should match",
+			requirements: []*registry.LabelSelectorRequirement{
+				{Key: "gpu", Operator: OperatorExists},
+				{Key: "tier", Operator: OperatorIn, Values: []string{"debug"}},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesExpressions(labels, tt.requirements); got != tt.want {
+				t.Errorf("matchesExpressions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchSelectorMatchExpressions(t *testing.T) {
+	ns := &registry.NetworkService{
+		Name: "firewall",
+		Matches: []*registry.Match{
+			{
+				SourceMatchExpressions: []*registry.LabelSelectorRequirement{
+					{Key: "app", Operator: OperatorIn, Values: []string{"web", "api"}},
+				},
+				Routes: []*registry.Destination{
+					{
+						DestinationMatchExpressions: []*registry.LabelSelectorRequirement{
+							{Key: "version", Operator: OperatorIn, Values: []string{"v2", "v3"}},
+							{Key: "tier", Operator: OperatorNotIn, Values: []string{"debug"}},
+						},
+					},
+				},
+			},
+			{
+				Routes: []*registry.Destination{
+					{
+						DestinationMatchExpressions: []*registry.LabelSelectorRequirement{
+							{Key: "gpu", Operator: OperatorExists},
+						},
+					},
+				},
+			},
+		},
+	}
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "v1", Labels: map[string]string{"version": "v1"}},
+		{Name: "v2-debug", Labels: map[string]string{"version": "v2", "tier": "debug"}},
+		{Name: "v3", Labels: map[string]string{"version": "v3"}},
+		{Name: "gpu", Labels: map[string]string{"gpu": "true"}},
+	}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{
+			name:   "source expressions match",
+			labels: map[string]string{"app": "web"},
+			want:   "v3",
+		},
+		{
+			name:   "source expressions do not match",
+			labels: map[string]string{"app": "db"},
+			want:   "gpu",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := mustSelectEndpoint(t, NewMatchSelector(NewRegistry(nil)), &connection.Connection{Labels: tt.labels}, ns, endpoints)
+			if got.GetName() != tt.want {
+				t.Errorf("SelectEndpoint() = %v, want %v", got.GetName(), tt.want)
+			}
+		})
+	}
+}
@@ -16,10 +16,10 @@
 package selector
 
 import (
-	"bytes"
+	"strconv"
 	"sync"
-	"text/template"
 
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
@@ -28,96 +28,132 @@ import (
 
 type matchSelector struct {
 	sync.Mutex
-	roundRobin Selector
+	weighted   *weightedSelector
+	strategies *Registry
 }
 
-// NewMatchSelector creates a new
-func NewMatchSelector() Selector {
+// NewMatchSelector creates a new matchSelector, strategies are used to select one of the matched endpoints
+func NewMatchSelector(strategies *Registry) Selector {
 	return &matchSelector{
-		roundRobin: NewRoundRobinSelector(),
+		weighted:   newWeightedSelector(),
+		strategies: strategies,
 	}
 }
 
 // isSubset checks if B is a subset of A. TODO: reconsider this as a part of "tools"
-func isSubset(a, b, nsLabels map[string]string) bool {
+func isSubset(a, b, nsLabels map[string]string) (bool, error) {
 	if len(a) < len(b) {
-		return false
+		return false, nil
 	}
 	for k, v := range b {
 		if a[k] != v {
-			result := ProcessLabels(v, nsLabels)
+			result, err := ProcessLabels(v, nsLabels)
+			if err != nil {
+				return false, err
+			}
 			if a[k] != result {
-				return false
+				return false, nil
 			}
 		}
 	}
-	return true
+	return true, nil
 }
 
-func (m *matchSelector) matchEndpoint(nsLabels map[string]string, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+func (m *matchSelector) matchEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	nsLabels := requestConnection.GetLabels()
 	logrus.Infof("Matching endpoint for labels %v", nsLabels)
 
 	matchedNonEmptySelector := false
 	//Iterate through the matches
-	for _, match := range ns.GetMatches() {
+	for matchIdx, match := range ns.GetMatches() {
 		// All match source selector labels should be present in the requested labels map
-		if !isSubset(nsLabels, match.GetSourceSelector(), nsLabels) {
+		matched, err := isSubset(nsLabels, match.GetSourceSelector(), nsLabels)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to match source selector of the match %d", matchIdx)
+		}
+		if !matched {
+			continue
+		}
+
+		// Requested labels should satisfy all match source selector expressions
+		if !matchesExpressions(nsLabels, match.GetSourceMatchExpressions()) {
 			continue
 		}
 
+		emptySelector := len(match.GetSourceSelector()) == 0 && len(match.GetSourceMatchExpressions()) == 0
+
 		// If we already have matched any non empty selector we shouldn't match empty selector
-		if len(match.GetSourceSelector()) == 0 && matchedNonEmptySelector {
+		if emptySelector && matchedNonEmptySelector {
 			continue
 		}
 
-		if len(match.GetSourceSelector()) != 0 {
+		if !emptySelector {
 			matchedNonEmptySelector = true
 		}
 
-		nseCandidates := []*registry.NetworkServiceEndpoint{}
 		// Check all Destinations in that match
-		for _, destination := range match.GetRoutes() {
-			// Each NSE should be matched against that destination
-			for _, nse := range networkServiceEndpoints {
-				if isSubset(nse.GetLabels(), destination.GetDestinationSelector(), nsLabels) {
-					nseCandidates = append(nseCandidates, nse)
-				}
-			}
+		routes, err := matchRoutes(nsLabels, match.GetRoutes(), networkServiceEndpoints)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to match destination selector of the match %d", matchIdx)
+		}
+		nseCandidates := []*registry.NetworkServiceEndpoint{}
+		for _, route := range routes {
+			nseCandidates = append(nseCandidates, route.endpoints...)
+		}
+
+		if isWeighted(routes) && usesWeights(ns) {
+			// Routes have weights. Distribute between the routes proportionally to the weights
+			return m.weighted.selectEndpoint(ns.GetName()+"/"+strconv.Itoa(matchIdx), routes), nil
 		}
 
 		if len(nseCandidates) > 0 {
-			// We found candidates. Use RoundRobin to select one
-			return m.roundRobin.SelectEndpoint(nil, ns, nseCandidates)
+			// We found candidates. Use selection strategy of the Network Service to select one
+			return m.selectCandidate(requestConnection, ns, nseCandidates)
+		}
+	}
+	return nil, nil
+}
+
+// matchRoutes matches each NSE against every destination
+func matchRoutes(nsLabels map[string]string, destinations []*registry.Destination, networkServiceEndpoints []*registry.NetworkServiceEndpoint) ([]*routeCandidates, error) {
+	routes := make([]*routeCandidates, 0, len(destinations))
+	for _, destination := range destinations {
+		route := &routeCandidates{destination: destination}
+		for _, nse := range networkServiceEndpoints {
+			matched, err := isSubset(nse.GetLabels(), destination.GetDestinationSelector(), nsLabels)
+			if err != nil {
+				return nil, err
+			}
+			if matched && matchesExpressions(nse.GetLabels(), destination.GetDestinationMatchExpressions()) {
+				route.endpoints = append(route.endpoints, nse)
+			}
 		}
+		routes = append(routes, route)
 	}
-	return nil
+	return routes, nil
 }
 
-func (m *matchSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+func (m *matchSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
 	logrus.Infof("Selecting endpoint for %s with %d matches.", requestConnection.GetNetworkService(), len(ns.GetMatches()))
 	if len(ns.GetMatches()) == 0 {
-		return m.roundRobin.SelectEndpoint(nil, ns, networkServiceEndpoints)
+		return m.selectCandidate(requestConnection, ns, networkServiceEndpoints)
 	}
 
-	return m.matchEndpoint(requestConnection.GetLabels(), ns, networkServiceEndpoints)
+	return m.matchEndpoint(requestConnection, ns, networkServiceEndpoints)
 }
 
-// ProcessLabels generates matches based on destination label selectors that specify templating.
-func ProcessLabels(str string, vars interface{}) string {
-	tmpl, err := template.New("tmpl").Parse(str)
-
-	if err != nil {
-		panic(err)
-	}
-	return process(tmpl, vars)
+// usesWeights checks if route weights should be honored for the Network Service. Weights are ignored
+// when the Network Service explicitly requests another selection strategy
+func usesWeights(ns *registry.NetworkService) bool {
+	strategy := ns.GetSelectionStrategy()
+	return strategy == "" || strategy == Weighted
 }
 
-func process(t *template.Template, vars interface{}) string {
-	var tmplBytes bytes.Buffer
-
-	err := t.Execute(&tmplBytes, vars)
-	if err != nil {
-		panic(err)
+// selectCandidate selects one of the candidates using selection strategy requested by the Network Service
+func (m *matchSelector) selectCandidate(requestConnection *connection.Connection, ns *registry.NetworkService, candidates []*registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	strategy, ok := m.strategies.Get(ns.GetSelectionStrategy())
+	if !ok {
+		return nil, errors.Errorf("unknown selection strategy %q of the network service %s", ns.GetSelectionStrategy(), ns.GetName())
 	}
-	return tmplBytes.String()
+	return strategy.SelectEndpoint(requestConnection, ns, candidates)
 }
@@ -0,0 +1,76 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"sync"
+)
+
+// Names of the selection strategies
+const (
+	// RoundRobin selects endpoints one by one
+	RoundRobin = "round-robin"
+	// Weighted distributes connections between the routes proportionally to their weights
+	Weighted = "weighted"
+	// LeastConnections selects endpoint with the fewest active connections
+	LeastConnections = "least-connections"
+	// Random selects random endpoint
+	Random = "random"
+	// ConsistentHash keeps clients with the same affinity labels on the same endpoint
+	ConsistentHash = "consistent-hash"
+)
+
+// DefaultStrategy is used for the Network Services not requesting any selection strategy
+const DefaultStrategy = RoundRobin
+
+// Registry holds selectors keyed by the selection strategy name
+type Registry struct {
+	sync.RWMutex
+	selectors map[string]Selector
+}
+
+// NewRegistry creates a registry of all known selection strategies, counter is used by the least-connections strategy
+func NewRegistry(counter ConnectionCounter) *Registry {
+	r := &Registry{
+		selectors: make(map[string]Selector),
+	}
+	r.Register(RoundRobin, NewRoundRobinSelector())
+	// Endpoints of the same route and endpoints of the services without weighted routes are round robined
+	r.Register(Weighted, NewRoundRobinSelector())
+	r.Register(LeastConnections, NewLeastConnectionsSelector(counter))
+	r.Register(Random, NewRandomSelector())
+	r.Register(ConsistentHash, NewConsistentHashSelector())
+	return r
+}
+
+// Register registers selector for the strategy, selector registered before for the same strategy is replaced
+func (r *Registry) Register(strategy string, s Selector) {
+	r.Lock()
+	defer r.Unlock()
+	r.selectors[strategy] = s
+}
+
+// Get returns selector of the strategy, selector of the DefaultStrategy is returned for the empty strategy
+func (r *Registry) Get(strategy string) (Selector, bool) {
+	if strategy == "" {
+		strategy = DefaultStrategy
+	}
+	r.RLock()
+	defer r.RUnlock()
+	s, ok := r.selectors[strategy]
+	return s, ok
+}
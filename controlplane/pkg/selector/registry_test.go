@@ -0,0 +1,120 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+type fixedSelector string
+
+func (f fixedSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
+	for _, nse := range networkServiceEndpoints {
+		if nse.GetName() == string(f) {
+			return nse, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestRegistryGet(t *testing.T) {
+	r := NewRegistry(nil)
+	for _, strategy := range []string{"", RoundRobin, Weighted, LeastConnections, Random, ConsistentHash} {
+		if s, ok := r.Get(strategy); !ok || s == nil {
+			t.Errorf("Get(%q) = %v, %v, want registered selector", strategy, s, ok)
+		}
+	}
+	if _, ok := r.Get("unknown"); ok {
+		t.Errorf("Get(%q) should fail", "unknown")
+	}
+}
+
+func TestMatchSelectorCustomStrategy(t *testing.T) {
+	r := NewRegistry(nil)
+	r.Register("last", fixedSelector("nse3"))
+	s := NewMatchSelector(r)
+	ns := &registry.NetworkService{
+		Name:              "ns",
+		SelectionStrategy: "last",
+	}
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "nse1"},
+		{Name: "nse2"},
+		{Name: "nse3"},
+	}
+	for i := 0; i < 3; i++ {
+		if nse := mustSelectEndpoint(t, s, &connection.Connection{}, ns, endpoints); nse.GetName() != "nse3" {
+			t.Fatalf("SelectEndpoint() = %v, want nse3", nse.GetName())
+		}
+	}
+}
+
+func TestMatchSelectorUnknownStrategy(t *testing.T) {
+	ns := &registry.NetworkService{
+		Name:              "ns",
+		SelectionStrategy: "unknown",
+	}
+	endpoints := []*registry.NetworkServiceEndpoint{{Name: "nse1"}}
+	if _, err := NewMatchSelector(NewRegistry(nil)).SelectEndpoint(&connection.Connection{}, ns, endpoints); err == nil {
+		t.Errorf("SelectEndpoint() should fail for unknown selection strategy")
+	}
+}
+
+func TestMatchSelectorStrategyOverridesWeights(t *testing.T) {
+	counter := testConnectionCounter{"nse1": 3, "nse2": 0}
+	ns := &registry.NetworkService{
+		Name:              "ns",
+		SelectionStrategy: LeastConnections,
+		Matches: []*registry.Match{
+			{
+				Routes: []*registry.Destination{
+					{DestinationSelector: map[string]string{"version": "v1"}, Weight: 100},
+					{DestinationSelector: map[string]string{"version": "v2"}, Weight: 0},
+				},
+			},
+		},
+	}
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "nse1", Labels: map[string]string{"version": "v1"}},
+		{Name: "nse2", Labels: map[string]string{"version": "v2"}},
+	}
+	if nse := mustSelectEndpoint(t, NewMatchSelector(NewRegistry(counter)), &connection.Connection{}, ns, endpoints); nse.GetName() != "nse2" {
+		t.Errorf("SelectEndpoint() = %v, want nse2", nse.GetName())
+	}
+}
+
+func TestRandomSelector(t *testing.T) {
+	s := NewRandomSelector()
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "nse1"},
+		{Name: "nse2"},
+	}
+	selected := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		nse := mustSelectEndpoint(t, s, &connection.Connection{}, &registry.NetworkService{Name: "ns"}, endpoints)
+		selected[nse.GetName()] = true
+	}
+	if len(selected) != len(endpoints) {
+		t.Errorf("SelectEndpoint() selected %v, want all endpoints", selected)
+	}
+	if nse := mustSelectEndpoint(t, s, &connection.Connection{}, &registry.NetworkService{Name: "ns"}, nil); nse != nil {
+		t.Errorf("SelectEndpoint() = %v, want nil", nse)
+	}
+}
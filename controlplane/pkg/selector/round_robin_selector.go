@@ -15,26 +15,35 @@ type roundRobinSelector struct {
 }
 
 func NewRoundRobinSelector() Selector {
+	return newRoundRobinSelector()
+}
+
+func newRoundRobinSelector() *roundRobinSelector {
 	return &roundRobinSelector{
 		roundRobin: make(map[string]int),
 	}
 }
 
-func (rr *roundRobinSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+func (rr *roundRobinSelector) SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error) {
 	if rr == nil {
-		return nil
+		return nil, nil
 	}
+	return rr.selectByKey(ns.GetName(), networkServiceEndpoints), nil
+}
+
+// selectByKey selects next endpoint using separate round robin counter for every key
+func (rr *roundRobinSelector) selectByKey(key string, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
 	if len(networkServiceEndpoints) == 0 {
 		return nil
 	}
 	rr.Lock()
 	defer rr.Unlock()
-	idx := rr.roundRobin[ns.GetName()] % len(networkServiceEndpoints)
+	idx := rr.roundRobin[key] % len(networkServiceEndpoints)
 	endpoint := networkServiceEndpoints[idx]
 	if endpoint == nil {
 		return nil
 	}
-	rr.roundRobin[ns.GetName()] = rr.roundRobin[ns.GetName()] + 1
+	rr.roundRobin[key] = rr.roundRobin[key] + 1
 	logrus.Infof("RoundRobin selected %v", endpoint)
 	return endpoint
 }
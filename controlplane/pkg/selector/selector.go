@@ -6,5 +6,12 @@ import (
 )
 
 type Selector interface {
-	SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint
+	// SelectEndpoint selects one of the endpoints for the request, nil is returned if no endpoint could be selected
+	SelectEndpoint(requestConnection *connection.Connection, ns *registry.NetworkService, networkServiceEndpoints []*registry.NetworkServiceEndpoint) (*registry.NetworkServiceEndpoint, error)
+}
+
+// ConnectionCounter provides amount of active connections to the Network Service Endpoints
+type ConnectionCounter interface {
+	// ConnectionCounts returns a snapshot of active connections per endpoint name
+	ConnectionCounts() map[string]int
 }
@@ -0,0 +1,121 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+const templateLeftDelim = "{{"
+
+// templates caches compiled selector templates by their text
+var templates = &templateCache{
+	templates: make(map[string]*template.Template),
+}
+
+type templateCache struct {
+	sync.RWMutex
+	templates map[string]*template.Template
+}
+
+func (c *templateCache) compile(text string) (*template.Template, error) {
+	c.RLock()
+	tmpl, ok := c.templates[text]
+	c.RUnlock()
+	if ok {
+		return tmpl, nil
+	}
+
+	tmpl, err := template.New("tmpl").Parse(text)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid selector template %q", text)
+	}
+
+	c.Lock()
+	c.templates[text] = tmpl
+	c.Unlock()
+	return tmpl, nil
+}
+
+// ProcessLabels generates matches based on destination label selectors that specify templating. vars are the labels of
+// the client connection, see docs/spec/ns-endpoint-selection.md for the list of well known ones.
+func ProcessLabels(str string, vars interface{}) (string, error) {
+	if !strings.Contains(str, templateLeftDelim) {
+		return str, nil
+	}
+
+	tmpl, err := templates.compile(str)
+	if err != nil {
+		return "", err
+	}
+
+	var tmplBytes bytes.Buffer
+	if err = tmpl.Execute(&tmplBytes, vars); err != nil {
+		return "", errors.Wrapf(err, "failed to execute selector template %q", str)
+	}
+	return tmplBytes.String(), nil
+}
+
+// ValidateNetworkService checks that selectors of the Network Service are valid. Selector templates are compiled and
+// cached for the endpoint selection.
+func ValidateNetworkService(ns *registry.NetworkService) error {
+	for matchIdx, match := range ns.GetMatches() {
+		if err := validateSelector(match.GetSourceSelector(), match.GetSourceMatchExpressions()); err != nil {
+			return errors.Wrapf(err, "network service %s: invalid source selector of the match %d", ns.GetName(), matchIdx)
+		}
+		for routeIdx, route := range match.GetRoutes() {
+			if err := validateSelector(route.GetDestinationSelector(), route.GetDestinationMatchExpressions()); err != nil {
+				return errors.Wrapf(err, "network service %s: invalid destination selector of the route %d of the match %d",
+					ns.GetName(), routeIdx, matchIdx)
+			}
+		}
+	}
+	return nil
+}
+
+func validateSelector(selector map[string]string, requirements []*registry.LabelSelectorRequirement) error {
+	for _, value := range selector {
+		if !strings.Contains(value, templateLeftDelim) {
+			continue
+		}
+		if _, err := templates.compile(value); err != nil {
+			return err
+		}
+	}
+	for _, requirement := range requirements {
+		switch requirement.GetOperator() {
+		case OperatorIn, OperatorNotIn:
+			if len(requirement.GetValues()) == 0 {
+				return errors.Errorf("operator %s requires values for the key %q", requirement.GetOperator(), requirement.GetKey())
+			}
+		case OperatorExists, OperatorDoesNotExist:
+			if len(requirement.GetValues()) != 0 {
+				return errors.Errorf("operator %s does not allow values for the key %q", requirement.GetOperator(), requirement.GetKey())
+			}
+		default:
+			return errors.Errorf("unknown operator %q for the key %q", requirement.GetOperator(), requirement.GetKey())
+		}
+	}
+	return nil
+}
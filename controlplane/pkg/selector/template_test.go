@@ -0,0 +1,148 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func TestProcessLabels(t *testing.T) {
+	vars := map[string]string{
+		"app":      "firewall",
+		"nodeName": "node1",
+	}
+	tests := []struct {
+		name    string
+		str     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "plain value",
+			str:  "firewall",
+			want: "firewall",
+		},
+		{
+			name: "label template",
+			str:  `{{index . "nodeName"}}`,
+			want: "node1",
+		},
+		{
+			name: "missing label",
+			str:  `{{index . "namespace"}}`,
+			want: "",
+		},
+		{
+			name:    "invalid template",
+			str:     `{{index . "app"`,
+			wantErr: true,
+		},
+		{
+			name:    "failing template",
+			str:     `{{index . 1}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ProcessLabels(tt.str, vars)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProcessLabels() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ProcessLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func templateNetworkService(destinationSelector map[string]string, requirements ...*registry.LabelSelectorRequirement) *registry.NetworkService {
+	return &registry.NetworkService{
+		Name: "firewall",
+		Matches: []*registry.Match{
+			{
+				Routes: []*registry.Destination{
+					{
+						DestinationSelector:         destinationSelector,
+						DestinationMatchExpressions: requirements,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestValidateNetworkService(t *testing.T) {
+	tests := []struct {
+		name    string
+		ns      *registry.NetworkService
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			ns: templateNetworkService(map[string]string{"nodeName": `{{index . "nodeName"}}`},
+				&registry.LabelSelectorRequirement{Key: "version", Operator: OperatorIn, Values: []string{"v1"}}),
+		},
+		{
+			name:    "invalid template",
+			ns:      templateNetworkService(map[string]string{"nodeName": `{{index . "nodeName"`}),
+			wantErr: true,
+		},
+		{
+			name: "unknown operator",
+			ns: templateNetworkService(nil,
+				&registry.LabelSelectorRequirement{Key: "version", Operator: "Gt", Values: []string{"v1"}}),
+			wantErr: true,
+		},
+		{
+			name: "in without values",
+			ns: templateNetworkService(nil,
+				&registry.LabelSelectorRequirement{Key: "version", Operator: OperatorIn}),
+			wantErr: true,
+		},
+		{
+			name: "exists with values",
+			ns: templateNetworkService(nil,
+				&registry.LabelSelectorRequirement{Key: "version", Operator: OperatorExists, Values: []string{"v1"}}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateNetworkService(tt.ns); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateNetworkService() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchSelectorInvalidTemplate(t *testing.T) {
+	ns := templateNetworkService(map[string]string{"app": `{{index . "app"`})
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "nse1", Labels: map[string]string{"app": "vpn"}},
+	}
+
+	nse, err := NewMatchSelector(NewRegistry(nil)).SelectEndpoint(&connection.Connection{}, ns, endpoints)
+	if err == nil {
+		t.Errorf("SelectEndpoint() = %v, want error", nse)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+const (
+	// TopologyKeyHostname matches endpoints registered by the local Network Service Manager
+	TopologyKeyHostname = "kubernetes.io/hostname"
+	// TopologyKeyAny matches any endpoint
+	TopologyKeyAny = "*"
+)
+
+// SplitByTopology splits endpoints into groups in order of preference defined by topologyKeys. Every key produces a group
+// of endpoints registered by the Network Service Managers having the same value of the label as the local one.
+// TopologyKeyHostname produces a group of endpoints registered by the local Network Service Manager and TopologyKeyAny
+// produces a group of all endpoints. If topologyKeys are empty, a single group of all endpoints is returned.
+func SplitByTopology(topologyKeys []string, localNsm *registry.NetworkServiceManager, nsms map[string]*registry.NetworkServiceManager,
+	endpoints []*registry.NetworkServiceEndpoint) [][]*registry.NetworkServiceEndpoint {
+	if len(topologyKeys) == 0 {
+		return [][]*registry.NetworkServiceEndpoint{endpoints}
+	}
+
+	var groups [][]*registry.NetworkServiceEndpoint
+	for _, key := range topologyKeys {
+		if key == TopologyKeyAny {
+			groups = append(groups, endpoints)
+			// No other key could produce more endpoints
+			break
+		}
+
+		var group []*registry.NetworkServiceEndpoint
+		for _, nse := range endpoints {
+			if topologyMatches(key, localNsm, nsms[nse.GetNetworkServiceManagerName()], nse) {
+				group = append(group, nse)
+			}
+		}
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+func topologyMatches(key string, localNsm, nsm *registry.NetworkServiceManager, nse *registry.NetworkServiceEndpoint) bool {
+	if localNsm.GetName() != "" && nse.GetNetworkServiceManagerName() == localNsm.GetName() {
+		// Local endpoints have the same topology as the local Network Service Manager
+		return true
+	}
+	if key == TopologyKeyHostname {
+		return false
+	}
+	value, ok := localNsm.GetLabels()[key]
+	if !ok {
+		return false
+	}
+	remoteValue, ok := nsm.GetLabels()[key]
+	return ok && value == remoteValue
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+const zoneKey = "topology.kubernetes.io/zone"
+
+func topologyNames(groups [][]*registry.NetworkServiceEndpoint) [][]string {
+	result := [][]string{}
+	for _, group := range groups {
+		names := []string{}
+		for _, nse := range group {
+			names = append(names, nse.GetName())
+		}
+		result = append(result, names)
+	}
+	return result
+}
+
+func TestSplitByTopology(t *testing.T) {
+	localNsm := &registry.NetworkServiceManager{
+		Name:   "node1",
+		Labels: map[string]string{zoneKey: "zone-a"},
+	}
+	nsms := map[string]*registry.NetworkServiceManager{
+		"node1": localNsm,
+		"node2": {Name: "node2", Labels: map[string]string{zoneKey: "zone-a"}},
+		"node3": {Name: "node3", Labels: map[string]string{zoneKey: "zone-b"}},
+		"node4": {Name: "node4"},
+	}
+	endpoints := []*registry.NetworkServiceEndpoint{
+		{Name: "nse2", NetworkServiceManagerName: "node2"},
+		{Name: "nse3", NetworkServiceManagerName: "node3"},
+		{Name: "nse1", NetworkServiceManagerName: "node1"},
+		{Name: "nse4", NetworkServiceManagerName: "node4"},
+	}
+
+	tests := []struct {
+		name         string
+		topologyKeys []string
+		localNsm     *registry.NetworkServiceManager
+		want         [][]string
+	}{
+		{
+			name: "no topology keys",
+			want: [][]string{{"nse2", "nse3", "nse1", "nse4"}},
+		},
+		{
+			name:         "hostname, zone, any",
+			topologyKeys: []string{TopologyKeyHostname, zoneKey, TopologyKeyAny},
+			localNsm:     localNsm,
+			want:         [][]string{{"nse1"}, {"nse2", "nse1"}, {"nse2", "nse3", "nse1", "nse4"}},
+		},
+		{
+			name:         "zone only",
+			topologyKeys: []string{zoneKey},
+			localNsm:     localNsm,
+			want:         [][]string{{"nse2", "nse1"}},
+		},
+		{
+			name:         "local nsm without zone",
+			topologyKeys: []string{zoneKey, TopologyKeyAny},
+			localNsm:     nsms["node4"],
+			want:         [][]string{{"nse4"}, {"nse2", "nse3", "nse1", "nse4"}},
+		},
+		{
+			name:         "no matching endpoints",
+			topologyKeys: []string{TopologyKeyHostname},
+			localNsm:     &registry.NetworkServiceManager{Name: "node5"},
+			want:         [][]string{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := topologyNames(SplitByTopology(tt.topologyKeys, tt.localNsm, nsms, endpoints))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitByTopology() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+// routeCandidates is a Match route with all the endpoints matching its destination selector
+type routeCandidates struct {
+	destination *registry.Destination
+	endpoints   []*registry.NetworkServiceEndpoint
+}
+
+// weightedSelector distributes selections across the routes of a Match proportionally to
+// their weights. It uses smooth weighted round robin, so the distribution is deterministic:
+// routes with weights 90 and 10 get exactly 9 of every 10 selections and 1 of every 10.
+type weightedSelector struct {
+	sync.Mutex
+	currentWeights map[string][]int64
+	roundRobin     *roundRobinSelector
+}
+
+func newWeightedSelector() *weightedSelector {
+	return &weightedSelector{
+		currentWeights: make(map[string][]int64),
+		roundRobin:     newRoundRobinSelector(),
+	}
+}
+
+// isWeighted returns true if any of the routes having candidates has non zero weight
+func isWeighted(routes []*routeCandidates) bool {
+	for _, route := range routes {
+		if len(route.endpoints) > 0 && route.destination.GetWeight() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// selectEndpoint selects a route by weight and then an endpoint of the route using round robin.
+// key identifies a Match, routes are all the routes of the Match in their original order.
+func (ws *weightedSelector) selectEndpoint(key string, routes []*routeCandidates) *registry.NetworkServiceEndpoint {
+	idx := ws.selectRoute(key, routes)
+	if idx < 0 {
+		return nil
+	}
+	endpoint := ws.roundRobin.selectByKey(fmt.Sprintf("%s/%d", key, idx), routes[idx].endpoints)
+	logrus.Infof("Weighted selector selected route %d with weight %d", idx, routes[idx].destination.GetWeight())
+	return endpoint
+}
+
+// selectRoute returns index of the next route, routes without candidates or weight are skipped
+func (ws *weightedSelector) selectRoute(key string, routes []*routeCandidates) int {
+	ws.Lock()
+	defer ws.Unlock()
+
+	current := ws.currentWeights[key]
+	if len(current) != len(routes) {
+		// Network Service has been changed, start over
+		current = make([]int64, len(routes))
+		ws.currentWeights[key] = current
+	}
+
+	var total int64
+	selected := -1
+	for i, route := range routes {
+		weight := int64(route.destination.GetWeight())
+		if weight == 0 || len(route.endpoints) == 0 {
+			continue
+		}
+		current[i] += weight
+		total += weight
+		if selected < 0 || current[i] > current[selected] {
+			selected = i
+		}
+	}
+	if selected >= 0 {
+		current[selected] -= total
+	}
+	return selected
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+)
+
+func weightedNetworkService(weights map[string]uint32, versions ...string) *registry.NetworkService {
+	routes := []*registry.Destination{}
+	for _, version := range versions {
+		routes = append(routes, &registry.Destination{
+			DestinationSelector: map[string]string{
+				"version": version,
+			},
+			Weight: weights[version],
+		})
+	}
+	return &registry.NetworkService{
+		Name: "firewall",
+		Matches: []*registry.Match{
+			{
+				Routes: routes,
+			},
+		},
+	}
+}
+
+func versionedEndpoint(name, version string) *registry.NetworkServiceEndpoint {
+	return &registry.NetworkServiceEndpoint{
+		Name: name,
+		Labels: map[string]string{
+			"version": version,
+		},
+	}
+}
+
+func mustSelectEndpoint(t *testing.T, s Selector, requestConnection *connection.Connection, ns *registry.NetworkService, endpoints []*registry.NetworkServiceEndpoint) *registry.NetworkServiceEndpoint {
+	t.Helper()
+	nse, err := s.SelectEndpoint(requestConnection, ns, endpoints)
+	if err != nil {
+		t.Fatalf("SelectEndpoint() error = %v", err)
+	}
+	return nse
+}
+
+func countVersions(t *testing.T, s Selector, ns *registry.NetworkService, endpoints []*registry.NetworkServiceEndpoint, requests int) map[string]int {
+	counts := map[string]int{}
+	for i := 0; i < requests; i++ {
+		nse := mustSelectEndpoint(t, s, &connection.Connection{}, ns, endpoints)
+		if nse == nil {
+			counts[""]++
+			continue
+		}
+		counts[nse.GetLabels()["version"]]++
+	}
+	return counts
+}
+
+func Test_weightedSelector_Distribution(t *testing.T) {
+	endpoints := []*registry.NetworkServiceEndpoint{
+		versionedEndpoint("firewall-v1-1", "v1"),
+		versionedEndpoint("firewall-v1-2", "v1"),
+		versionedEndpoint("firewall-v2-1", "v2"),
+		versionedEndpoint("firewall-v3-1", "v3"),
+	}
+	tests := []struct {
+		name     string
+		ns       *registry.NetworkService
+		requests int
+		want     map[string]int
+	}{
+		{
+			name:     "canary 90/10",
+			ns:       weightedNetworkService(map[string]uint32{"v1": 90, "v2": 10}, "v1", "v2"),
+			requests: 1000,
+			want:     map[string]int{"v1": 900, "v2": 100},
+		},
+		{
+			name:     "three routes 50/30/20",
+			ns:       weightedNetworkService(map[string]uint32{"v1": 50, "v2": 30, "v3": 20}, "v1", "v2", "v3"),
+			requests: 1000,
+			want:     map[string]int{"v1": 500, "v2": 300, "v3": 200},
+		},
+		{
+			name:     "route without endpoints",
+			ns:       weightedNetworkService(map[string]uint32{"v1": 80, "v4": 20}, "v1", "v4"),
+			requests: 100,
+			want:     map[string]int{"v1": 100},
+		},
+		{
+			name:     "route without weight",
+			ns:       weightedNetworkService(map[string]uint32{"v1": 1}, "v1", "v2"),
+			requests: 100,
+			want:     map[string]int{"v1": 100},
+		},
+		{
+			name:     "no weights fallback to round robin",
+			ns:       weightedNetworkService(nil, "v1", "v2"),
+			requests: 300,
+			want:     map[string]int{"v1": 200, "v2": 100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countVersions(t, NewMatchSelector(NewRegistry(nil)), tt.ns, endpoints, tt.requests); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("weightedSelector distribution = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_weightedSelector_SmoothDistribution(t *testing.T) {
+	endpoints := []*registry.NetworkServiceEndpoint{
+		versionedEndpoint("firewall-v1-1", "v1"),
+		versionedEndpoint("firewall-v2-1", "v2"),
+	}
+	ns := weightedNetworkService(map[string]uint32{"v1": 90, "v2": 10}, "v1", "v2")
+	s := NewMatchSelector(NewRegistry(nil))
+
+	// Every window of 10 requests should get exactly one canary endpoint
+	for window := 0; window < 100; window++ {
+		want := map[string]int{"v1": 9, "v2": 1}
+		if got := countVersions(t, s, ns, endpoints, 10); !reflect.DeepEqual(got, want) {
+			t.Fatalf("weightedSelector window %d distribution = %v, want %v", window, got, want)
+		}
+	}
+}
+
+func Test_weightedSelector_RoundRobinWithinRoute(t *testing.T) {
+	endpoints := []*registry.NetworkServiceEndpoint{
+		versionedEndpoint("firewall-v1-1", "v1"),
+		versionedEndpoint("firewall-v1-2", "v1"),
+		versionedEndpoint("firewall-v2-1", "v2"),
+	}
+	ns := weightedNetworkService(map[string]uint32{"v1": 1, "v2": 1}, "v1", "v2")
+	s := NewMatchSelector(NewRegistry(nil))
+
+	counts := map[string]int{}
+	for i := 0; i < 400; i++ {
+		counts[mustSelectEndpoint(t, s, &connection.Connection{}, ns, endpoints).GetName()]++
+	}
+	want := map[string]int{"firewall-v1-1": 100, "firewall-v1-2": 100, "firewall-v2-1": 200}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("weightedSelector endpoints distribution = %v, want %v", counts, want)
+	}
+}
@@ -13,8 +13,10 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/authz"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/wgport"
 	forwarderapi "github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarder"
 )
 
@@ -44,6 +46,8 @@ type ServiceRegistry interface {
 
 	VniAllocator() vni.VniAllocator
 	SIDAllocator() sid.Allocator
+	WireguardPortAllocator() wgport.Allocator
+	Authorizer() authz.Authorizer
 
 	NewWorkspaceProvider() WorkspaceLocationProvider
 }
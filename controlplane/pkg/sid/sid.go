@@ -14,65 +14,165 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package sid - generate unique sid for connection (fd25::<connectionId>:<nextIndex>)
+// Package sid - allocates SRv6 SIDs for the connections from the locator prefix of the node. There is no default
+// locator: it should be unique for every node, otherwise the connections of different nodes get the same SIDs
 package sid
 
 import (
 	"encoding/binary"
-	"fmt"
+	"math"
 	"net"
 	"sync"
 
-	"github.com/sirupsen/logrus"
+	"github.com/pkg/errors"
 )
 
-// SIDPrefix - subnet for generated sid's
-const SIDPrefix = "fd25::"
+const (
+	// minLocatorSize - locators shorter than /64 are not supported, function part of the SID fits into uint64
+	minLocatorSize = 64
+	// maxLocatorSize - locator should leave some space for the SIDs
+	maxLocatorSize = 120
+	// firstFunction - the first allocated function, ::0 and ::1 are reserved
+	firstFunction = 2
+)
 
-// Allocator - generating unique SID for connection
+// Allocator - allocates unique SIDs for the connections
 type Allocator interface {
-	SID(requestID string) string
-	Restore(hardwareAddr, sid string)
+	// SIDs - allocates binding SID and local SID for the connection, the same SIDs are returned for the connection
+	// until they are released
+	SIDs(connectionID string) (bsid, localSID string, err error)
+	// Release - releases all SIDs allocated for the connection
+	Release(connectionID string)
+	// Restore - marks SID as allocated for the connection, fails if the SID is allocated for another connection
+	// or does not belong to the locator
+	Restore(connectionID, sid string) error
 }
 
 type sidAllocator struct {
-	lastSID map[string]uint32
 	sync.Mutex
+	locator      *net.IPNet
+	maxFunction  uint64
+	lastFunction uint64
+	owners       map[uint64]string
+	byConnection map[string][]uint64
 }
 
-// NewSIDAllocator - creates sid allocator
-func NewSIDAllocator() Allocator {
-	return &sidAllocator{
-		lastSID: make(map[string]uint32),
+// NewSIDAllocatorWithLocator - creates sid allocator for the locator prefix, e.g. fd25:0:0:1::/64
+func NewSIDAllocatorWithLocator(locator string) (Allocator, error) {
+	_, ipNet, err := net.ParseCIDR(locator)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid SRv6 locator %s", locator)
+	}
+	ones, bits := ipNet.Mask.Size()
+	if bits != net.IPv6len*8 || ones < minLocatorSize || ones > maxLocatorSize {
+		return nil, errors.Errorf("invalid SRv6 locator %s: IPv6 prefix of /%d-/%d length expected", locator, minLocatorSize, maxLocatorSize)
 	}
+	maxFunction := uint64(math.MaxUint64)
+	if hostBits := uint(bits - ones); hostBits < 64 {
+		maxFunction = 1<<hostBits - 1
+	}
+	return &sidAllocator{
+		locator:      ipNet,
+		maxFunction:  maxFunction,
+		owners:       make(map[uint64]string),
+		byConnection: make(map[string][]uint64),
+	}, nil
 }
 
-// SID - Allocate a new SID for SRv6 Policy
-func (a *sidAllocator) SID(requestID string) string {
-	lastSID := a.lastSID[requestID] + 1
-	if lastSID < 2 {
-		lastSID = 2
+// SIDs - returns the first two SIDs allocated or restored for the connection, missing ones are allocated
+func (a *sidAllocator) SIDs(connectionID string) (bsid, localSID string, err error) {
+	a.Lock()
+	defer a.Unlock()
+
+	for len(a.byConnection[connectionID]) < 2 {
+		function, nextErr := a.next()
+		if nextErr != nil {
+			return "", "", nextErr
+		}
+		a.add(connectionID, function)
 	}
+	functions := a.byConnection[connectionID]
+	return a.toSID(functions[0]).String(), a.toSID(functions[1]).String(), nil
+}
 
-	a.lastSID[requestID] = lastSID
-	return fmt.Sprintf("%s:%x", transformRequestID(requestID), lastSID)
+// next - finds the next free function of the locator
+func (a *sidAllocator) next() (uint64, error) {
+	function := a.lastFunction
+	for i := uint64(0); i <= a.maxFunction-firstFunction; i++ {
+		if function < firstFunction || function >= a.maxFunction {
+			function = firstFunction
+		} else {
+			function++
+		}
+		if _, used := a.owners[function]; !used {
+			a.lastFunction = function
+			return function, nil
+		}
+	}
+	return 0, errors.Errorf("no free SID left in the locator %s", a.locator)
 }
 
-// Restore value of last SID based on connections we have at the moment
-func (a *sidAllocator) Restore(requestID, sid string) {
-	parsedSID := net.ParseIP(sid)
-	intSID := binary.BigEndian.Uint16(parsedSID[len(parsedSID)-2:])
-	a.lastSID[requestID] = uint32(intSID)
+// Release - releases all SIDs allocated for the connection
+func (a *sidAllocator) Release(connectionID string) {
+	a.Lock()
+	defer a.Unlock()
+
+	for _, function := range a.byConnection[connectionID] {
+		delete(a.owners, function)
+	}
+	delete(a.byConnection, connectionID)
 }
 
-func transformRequestID(requestID string) string {
-	sid := requestID
-	for i := 0; i < len(requestID)/4; i++ {
-		idx := len(requestID) - (i+1)*4
-		sid = fmt.Sprintf("%s:%s", sid[:idx], sid[idx:])
+// Restore - restores SID allocated for the connection based on cross connects we have at the moment
+func (a *sidAllocator) Restore(connectionID, sid string) error {
+	a.Lock()
+	defer a.Unlock()
+
+	ip := net.ParseIP(sid)
+	if ip == nil || ip.To4() != nil || !a.locator.Contains(ip) {
+		return errors.Errorf("SID %s does not belong to the locator %s", sid, a.locator)
+	}
+	function := binary.BigEndian.Uint64(ip[8:]) & a.maxFunction
+	if owner, used := a.owners[function]; used {
+		if owner == connectionID {
+			return nil
+		}
+		return errors.Errorf("SID %s is restored for connection %s, but allocated for connection %s", sid, connectionID, owner)
 	}
+	a.add(connectionID, function)
+	if function > a.lastFunction {
+		a.lastFunction = function
+	}
+	return nil
+}
+
+func (a *sidAllocator) add(connectionID string, function uint64) {
+	a.owners[function] = connectionID
+	a.byConnection[connectionID] = append(a.byConnection[connectionID], function)
+}
+
+func (a *sidAllocator) toSID(function uint64) net.IP {
+	sid := make(net.IP, net.IPv6len)
+	copy(sid, a.locator.IP.To16())
+	binary.BigEndian.PutUint64(sid[8:], binary.BigEndian.Uint64(sid[8:])|function)
+	return sid
+}
+
+// disabledSIDAllocator - refuses to allocate SIDs on the node without a locator
+type disabledSIDAllocator struct{}
+
+// NewDisabledSIDAllocator - creates sid allocator refusing to allocate and restore SIDs, it is used if no locator is
+// configured for the node, so the SRv6 mechanism is not offered
+func NewDisabledSIDAllocator() Allocator {
+	return disabledSIDAllocator{}
+}
+
+func (disabledSIDAllocator) SIDs(string) (bsid, localSID string, err error) {
+	return "", "", errors.New("SRv6 locator of the node is not configured")
+}
 
-	logrus.Printf("Generated IP: %v %v", fmt.Sprintf("%s%s", SIDPrefix, sid), net.ParseIP(fmt.Sprintf("%s%s", SIDPrefix, sid)).String())
+func (disabledSIDAllocator) Release(string) {}
 
-	return net.ParseIP(fmt.Sprintf("%s%s", SIDPrefix, sid)).String()
+func (disabledSIDAllocator) Restore(_, sid string) error {
+	return errors.Errorf("SID %s can't be restored, SRv6 locator of the node is not configured", sid)
 }
@@ -0,0 +1,120 @@
+// Copyright (c) 2020 Cisco and/or its affiliates.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sid
+
+import (
+	"strconv"
+	"testing"
+)
+
+func mustSIDs(t *testing.T, a Allocator, connectionID string) (bsid, localSID string) {
+	t.Helper()
+	bsid, localSID, err := a.SIDs(connectionID)
+	if err != nil {
+		t.Fatalf("SIDs() failed: %v", err)
+	}
+	return bsid, localSID
+}
+
+func TestNewSIDAllocatorWithLocator(t *testing.T) {
+	for _, locator := range []string{"fd25::/64", "fd25:0:0:1::/80", "fd25::/120"} {
+		if _, err := NewSIDAllocatorWithLocator(locator); err != nil {
+			t.Errorf("NewSIDAllocatorWithLocator(%q) failed: %v", locator, err)
+		}
+	}
+	for _, locator := range []string{"fd25::", "10.0.0.0/8", "fd25::/48", "fd25::/124"} {
+		if _, err := NewSIDAllocatorWithLocator(locator); err == nil {
+			t.Errorf("NewSIDAllocatorWithLocator(%q) should fail", locator)
+		}
+	}
+}
+
+func TestSIDs(t *testing.T) {
+	a, err := NewSIDAllocatorWithLocator("fd25:0:0:1::/64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if bsid, localSID := mustSIDs(t, a, "1"); bsid != "fd25:0:0:1::2" || localSID != "fd25:0:0:1::3" {
+			t.Errorf("SIDs() = %v, %v, want the same fd25:0:0:1::2, fd25:0:0:1::3", bsid, localSID)
+		}
+	}
+	a.Release("1")
+	// Allocation goes on from the last allocated SID
+	if bsid, localSID := mustSIDs(t, a, "2"); bsid != "fd25:0:0:1::4" || localSID != "fd25:0:0:1::5" {
+		t.Errorf("SIDs() = %v, %v, want fd25:0:0:1::4, fd25:0:0:1::5", bsid, localSID)
+	}
+}
+
+func TestSIDsExhausted(t *testing.T) {
+	a, err := NewSIDAllocatorWithLocator("fd25::/120")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := firstFunction; i <= 0xff; i += 2 {
+		mustSIDs(t, a, strconv.Itoa(int(i)))
+	}
+	if bsid, localSID, sidErr := a.SIDs("new"); sidErr == nil {
+		t.Errorf("SIDs() = %v, %v, want error", bsid, localSID)
+	}
+	a.Release(strconv.Itoa(firstFunction))
+	if bsid, localSID := mustSIDs(t, a, "new"); bsid != "fd25::2" || localSID != "fd25::3" {
+		t.Errorf("SIDs() = %v, %v, want fd25::2, fd25::3 after wrap", bsid, localSID)
+	}
+}
+
+func TestSIDRestore(t *testing.T) {
+	a, err := NewSIDAllocatorWithLocator("fd25::/64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = a.Restore("1", "fd25::10"); err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if err := a.Restore("1", "fd25::10"); err != nil {
+		t.Errorf("Restore() failed for the same connection: %v", err)
+	}
+	if err := a.Restore("2", "fd25::10"); err == nil {
+		t.Errorf("Restore() should detect collision")
+	}
+	if err := a.Restore("2", "fd26::10"); err == nil {
+		t.Errorf("Restore() should fail for SID out of the locator")
+	}
+	if err := a.Restore("2", "invalid"); err == nil {
+		t.Errorf("Restore() should fail for invalid SID")
+	}
+	if err := a.Restore("3", "fd25::20"); err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if bsid, localSID := mustSIDs(t, a, "3"); bsid != "fd25::20" || localSID != "fd25::21" {
+		t.Errorf("SIDs() = %v, %v, want restored fd25::20 and fd25::21 allocated after it", bsid, localSID)
+	}
+	a.Release("1")
+	if err := a.Restore("2", "fd25::10"); err != nil {
+		t.Errorf("Restore() failed for released SID: %v", err)
+	}
+}
+
+func TestDisabledSIDAllocator(t *testing.T) {
+	a := NewDisabledSIDAllocator()
+	if bsid, localSID, err := a.SIDs("1"); err == nil {
+		t.Errorf("SIDs() = %v, %v, want error without locator", bsid, localSID)
+	}
+	if err := a.Restore("1", "fd25::10"); err == nil {
+		t.Errorf("Restore() should fail without locator")
+	}
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
@@ -31,12 +32,14 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/nsmdapi"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 	nsm2 "github.com/networkservicemesh/networkservicemesh/controlplane/pkg/api/nsm"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/authz"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/model"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsm"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/sid"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/vni"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/wgport"
 	"github.com/networkservicemesh/networkservicemesh/forwarder/api/forwarder"
 	"github.com/networkservicemesh/networkservicemesh/pkg/probes"
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
@@ -105,6 +108,19 @@ func (impl *nsmdTestServiceDiscovery) RemoveNSE(ctx context.Context, in *registr
 	return nil, nil
 }
 
+func (impl *nsmdTestServiceDiscovery) DrainNSE(ctx context.Context, in *registry.DrainNSERequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	impl.storage.Lock()
+	defer impl.storage.Unlock()
+	endpoint, ok := impl.storage.endpoints[in.GetNetworkServiceEndpointName()]
+	if !ok {
+		return nil, errors.Errorf("endpoint %v is not found", in.GetNetworkServiceEndpointName())
+	}
+	endpoint = proto.Clone(endpoint).(*registry.NetworkServiceEndpoint)
+	endpoint.State = registry.EndpointStatePaused
+	impl.storage.endpoints[in.GetNetworkServiceEndpointName()] = endpoint
+	return &empty.Empty{}, nil
+}
+
 func newNSMDTestServiceDiscovery(testAPI *testApiRegistry, nsmgrName string, storage *sharedStorage) *nsmdTestServiceDiscovery {
 	return &nsmdTestServiceDiscovery{
 		storage:     storage,
@@ -140,6 +156,10 @@ func (impl *nsmdTestServiceDiscovery) FindNetworkService(ctx context.Context, in
 	}, nil
 }
 
+func (impl *nsmdTestServiceDiscovery) WatchNetworkService(ctx context.Context, in *registry.FindNetworkServiceRequest, opts ...grpc.CallOption) (registry.NetworkServiceDiscovery_WatchNetworkServiceClient, error) {
+	return nil, errors.Errorf("not implemented")
+}
+
 func (impl *nsmdTestServiceDiscovery) RegisterNSM(ctx context.Context, in *registry.NetworkServiceManager, opts ...grpc.CallOption) (*registry.NetworkServiceManager, error) {
 	logrus.Infof("Register NSM: %v", in)
 	in.Name = impl.nsmgrName
@@ -166,6 +186,8 @@ type nsmdTestServiceRegistry struct {
 	localTestNSE            networkservice.NetworkServiceClient
 	vniAllocator            vni.VniAllocator
 	sidAllocator            sid.Allocator
+	wgPortAllocator         wgport.Allocator
+	authorizer              authz.Authorizer
 	rootDir                 string
 }
 
@@ -173,6 +195,14 @@ func (impl *nsmdTestServiceRegistry) SIDAllocator() sid.Allocator {
 	return impl.sidAllocator
 }
 
+func (impl *nsmdTestServiceRegistry) WireguardPortAllocator() wgport.Allocator {
+	return impl.wgPortAllocator
+}
+
+func (impl *nsmdTestServiceRegistry) Authorizer() authz.Authorizer {
+	return impl.authorizer
+}
+
 func (impl *nsmdTestServiceRegistry) VniAllocator() vni.VniAllocator {
 	return impl.vniAllocator
 }
@@ -528,6 +558,10 @@ func newNSMDFullServerAt(ctx context.Context, nsmgrName string, storage *sharedS
 	if err != nil {
 		panic(err)
 	}
+	sidAllocator, err := sid.NewSIDAllocatorWithLocator("fd25::/64")
+	if err != nil {
+		panic(err)
+	}
 	srv.serviceRegistry = &nsmdTestServiceRegistry{
 		nseRegistry:             srv.nseRegistry,
 		apiRegistry:             srv.apiRegistry,
@@ -536,8 +570,11 @@ func newNSMDFullServerAt(ctx context.Context, nsmgrName string, storage *sharedS
 			prefixPool:           prefixPool,
 			requestHandleCounter: 0,
 		},
-		vniAllocator: vni.NewVniAllocator(),
-		rootDir:      rootDir,
+		vniAllocator:    vni.NewVniAllocator(),
+		sidAllocator:    sidAllocator,
+		wgPortAllocator: wgport.NewPortAllocator(),
+		authorizer:      authz.NewPolicyAuthorizer(nil),
+		rootDir:         rootDir,
 	}
 
 	srv.TestModel = testModel
@@ -12,51 +12,278 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
+// Package vni - allocates VXLAN network identifiers for the connections
 package vni
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"net"
+	"os"
+	"sort"
 	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
 )
 
+const (
+	// MaxVni - the largest VNI in the 24-bit VXLAN network identifier space
+	MaxVni = 1<<24 - 1
+	// firstOddVni and firstEvenVni are the first VNIs allocated for the pair
+	firstOddVni  = 3
+	firstEvenVni = 2
+)
+
+// VniAllocator - allocates VNIs unique for every (local IP, remote IP) pair.
+// VNI is odd if local IP < remote IP and even otherwise, so both sides of the tunnel never allocate the same VNI.
 type VniAllocator interface {
-	Vni(local_ip string, remote_ip string) uint32
-	Restore(local_ip string, remote_ip string, vniId uint32)
+	// Vni - allocates VNI for the connection, the same VNI is returned for the connection until it is released
+	Vni(connectionID, localIP, remoteIP string) (uint32, error)
+	// Release - releases VNI allocated for the connection
+	Release(connectionID string)
+	// Restore - marks VNI as allocated for the connection, fails if the VNI is allocated for another connection
+	Restore(connectionID, localIP, remoteIP string, vni uint32) error
+	// ConnectionIDs - returns connections having VNI allocated, including ones loaded from the persisted state
+	ConnectionIDs() []string
+}
+
+type pair struct {
+	localIP  string
+	remoteIP string
+}
+
+type allocation struct {
+	ConnectionID string `json:"connectionId"`
+	LocalIP      string `json:"localIp"`
+	RemoteIP     string `json:"remoteIp"`
+	Vni          uint32 `json:"vni"`
+}
+
+func (a *allocation) pair() pair {
+	return pair{localIP: a.LocalIP, remoteIP: a.RemoteIP}
 }
 
 type vniAllocator struct {
-	lastVni map[string]uint32
 	sync.Mutex
+	inUse        map[pair]map[uint32]string
+	lastVni      map[pair]uint32
+	byConnection map[string]*allocation
+	maxVni       uint32
+	statePath    string
 }
 
+// NewVniAllocator - creates VNI allocator keeping its state in memory
 func NewVniAllocator() VniAllocator {
+	return newVniAllocator()
+}
+
+// NewPersistentVniAllocator - creates VNI allocator saving its state to the statePath file on every change,
+// the state is loaded from the file if it exists
+func NewPersistentVniAllocator(statePath string) (VniAllocator, error) {
+	a := newVniAllocator()
+	a.statePath = statePath
+	if err := a.load(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func newVniAllocator() *vniAllocator {
 	return &vniAllocator{
-		lastVni: make(map[string]uint32),
+		inUse:        make(map[pair]map[uint32]string),
+		lastVni:      make(map[pair]uint32),
+		byConnection: make(map[string]*allocation),
+		maxVni:       MaxVni,
+	}
+}
+
+// Vni - allocates the next free VNI of the pair parity
+func (a *vniAllocator) Vni(connectionID, localIP, remoteIP string) (uint32, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	p := pair{localIP: localIP, remoteIP: remoteIP}
+	if existing, ok := a.byConnection[connectionID]; ok {
+		if existing.pair() == p {
+			return existing.Vni, nil
+		}
+		a.release(existing)
+	}
+
+	first, last := a.vniRange(localIP, remoteIP)
+	vni := a.lastVni[p]
+	for i := uint32(0); i <= (last-first)/2; i++ {
+		if vni < first || vni >= last || vni%2 != first%2 {
+			vni = first
+		} else {
+			vni += 2
+		}
+		if _, used := a.inUse[p][vni]; !used {
+			a.add(&allocation{ConnectionID: connectionID, LocalIP: localIP, RemoteIP: remoteIP, Vni: vni})
+			a.lastVni[p] = vni
+			a.save()
+			return vni, nil
+		}
+	}
+	return 0, errors.Errorf("no free VNI left for local IP %s and remote IP %s", localIP, remoteIP)
+}
+
+// Release - releases VNI allocated for the connection
+func (a *vniAllocator) Release(connectionID string) {
+	a.Lock()
+	defer a.Unlock()
+
+	if existing, ok := a.byConnection[connectionID]; ok {
+		a.release(existing)
+		a.save()
+	}
+}
+
+// Restore - restores VNI allocated for the connection based on connections we have at the moment
+func (a *vniAllocator) Restore(connectionID, localIP, remoteIP string, vni uint32) error {
+	a.Lock()
+	defer a.Unlock()
+
+	p := pair{localIP: localIP, remoteIP: remoteIP}
+	if owner, used := a.inUse[p][vni]; used && owner != connectionID {
+		return errors.Errorf("VNI %d for local IP %s and remote IP %s is restored for connection %s, but allocated for connection %s",
+			vni, localIP, remoteIP, connectionID, owner)
+	}
+	if existing, ok := a.byConnection[connectionID]; ok {
+		a.release(existing)
 	}
+	a.add(&allocation{ConnectionID: connectionID, LocalIP: localIP, RemoteIP: remoteIP, Vni: vni})
+	a.restoreLastVni(p, vni)
+	a.save()
+	return nil
 }
 
-// Vni - Allocate a new VNI, odd if local_ip < remote_ip, even otherwise
-func (a *vniAllocator) Vni(localIP, remoteIP string) uint32 {
+// ConnectionIDs - returns connections having VNI allocated sorted by ID
+func (a *vniAllocator) ConnectionIDs() []string {
 	a.Lock()
 	defer a.Unlock()
-	lip := net.ParseIP(localIP)
-	rip := net.ParseIP(remoteIP)
-	lastVni := a.lastVni[remoteIP]
-	if lastVni == 0 {
-		if compareIps(lip, rip) < 0 {
-			lastVni = 1
+
+	ids := make([]string, 0, len(a.byConnection))
+	for id := range a.byConnection {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (a *vniAllocator) add(alloc *allocation) {
+	p := alloc.pair()
+	if a.inUse[p] == nil {
+		a.inUse[p] = make(map[uint32]string)
+	}
+	a.inUse[p][alloc.Vni] = alloc.ConnectionID
+	a.byConnection[alloc.ConnectionID] = alloc
+}
+
+func (a *vniAllocator) release(alloc *allocation) {
+	p := alloc.pair()
+	delete(a.inUse[p], alloc.Vni)
+	if len(a.inUse[p]) == 0 {
+		delete(a.inUse, p)
+	}
+	delete(a.byConnection, alloc.ConnectionID)
+}
+
+// restoreLastVni continues allocation after the restored VNI, VNIs allocated by the remote side have another parity and are skipped
+func (a *vniAllocator) restoreLastVni(p pair, vni uint32) {
+	first, _ := a.vniRange(p.localIP, p.remoteIP)
+	if vni%2 == first%2 && vni > a.lastVni[p] {
+		a.lastVni[p] = vni
+	}
+}
+
+func (a *vniAllocator) load() error {
+	data, err := ioutil.ReadFile(a.statePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to read VNI allocator state from %s", a.statePath)
+	}
+	var allocations []*allocation
+	if err = json.Unmarshal(data, &allocations); err != nil {
+		return errors.Wrapf(err, "failed to parse VNI allocator state from %s", a.statePath)
+	}
+	for _, alloc := range allocations {
+		if _, used := a.inUse[alloc.pair()][alloc.Vni]; used {
+			logrus.Errorf("VNI %d for local IP %s and remote IP %s is allocated twice in %s, skipping connection %s",
+				alloc.Vni, alloc.LocalIP, alloc.RemoteIP, a.statePath, alloc.ConnectionID)
+			continue
 		}
+		a.add(alloc)
+		a.restoreLastVni(alloc.pair(), alloc.Vni)
+	}
+	return nil
+}
+
+// save writes the state into the temporary file and renames it, so the state file is never partially written
+func (a *vniAllocator) save() {
+	if a.statePath == "" {
+		return
+	}
+	allocations := make([]*allocation, 0, len(a.byConnection))
+	for _, alloc := range a.byConnection {
+		allocations = append(allocations, alloc)
+	}
+	sort.Slice(allocations, func(i, j int) bool {
+		return allocations[i].ConnectionID < allocations[j].ConnectionID
+	})
+	data, err := json.Marshal(allocations)
+	if err != nil {
+		logrus.Errorf("Failed to marshal VNI allocator state: %v", err)
+		return
+	}
+	tmpPath := a.statePath + ".tmp"
+	if err = ioutil.WriteFile(tmpPath, data, 0600); err != nil {
+		logrus.Errorf("Failed to save VNI allocator state to %s: %v", tmpPath, err)
+		return
+	}
+	if err = os.Rename(tmpPath, a.statePath); err != nil {
+		logrus.Errorf("Failed to save VNI allocator state to %s: %v", a.statePath, err)
+	}
+}
+
+// MechanismPair - returns local and remote IPs the VNI of the VXLAN mechanism requested by the remote NSM is allocated for
+func MechanismPair(parameters map[string]string) (localIP, remoteIP string) {
+	extSrcIP := parameters[vxlan.SrcIP]
+	extDstIP := parameters[vxlan.DstIP]
+	srcIP := parameters[vxlan.SrcIP]
+	dstIP := parameters[vxlan.DstIP]
+
+	if ip, ok := parameters[vxlan.SrcOriginalIP]; ok {
+		srcIP = ip
+	}
+
+	if ip, ok := parameters[vxlan.DstExternalIP]; ok {
+		extDstIP = ip
 	}
-	a.lastVni[remoteIP] = lastVni + 2
-	return a.lastVni[remoteIP]
+
+	if extDstIP != extSrcIP {
+		return extDstIP, extSrcIP
+	}
+	return dstIP, srcIP
 }
 
-// Restore value of last Vni based on connections we have at the moment.
-func (a *vniAllocator) Restore(localIP, remoteIP string, vniID uint32) {
-	a.lastVni[remoteIP] = vniID
+// vniRange returns the first and the last VNI of the pair parity
+func (a *vniAllocator) vniRange(localIP, remoteIP string) (first, last uint32) {
+	if compareIps(net.ParseIP(localIP), net.ParseIP(remoteIP)) < 0 {
+		return firstOddVni, a.maxVni | 1
+	}
+	return firstEvenVni, a.maxVni &^ 1
 }
 
 func compareIps(ip1, ip2 net.IP) int {
+	if len(ip1) != len(ip2) {
+		return len(ip1) - len(ip2)
+	}
 	for index, value := range ip1 {
 		if value < ip2[index] {
 			return -1
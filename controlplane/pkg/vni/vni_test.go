@@ -0,0 +1,214 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vni
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
+)
+
+const (
+	lowIP  = "10.0.0.1"
+	highIP = "10.0.0.2"
+)
+
+func mustVni(t *testing.T, a VniAllocator, connectionID, localIP, remoteIP string) uint32 {
+	t.Helper()
+	vni, err := a.Vni(connectionID, localIP, remoteIP)
+	if err != nil {
+		t.Fatalf("Vni() failed: %v", err)
+	}
+	return vni
+}
+
+func TestVniParity(t *testing.T) {
+	a := NewVniAllocator()
+	if vni := mustVni(t, a, "1", lowIP, highIP); vni != 3 {
+		t.Errorf("Vni() = %v, want 3", vni)
+	}
+	if vni := mustVni(t, a, "2", lowIP, highIP); vni != 5 {
+		t.Errorf("Vni() = %v, want 5", vni)
+	}
+	if vni := mustVni(t, a, "3", highIP, lowIP); vni != 2 {
+		t.Errorf("Vni() = %v, want 2", vni)
+	}
+	if vni := mustVni(t, a, "4", highIP, lowIP); vni != 4 {
+		t.Errorf("Vni() = %v, want 4", vni)
+	}
+}
+
+func TestVniSameConnection(t *testing.T) {
+	a := NewVniAllocator()
+	vni := mustVni(t, a, "1", lowIP, highIP)
+	if got := mustVni(t, a, "1", lowIP, highIP); got != vni {
+		t.Errorf("Vni() = %v, want %v for the same connection", got, vni)
+	}
+	// Pair changed, old VNI should be released
+	mustVni(t, a, "1", lowIP, "10.0.0.3")
+	if err := a.Restore("2", lowIP, highIP, vni); err != nil {
+		t.Errorf("Restore() failed for released VNI: %v", err)
+	}
+}
+
+func TestVniRelease(t *testing.T) {
+	a := newVniAllocator()
+	a.lastVni[pair{localIP: lowIP, remoteIP: highIP}] = MaxVni - 2
+	if vni := mustVni(t, a, "1", lowIP, highIP); vni != MaxVni {
+		t.Errorf("Vni() = %v, want %v", vni, MaxVni)
+	}
+	first := mustVni(t, a, "2", lowIP, highIP)
+	if first != 3 {
+		t.Errorf("Vni() = %v, want 3 after wrap", first)
+	}
+	a.Release("1")
+	a.Release("unknown")
+	// Allocation goes on from the last allocated VNI
+	if vni := mustVni(t, a, "3", lowIP, highIP); vni != 5 {
+		t.Errorf("Vni() = %v, want 5", vni)
+	}
+	if err := a.Restore("4", lowIP, highIP, MaxVni); err != nil {
+		t.Errorf("Restore() failed for released VNI: %v", err)
+	}
+}
+
+func TestVniExhausted(t *testing.T) {
+	a := newVniAllocator()
+	a.maxVni = 199
+	p := pair{localIP: lowIP, remoteIP: highIP}
+	a.inUse[p] = make(map[uint32]string)
+	for vni := uint32(firstOddVni); vni <= a.maxVni; vni += 2 {
+		a.inUse[p][vni] = "used"
+	}
+	if vni, err := a.Vni("1", lowIP, highIP); err == nil {
+		t.Errorf("Vni() = %v, want error", vni)
+	}
+	delete(a.inUse[p], 101)
+	if vni := mustVni(t, a, "1", lowIP, highIP); vni != 101 {
+		t.Errorf("Vni() = %v, want 101", vni)
+	}
+}
+
+func TestVniRestore(t *testing.T) {
+	a := NewVniAllocator()
+	if err := a.Restore("1", lowIP, highIP, 7); err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if err := a.Restore("1", lowIP, highIP, 7); err != nil {
+		t.Errorf("Restore() failed for the same connection: %v", err)
+	}
+	if err := a.Restore("2", lowIP, highIP, 7); err == nil {
+		t.Errorf("Restore() should detect collision")
+	}
+	// VNI of the other pair does not collide
+	if err := a.Restore("2", lowIP, "10.0.0.3", 7); err != nil {
+		t.Errorf("Restore() failed: %v", err)
+	}
+	if vni := mustVni(t, a, "3", lowIP, highIP); vni != 9 {
+		t.Errorf("Vni() = %v, want 9 after restored 7", vni)
+	}
+	// VNI of another parity is allocated by the remote side and does not affect allocation
+	if err := a.Restore("4", lowIP, highIP, 100); err != nil {
+		t.Errorf("Restore() failed: %v", err)
+	}
+	if vni := mustVni(t, a, "5", lowIP, highIP); vni != 11 {
+		t.Errorf("Vni() = %v, want 11", vni)
+	}
+}
+
+func TestPersistentVniAllocator(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vni")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	statePath := filepath.Join(dir, "vni.json")
+
+	a, err := NewPersistentVniAllocator(statePath)
+	if err != nil {
+		t.Fatalf("NewPersistentVniAllocator() failed: %v", err)
+	}
+	mustVni(t, a, "1", lowIP, highIP)
+	vni := mustVni(t, a, "2", lowIP, highIP)
+	mustVni(t, a, "3", lowIP, highIP)
+	a.Release("3")
+
+	restored, err := NewPersistentVniAllocator(statePath)
+	if err != nil {
+		t.Fatalf("NewPersistentVniAllocator() failed: %v", err)
+	}
+	if got := restored.ConnectionIDs(); !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Errorf("ConnectionIDs() = %v, want [1 2]", got)
+	}
+	if got := mustVni(t, restored, "2", lowIP, highIP); got != vni {
+		t.Errorf("Vni() = %v, want %v restored", got, vni)
+	}
+	if err = restored.Restore("4", lowIP, highIP, vni); err == nil {
+		t.Errorf("Restore() should detect collision with the persisted VNI")
+	}
+	if got := mustVni(t, restored, "5", lowIP, highIP); got != 7 {
+		t.Errorf("Vni() = %v, want 7", got)
+	}
+
+	if err = ioutil.WriteFile(statePath, []byte("broken"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = NewPersistentVniAllocator(statePath); err == nil {
+		t.Errorf("NewPersistentVniAllocator() should fail for broken state")
+	}
+}
+
+func TestMechanismPair(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]string
+		localIP    string
+		remoteIP   string
+	}{
+		{
+			name:       "direct",
+			parameters: map[string]string{vxlan.SrcIP: "1.1.1.1", vxlan.DstIP: "2.2.2.2"},
+			localIP:    "2.2.2.2",
+			remoteIP:   "1.1.1.1",
+		},
+		{
+			name:       "external",
+			parameters: map[string]string{vxlan.SrcIP: "1.1.1.1", vxlan.DstIP: "2.2.2.2", vxlan.DstExternalIP: "3.3.3.3"},
+			localIP:    "3.3.3.3",
+			remoteIP:   "1.1.1.1",
+		},
+		{
+			name:       "same external",
+			parameters: map[string]string{vxlan.SrcIP: "1.1.1.1", vxlan.SrcOriginalIP: "4.4.4.4", vxlan.DstIP: "2.2.2.2", vxlan.DstExternalIP: "1.1.1.1"},
+			localIP:    "2.2.2.2",
+			remoteIP:   "4.4.4.4",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			localIP, remoteIP := MechanismPair(tt.parameters)
+			if localIP != tt.localIP || remoteIP != tt.remoteIP {
+				t.Errorf("MechanismPair() = %v, %v, want %v, %v", localIP, remoteIP, tt.localIP, tt.remoteIP)
+			}
+		})
+	}
+}
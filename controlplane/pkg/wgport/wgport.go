@@ -0,0 +1,161 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package wgport - allocates Wireguard listen ports of the node for the connections
+package wgport
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+)
+
+const (
+	// rangeSize - size of the default port range
+	rangeSize = 1000
+)
+
+// DefaultRange - default range of the Wireguard listen ports
+var DefaultRange = strconv.Itoa(wireguard.BasePort) + "-" + strconv.Itoa(wireguard.BasePort+rangeSize-1)
+
+// Allocator - allocates unique UDP ports for the Wireguard interfaces of the node
+type Allocator interface {
+	// Port - allocates a free port for the connection, returns the already allocated one if any
+	Port(connectionID string) (int, error)
+	// Release - releases port allocated for the connection
+	Release(connectionID string)
+	// Restore - marks port as allocated for the connection, fails if the port is allocated for another connection
+	// or is out of the range
+	Restore(connectionID string, port int) error
+}
+
+type portAllocator struct {
+	sync.Mutex
+	first        int
+	last         int
+	lastPort     int
+	owners       map[int]string
+	byConnection map[string]int
+}
+
+// NewPortAllocator - creates port allocator for the DefaultRange
+func NewPortAllocator() Allocator {
+	a, err := NewPortAllocatorWithRange(DefaultRange)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// NewPortAllocatorWithRange - creates port allocator for the range of ports, e.g. 51820-52819
+func NewPortAllocatorWithRange(portRange string) (Allocator, error) {
+	first, last, err := parseRange(portRange)
+	if err != nil {
+		return nil, err
+	}
+	return &portAllocator{
+		first:        first,
+		last:         last,
+		owners:       make(map[int]string),
+		byConnection: make(map[string]int),
+	}, nil
+}
+
+// Port - allocates the next free port of the range
+func (a *portAllocator) Port(connectionID string) (int, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if port, ok := a.byConnection[connectionID]; ok {
+		return port, nil
+	}
+
+	port := a.lastPort
+	for i := a.first; i <= a.last; i++ {
+		if port < a.first || port >= a.last {
+			port = a.first
+		} else {
+			port++
+		}
+		if _, used := a.owners[port]; !used {
+			a.add(connectionID, port)
+			a.lastPort = port
+			return port, nil
+		}
+	}
+	return 0, errors.Errorf("no free Wireguard port left in the range %d-%d", a.first, a.last)
+}
+
+// Release - releases port allocated for the connection
+func (a *portAllocator) Release(connectionID string) {
+	a.Lock()
+	defer a.Unlock()
+
+	if port, ok := a.byConnection[connectionID]; ok {
+		delete(a.owners, port)
+		delete(a.byConnection, connectionID)
+	}
+}
+
+// Restore - restores port allocated for the connection based on cross connects we have at the moment
+func (a *portAllocator) Restore(connectionID string, port int) error {
+	a.Lock()
+	defer a.Unlock()
+
+	if port < a.first || port > a.last {
+		return errors.Errorf("port %d is out of the range %d-%d", port, a.first, a.last)
+	}
+	if owner, used := a.owners[port]; used {
+		if owner == connectionID {
+			return nil
+		}
+		return errors.Errorf("port %d is restored for connection %s, but allocated for connection %s", port, connectionID, owner)
+	}
+	if existing, ok := a.byConnection[connectionID]; ok {
+		return errors.Errorf("port %d is restored for connection %s, but port %d is already allocated for it", port, connectionID, existing)
+	}
+	a.add(connectionID, port)
+	if port > a.lastPort {
+		a.lastPort = port
+	}
+	return nil
+}
+
+func (a *portAllocator) add(connectionID string, port int) {
+	a.owners[port] = connectionID
+	a.byConnection[connectionID] = port
+}
+
+func parseRange(portRange string) (first, last int, err error) {
+	bounds := strings.Split(portRange, "-")
+	if len(bounds) != 2 {
+		return 0, 0, errors.Errorf("invalid port range %s: first-last expected", portRange)
+	}
+	if first, err = strconv.Atoi(strings.TrimSpace(bounds[0])); err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid port range %s", portRange)
+	}
+	if last, err = strconv.Atoi(strings.TrimSpace(bounds[1])); err != nil {
+		return 0, 0, errors.Wrapf(err, "invalid port range %s", portRange)
+	}
+	if first < 1 || last > 65535 || first > last {
+		return 0, 0, errors.Errorf("invalid port range %s: ports should be within 1-65535, first should not exceed last", portRange)
+	}
+	return first, last, nil
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wgport
+
+import (
+	"testing"
+)
+
+func mustPort(t *testing.T, a Allocator, connectionID string) int {
+	t.Helper()
+	port, err := a.Port(connectionID)
+	if err != nil {
+		t.Fatalf("Port() failed: %v", err)
+	}
+	return port
+}
+
+func TestNewPortAllocatorWithRange(t *testing.T) {
+	for _, portRange := range []string{DefaultRange, "1-65535", "51820 - 51820"} {
+		if _, err := NewPortAllocatorWithRange(portRange); err != nil {
+			t.Errorf("NewPortAllocatorWithRange(%q) failed: %v", portRange, err)
+		}
+	}
+	for _, portRange := range []string{"", "51820", "a-b", "0-10", "51820-70000", "52000-51820"} {
+		if _, err := NewPortAllocatorWithRange(portRange); err == nil {
+			t.Errorf("NewPortAllocatorWithRange(%q) should fail", portRange)
+		}
+	}
+}
+
+func TestPort(t *testing.T) {
+	a, err := NewPortAllocatorWithRange("100-102")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []int{100, 101, 102} {
+		if port := mustPort(t, a, string(rune('a'+i))); port != want {
+			t.Errorf("Port() = %v, want %v", port, want)
+		}
+	}
+	// Port is kept for the connection
+	if port := mustPort(t, a, "b"); port != 101 {
+		t.Errorf("Port() = %v, want 101", port)
+	}
+	if _, err = a.Port("d"); err == nil {
+		t.Error("Port() should fail when the range is exhausted")
+	}
+	a.Release("b")
+	if port := mustPort(t, a, "d"); port != 101 {
+		t.Errorf("Port() = %v, want 101", port)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	a := NewPortAllocator()
+	if err := a.Restore("1", 51830); err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if err := a.Restore("1", 51830); err != nil {
+		t.Errorf("Restore() of the same port failed: %v", err)
+	}
+	if err := a.Restore("2", 51830); err == nil {
+		t.Error("Restore() of the port allocated for another connection should fail")
+	}
+	if err := a.Restore("3", 80); err == nil {
+		t.Error("Restore() of the port out of the range should fail")
+	}
+	// Allocation goes on after the restored port
+	if port := mustPort(t, a, "4"); port != 51831 {
+		t.Errorf("Port() = %v, want 51831", port)
+	}
+}
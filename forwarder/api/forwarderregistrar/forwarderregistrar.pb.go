@@ -194,36 +194,122 @@ func (m *ForwarderUnRegistrationReply) GetUnRegistered() bool {
 	return false
 }
 
+// ForwarderDrainRequest is sent by the forwarder to NSM before it is terminated
+// to move all its connections to other forwarders and remove it from the list of available forwarders.
+type ForwarderDrainRequest struct {
+	ForwarderName        string   `protobuf:"bytes,1,opt,name=forwarder_name,json=forwarderName,proto3" json:"forwarder_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ForwarderDrainRequest) Reset()         { *m = ForwarderDrainRequest{} }
+func (m *ForwarderDrainRequest) String() string { return proto.CompactTextString(m) }
+func (*ForwarderDrainRequest) ProtoMessage()    {}
+func (*ForwarderDrainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf2c0f4975ef21fe, []int{4}
+}
+
+func (m *ForwarderDrainRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForwarderDrainRequest.Unmarshal(m, b)
+}
+func (m *ForwarderDrainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForwarderDrainRequest.Marshal(b, m, deterministic)
+}
+func (m *ForwarderDrainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForwarderDrainRequest.Merge(m, src)
+}
+func (m *ForwarderDrainRequest) XXX_Size() int {
+	return xxx_messageInfo_ForwarderDrainRequest.Size(m)
+}
+func (m *ForwarderDrainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForwarderDrainRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForwarderDrainRequest proto.InternalMessageInfo
+
+func (m *ForwarderDrainRequest) GetForwarderName() string {
+	if m != nil {
+		return m.ForwarderName
+	}
+	return ""
+}
+
+type ForwarderDrainReply struct {
+	Drained              bool     `protobuf:"varint,1,opt,name=drained,proto3" json:"drained,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ForwarderDrainReply) Reset()         { *m = ForwarderDrainReply{} }
+func (m *ForwarderDrainReply) String() string { return proto.CompactTextString(m) }
+func (*ForwarderDrainReply) ProtoMessage()    {}
+func (*ForwarderDrainReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bf2c0f4975ef21fe, []int{5}
+}
+
+func (m *ForwarderDrainReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ForwarderDrainReply.Unmarshal(m, b)
+}
+func (m *ForwarderDrainReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ForwarderDrainReply.Marshal(b, m, deterministic)
+}
+func (m *ForwarderDrainReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForwarderDrainReply.Merge(m, src)
+}
+func (m *ForwarderDrainReply) XXX_Size() int {
+	return xxx_messageInfo_ForwarderDrainReply.Size(m)
+}
+func (m *ForwarderDrainReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForwarderDrainReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForwarderDrainReply proto.InternalMessageInfo
+
+func (m *ForwarderDrainReply) GetDrained() bool {
+	if m != nil {
+		return m.Drained
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*ForwarderRegistrationRequest)(nil), "forwarderregistrar.ForwarderRegistrationRequest")
 	proto.RegisterType((*ForwarderRegistrationReply)(nil), "forwarderregistrar.ForwarderRegistrationReply")
 	proto.RegisterType((*ForwarderUnRegistrationRequest)(nil), "forwarderregistrar.ForwarderUnRegistrationRequest")
 	proto.RegisterType((*ForwarderUnRegistrationReply)(nil), "forwarderregistrar.ForwarderUnRegistrationReply")
+	proto.RegisterType((*ForwarderDrainRequest)(nil), "forwarderregistrar.ForwarderDrainRequest")
+	proto.RegisterType((*ForwarderDrainReply)(nil), "forwarderregistrar.ForwarderDrainReply")
 }
 
 func init() { proto.RegisterFile("forwarderregistrar.proto", fileDescriptor_bf2c0f4975ef21fe) }
 
 var fileDescriptor_bf2c0f4975ef21fe = []byte{
-	// 304 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x92, 0xcf, 0x4a, 0xc3, 0x40,
-	0x18, 0xc4, 0x59, 0x0f, 0xa2, 0x1f, 0xd6, 0xca, 0x82, 0x1a, 0x42, 0x09, 0x12, 0x11, 0xea, 0x65,
-	0x0d, 0xf1, 0xea, 0x4d, 0xaa, 0x17, 0xf1, 0x10, 0xf1, 0x5c, 0x52, 0xfb, 0x35, 0x04, 0x93, 0xdd,
-	0x75, 0x77, 0xa3, 0xe4, 0xe6, 0xc9, 0x07, 0xf1, 0xcd, 0x7c, 0x13, 0xc9, 0x1f, 0x63, 0x49, 0x93,
-	0x42, 0x7b, 0xc9, 0x61, 0xf2, 0x9b, 0xdd, 0x99, 0x4c, 0xc0, 0x5a, 0x08, 0xf5, 0x11, 0xaa, 0x39,
-	0x2a, 0x85, 0x51, 0xac, 0x8d, 0x0a, 0x15, 0x93, 0x4a, 0x18, 0x41, 0xe9, 0xea, 0x1b, 0xdb, 0x92,
-	0x26, 0x97, 0xa8, 0xaf, 0x30, 0x95, 0x26, 0xaf, 0x9e, 0x15, 0xed, 0x4a, 0x18, 0xdd, 0xfd, 0xf1,
-	0x41, 0xcd, 0x9b, 0x58, 0xf0, 0x00, 0xdf, 0x32, 0xd4, 0x86, 0x5e, 0xc0, 0x61, 0x73, 0xde, 0x94,
-	0x87, 0x29, 0x5a, 0xe4, 0x8c, 0x8c, 0xf7, 0x83, 0x41, 0xa3, 0x3e, 0x86, 0x29, 0xd2, 0x4b, 0x38,
-	0xfa, 0xc7, 0xb4, 0x78, 0x79, 0x45, 0x63, 0xed, 0x94, 0xe0, 0xb0, 0xd1, 0x9f, 0x4a, 0xd9, 0xbd,
-	0x01, 0xbb, 0xe7, 0x46, 0x99, 0xe4, 0xd4, 0x01, 0xa8, 0x62, 0xa3, 0xc2, 0x79, 0x79, 0xd7, 0x5e,
-	0xb0, 0xa4, 0xb8, 0xf7, 0xe0, 0x34, 0xee, 0x67, 0xbe, 0x7d, 0x62, 0xf7, 0x76, 0xa9, 0x78, 0xfb,
-	0xa0, 0x22, 0xc8, 0x39, 0x0c, 0x32, 0x3e, 0x5d, 0xc9, 0x72, 0x90, 0xd5, 0x6c, 0xa1, 0xf9, 0x3f,
-	0x04, 0x8e, 0x3b, 0xcb, 0xd0, 0x4f, 0x02, 0xa3, 0x3a, 0x51, 0x37, 0xe0, 0xb1, 0x8e, 0x05, 0xd7,
-	0x4d, 0x61, 0xb3, 0x0d, 0x1c, 0x45, 0x83, 0x09, 0x0c, 0x6b, 0xeb, 0x43, 0xfc, 0x8e, 0x1c, 0xb5,
-	0xa6, 0x27, 0x2c, 0x12, 0x22, 0x4a, 0xb0, 0x1a, 0x7f, 0x96, 0x2d, 0xd8, 0xa4, 0xf8, 0x17, 0xec,
-	0x1e, 0x7d, 0x4c, 0x3c, 0xe2, 0x7f, 0x13, 0x38, 0xed, 0xf9, 0x52, 0xf4, 0x8b, 0x80, 0xd3, 0x6e,
-	0xd9, 0x42, 0xfc, 0xb5, 0xa9, 0x3b, 0x27, 0xb4, 0xbd, 0x8d, 0x3c, 0x32, 0xc9, 0x67, 0xbb, 0x65,
-	0xf0, 0xeb, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc9, 0xaf, 0x67, 0xb2, 0x17, 0x03, 0x00, 0x00,
+	// 353 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x52, 0x41, 0x4b, 0xf3, 0x40,
+	0x14, 0x64, 0xbf, 0xc3, 0xa7, 0x3e, 0x6c, 0x2b, 0x2b, 0xd5, 0x10, 0x4a, 0x91, 0x88, 0xd8, 0x5e,
+	0xd2, 0x12, 0xaf, 0xe2, 0x45, 0xab, 0x17, 0xf1, 0x10, 0xf1, 0x5c, 0x52, 0xfb, 0x5a, 0x42, 0xd3,
+	0xec, 0xba, 0xbb, 0x51, 0x72, 0x10, 0x3c, 0xf9, 0x43, 0xfc, 0x67, 0xfe, 0x13, 0x49, 0xd2, 0xae,
+	0x31, 0x6d, 0x8a, 0xf1, 0x12, 0xd8, 0xd9, 0x99, 0x37, 0x93, 0x7d, 0x03, 0xc6, 0x84, 0x89, 0x17,
+	0x4f, 0x8c, 0x51, 0x08, 0x9c, 0xfa, 0x52, 0x09, 0x4f, 0xd8, 0x5c, 0x30, 0xc5, 0x28, 0x5d, 0xbd,
+	0x31, 0x0d, 0xae, 0x62, 0x8e, 0xb2, 0x87, 0x73, 0xae, 0xe2, 0xec, 0x9b, 0xb1, 0x2d, 0x0e, 0xad,
+	0xeb, 0x25, 0xdf, 0x5d, 0xf0, 0x95, 0xcf, 0x42, 0x17, 0x9f, 0x22, 0x94, 0x8a, 0x9e, 0x40, 0x5d,
+	0xcf, 0x1b, 0x86, 0xde, 0x1c, 0x0d, 0x72, 0x44, 0x3a, 0x3b, 0x6e, 0x4d, 0xa3, 0x77, 0xde, 0x1c,
+	0x69, 0x17, 0xf6, 0xbe, 0x69, 0x92, 0x3d, 0xce, 0x50, 0x19, 0xff, 0x52, 0x62, 0x43, 0xe3, 0xf7,
+	0x29, 0x6c, 0x9d, 0x83, 0x59, 0xe2, 0xc8, 0x83, 0x98, 0xb6, 0x01, 0xb2, 0xd8, 0x28, 0x70, 0x9c,
+	0x7a, 0x6d, 0xbb, 0x39, 0xc4, 0xba, 0x81, 0xb6, 0x56, 0x3f, 0x84, 0x7f, 0x4f, 0x6c, 0x5d, 0x42,
+	0xab, 0x74, 0x50, 0x12, 0xe4, 0x18, 0x6a, 0x51, 0x38, 0x5c, 0xc9, 0xb2, 0x1b, 0x85, 0xae, 0xc6,
+	0xac, 0x0b, 0x68, 0xea, 0x21, 0x57, 0xc2, 0xf3, 0xab, 0x86, 0xe8, 0xc1, 0x7e, 0x51, 0x9f, 0x78,
+	0x1b, 0xb0, 0x35, 0x4e, 0x4e, 0xda, 0x75, 0x79, 0x74, 0x3e, 0x49, 0xce, 0x31, 0x1f, 0x9a, 0xbe,
+	0x11, 0x68, 0x2d, 0xdc, 0xd7, 0x13, 0xfa, 0xf6, 0x9a, 0xca, 0x6c, 0xda, 0xbd, 0x69, 0x57, 0x50,
+	0x24, 0xb1, 0x07, 0xd0, 0x58, 0x48, 0x6f, 0xfd, 0x67, 0x0c, 0x51, 0x4a, 0x7a, 0x60, 0x4f, 0x19,
+	0x9b, 0x06, 0x98, 0xb5, 0x6d, 0x14, 0x4d, 0xec, 0x41, 0x52, 0x3e, 0xb3, 0x04, 0xef, 0x90, 0x3e,
+	0x71, 0x3e, 0x08, 0x1c, 0x96, 0xac, 0x86, 0xbe, 0x13, 0x68, 0x17, 0xff, 0xb2, 0x40, 0x71, 0x36,
+	0xa6, 0x5e, 0xdb, 0x19, 0xb3, 0x5f, 0x49, 0xc3, 0x83, 0xd8, 0x79, 0x85, 0xfa, 0xcf, 0xcd, 0xd1,
+	0x19, 0x34, 0x8b, 0xc9, 0xb2, 0x8b, 0xee, 0xc6, 0xe1, 0xf9, 0xda, 0x98, 0xa7, 0xbf, 0xa1, 0xf2,
+	0x20, 0x1e, 0xfd, 0x4f, 0xdf, 0xed, 0xec, 0x6b, 0x00, 0x12, 0x16, 0x62, 0x76, 0x07, 0x04, 0x00,
+	0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -452,3 +538,75 @@ var _ForwarderUnRegistration_serviceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "forwarderregistrar.proto",
 }
+
+// ForwarderDrainClient is the client API for ForwarderDrain service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ForwarderDrainClient interface {
+	RequestForwarderDrain(ctx context.Context, in *ForwarderDrainRequest, opts ...grpc.CallOption) (*ForwarderDrainReply, error)
+}
+
+type forwarderDrainClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewForwarderDrainClient(cc grpc.ClientConnInterface) ForwarderDrainClient {
+	return &forwarderDrainClient{cc}
+}
+
+func (c *forwarderDrainClient) RequestForwarderDrain(ctx context.Context, in *ForwarderDrainRequest, opts ...grpc.CallOption) (*ForwarderDrainReply, error) {
+	out := new(ForwarderDrainReply)
+	err := c.cc.Invoke(ctx, "/forwarderregistrar.ForwarderDrain/RequestForwarderDrain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ForwarderDrainServer is the server API for ForwarderDrain service.
+type ForwarderDrainServer interface {
+	RequestForwarderDrain(context.Context, *ForwarderDrainRequest) (*ForwarderDrainReply, error)
+}
+
+// UnimplementedForwarderDrainServer can be embedded to have forward compatible implementations.
+type UnimplementedForwarderDrainServer struct {
+}
+
+func (*UnimplementedForwarderDrainServer) RequestForwarderDrain(ctx context.Context, req *ForwarderDrainRequest) (*ForwarderDrainReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestForwarderDrain not implemented")
+}
+
+func RegisterForwarderDrainServer(s *grpc.Server, srv ForwarderDrainServer) {
+	s.RegisterService(&_ForwarderDrain_serviceDesc, srv)
+}
+
+func _ForwarderDrain_RequestForwarderDrain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForwarderDrainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwarderDrainServer).RequestForwarderDrain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwarderregistrar.ForwarderDrain/RequestForwarderDrain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwarderDrainServer).RequestForwarderDrain(ctx, req.(*ForwarderDrainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ForwarderDrain_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "forwarderregistrar.ForwarderDrain",
+	HandlerType: (*ForwarderDrainServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestForwarderDrain",
+			Handler:    _ForwarderDrain_RequestForwarderDrain_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "forwarderregistrar.proto",
+}
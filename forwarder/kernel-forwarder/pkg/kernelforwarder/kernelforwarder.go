@@ -17,6 +17,7 @@ package kernelforwarder
 
 import (
 	"context"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
@@ -32,8 +33,12 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/forwarder/kernel-forwarder/pkg/kernelforwarder/remote"
 	"github.com/networkservicemesh/networkservicemesh/forwarder/kernel-forwarder/pkg/monitoring"
 	"github.com/networkservicemesh/networkservicemesh/forwarder/pkg/common"
+	"github.com/networkservicemesh/networkservicemesh/utils"
 )
 
+// WireguardKeyRotationIntervalEnv - interval of the Wireguard key pair rotation, e.g. 24h. Keys are not rotated if not set
+const WireguardKeyRotationIntervalEnv = utils.EnvVar("WIREGUARD_KEY_ROTATION_INTERVAL")
+
 // KernelForwarder instance
 type KernelForwarder struct {
 	common        *common.ForwarderConfig
@@ -54,8 +59,7 @@ func CreateKernelForwarder() *KernelForwarder {
 func (k *KernelForwarder) Init(common *common.ForwarderConfig) error {
 	k.common = common
 	k.common.Name = "kernel-forwarder"
-	k.configureKernelForwarder()
-	return nil
+	return k.configureKernelForwarder()
 }
 
 // CreateForwarderServer creates an instance of ForwarderServer
@@ -128,9 +132,30 @@ func (k *KernelForwarder) connectOrDisconnect(crossConnect *crossconnect.CrossCo
 }
 
 // configureKernelForwarder setups the Kernel forwarding plane
-func (k *KernelForwarder) configureKernelForwarder() {
+func (k *KernelForwarder) configureKernelForwarder() error {
+	wireguardPublicKey, err := k.remoteConnect.WireguardPublicKey()
+	if err != nil {
+		return err
+	}
 	k.common.MechanismsUpdateChannel = make(chan *common.Mechanisms, 1)
-	k.common.Mechanisms = &common.Mechanisms{
+	k.common.Mechanisms = k.mechanisms(wireguardPublicKey)
+	// Metrics monitoring
+	if k.common.MetricsEnabled {
+		k.monitoring = monitoring.CreateMetricsMonitor(k.common.MetricsPeriod)
+		k.monitoring.Start(k.common.Monitor)
+	}
+	// Network Service monitoring
+	common.CreateNSMonitor(k.common.Monitor, nsmonitorCallback)
+	// Wireguard key rotation
+	if interval := WireguardKeyRotationIntervalEnv.GetOrDefaultDuration(0); interval > 0 {
+		go k.rotateWireguardKeys(interval)
+	}
+	return nil
+}
+
+// mechanisms returns mechanisms supported by the Kernel forwarding plane
+func (k *KernelForwarder) mechanisms(wireguardPublicKey string) *common.Mechanisms {
+	return &common.Mechanisms{
 		LocalMechanisms: []*connection.Mechanism{
 			{
 				Type: kernel.MECHANISM,
@@ -146,18 +171,33 @@ func (k *KernelForwarder) configureKernelForwarder() {
 			{
 				Type: wireguard.MECHANISM,
 				Parameters: map[string]string{
-					wireguard.SrcIP: k.common.EgressInterface.SrcIPNet().IP.String(),
+					wireguard.SrcIP:        k.common.EgressInterface.SrcIPNet().IP.String(),
+					wireguard.SrcPublicKey: wireguardPublicKey,
 				},
 			},
 		},
 	}
-	// Metrics monitoring
-	if k.common.MetricsEnabled {
-		k.monitoring = monitoring.CreateMetricsMonitor(k.common.MetricsPeriod)
-		k.monitoring.Start(k.common.Monitor)
+}
+
+// rotateWireguardKeys periodically replaces the Wireguard key pair of the forwarder and advertises the new public key,
+// NSM re-requests the Wireguard connections to update them with the new keys
+func (k *KernelForwarder) rotateWireguardKeys(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		publicKey, err := k.remoteConnect.RotateWireguardKey()
+		if err != nil {
+			logrus.Errorf("kernel-forwarder: failed to rotate Wireguard key: %v", err)
+			continue
+		}
+		logrus.Infof("kernel-forwarder: Wireguard key is rotated, new public key: %v", publicKey)
+		// Only the latest mechanisms are relevant, drop the pending update if it is not sent yet
+		select {
+		case <-k.common.MechanismsUpdateChannel:
+		default:
+		}
+		k.common.MechanismsUpdateChannel <- k.mechanisms(publicKey)
 	}
-	// Network Service monitoring
-	common.CreateNSMonitor(k.common.Monitor, nsmonitorCallback)
 }
 
 // MonitorMechanisms handler
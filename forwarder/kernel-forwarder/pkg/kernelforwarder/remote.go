@@ -141,6 +141,16 @@ func (k *KernelForwarder) createRemoteConnection(connID string, localConnection,
 		return nil, err
 	}
 	if foundConn {
+		updated, updateErr := k.remoteConnect.UpdateInterface(ifaceName, remoteConnection, direction)
+		if updateErr != nil {
+			logrus.Errorf("remote: failed to update intf in host: %v", updateErr)
+			return nil, updateErr
+		}
+		if updated {
+			nsInode = localConnection.GetMechanism().GetParameters()[common2.NetNsInodeKey]
+			logrus.Infof("remote: connection updated in place for device - %s", ifaceName)
+			return map[string]monitoring.Device{nsInode: {Name: ifaceName, XconName: xconName}}, nil
+		}
 		logrus.Infof("remote: connection already exists. deleting... %v", localConnection)
                 _, err := ClearInterfaceSetup(ifaceName, localConnection)
                 if err != nil {
@@ -23,7 +23,7 @@ import (
 	"sync"
 
 	"github.com/pkg/errors"
-	wg "golang.zx2c4.com/wireguard/device"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/vxlan"
@@ -39,13 +39,18 @@ const (
 // Connect - struct with remote mechanism interfaces creation and deletion methods
 type Connect struct {
 	wireguardDevicesMutex sync.Mutex
-	wireguardDevices      map[string]*wg.Device
+	wireguardDevices      map[string]*wireguardDevice
+	wireguardKey          *wgtypes.Key
+	wireguardPreviousKeys map[wgtypes.Key]wgtypes.Key
+	configureDevice       func(ifaceName string, localPrivateKey wgtypes.Key, peer *wireguardPeer) error
 }
 
 // NewConnect - creates instance of remote Connect
 func NewConnect() *Connect {
 	return &Connect{
-		wireguardDevices: make(map[string]*wg.Device),
+		wireguardDevices:      make(map[string]*wireguardDevice),
+		wireguardPreviousKeys: make(map[wgtypes.Key]wgtypes.Key),
+		configureDevice:       configureWireguardDevice,
 	}
 }
 
@@ -60,6 +65,15 @@ func (c *Connect) CreateInterface(ifaceName string, remoteConnection *connection
 	return errors.Errorf("unknown remote mechanism - %v", remoteConnection.GetMechanism().GetType())
 }
 
+// UpdateInterface - updates existing interface to remote connection in place, returns false if the interface
+// can't be updated and should be re-created
+func (c *Connect) UpdateInterface(ifaceName string, remoteConnection *connection.Connection, direction uint8) (bool, error) {
+	if remoteConnection.GetMechanism().GetType() == wireguard.MECHANISM {
+		return c.updateWireguardInterface(ifaceName, remoteConnection, direction)
+	}
+	return false, nil
+}
+
 // DeleteInterface - deletes interface to remote connection
 func (c *Connect) DeleteInterface(ifaceName string, remoteConnection *connection.Connection) error {
 	switch remoteConnection.GetMechanism().GetType() {
@@ -32,94 +32,251 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
 )
 
-// CreateVXLANInterface creates a VXLAN interface
-func (c *Connect) createWireguardInterface(ifaceName string, remoteConnection *connection.Connection, direction uint8) error {
-	c.wireguardDevicesMutex.Lock()
-	defer c.wireguardDevicesMutex.Unlock()
+// wireguardDevice - Wireguard device with its configuration API listener, both are alive until the interface is deleted.
+// The device is configured with the local private key and the peer
+type wireguardDevice struct {
+	device     *device.Device
+	uapi       net.Listener
+	privateKey wgtypes.Key
+	peer       *wireguardPeer
+}
+
+func (d *wireguardDevice) close() {
+	if err := d.uapi.Close(); err != nil {
+		logrus.Errorf("Wireguard error: failed to close API listener %v", err)
+	}
+	d.device.Close()
+}
+
+// wireguardPeer - parameters of the remote side of the connection
+type wireguardPeer struct {
+	publicKey  wgtypes.Key
+	localPort  int
+	remotePort int
+	dstIP      net.IP
+}
+
+func (p *wireguardPeer) equal(other *wireguardPeer) bool {
+	return p.publicKey == other.publicKey && p.localPort == other.localPort && p.remotePort == other.remotePort &&
+		p.dstIP.Equal(other.dstIP)
+}
+
+func newWireguardPeer(remoteConnection *connection.Connection, direction uint8) (*wireguardPeer, error) {
 	mechanism := wireguard.ToMechanism(remoteConnection.GetMechanism())
 
-	/* Create interface - host namespace */
-	var localPrivateKeyStr string
 	var remotePublicKeyStr string
 	var localPort int
 	var remotePort int
 	var dstIPStr string
 	var err error
 	if direction == INCOMING {
-		if localPrivateKeyStr, err = mechanism.DstPrivateKey(); err != nil {
-			return err
-		}
 		if remotePublicKeyStr, err = mechanism.SrcPublicKey(); err != nil {
-			return err
+			return nil, err
 		}
 		if dstIPStr, err = mechanism.SrcIP(); err != nil {
-			return err
+			return nil, err
 		}
 		if localPort, err = mechanism.DstPort(); err != nil {
-			return err
+			return nil, err
 		}
 		if remotePort, err = mechanism.SrcPort(); err != nil {
-			return err
+			return nil, err
 		}
 	} else {
-		if localPrivateKeyStr, err = mechanism.SrcPrivateKey(); err != nil {
-			return err
-		}
 		if remotePublicKeyStr, err = mechanism.DstPublicKey(); err != nil {
-			return err
+			return nil, err
 		}
 		if dstIPStr, err = mechanism.DstIP(); err != nil {
-			return err
+			return nil, err
 		}
 		if localPort, err = mechanism.SrcPort(); err != nil {
-			return err
+			return nil, err
 		}
 		if remotePort, err = mechanism.DstPort(); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	dstIP := net.ParseIP(dstIPStr)
-	localPrivateKey, err := wgtypes.ParseKey(localPrivateKeyStr)
+	remotePublicKey, err := wgtypes.ParseKey(remotePublicKeyStr)
 	if err != nil {
-		return errors.Errorf("failed to parse local private key: %v", err)
+		return nil, errors.Errorf("failed to parse remote public key: %v", err)
 	}
-	remotePublicKey, err := wgtypes.ParseKey(remotePublicKeyStr)
+	return &wireguardPeer{
+		publicKey:  remotePublicKey,
+		localPort:  localPort,
+		remotePort: remotePort,
+		dstIP:      net.ParseIP(dstIPStr),
+	}, nil
+}
+
+// createWireguardInterface creates a Wireguard interface
+func (c *Connect) createWireguardInterface(ifaceName string, remoteConnection *connection.Connection, direction uint8) error {
+	c.wireguardDevicesMutex.Lock()
+	defer c.wireguardDevicesMutex.Unlock()
+
+	/* Create interface - host namespace */
+	peer, err := newWireguardPeer(remoteConnection, direction)
 	if err != nil {
-		return errors.Errorf("failed to parse remote public key: %v", err)
+		return err
+	}
+	localPrivateKey, err := c.localPrivateKey(remoteConnection, direction)
+	if err != nil {
+		return errors.Errorf("failed to get local private key: %v", err)
 	}
 
 	wgDevice, err := createWireguardDevice(ifaceName)
 	if err != nil {
 		return errors.Errorf("Wireguard error: %v", err)
 	}
-	c.wireguardDevices[ifaceName] = wgDevice
 	uapi, err := startWireguardAPI(ifaceName, wgDevice)
 	if err != nil {
 		wgDevice.Close()
 		return errors.Errorf("Wireguard error: %v", err)
 	}
-	defer func() {
-		if uapiErr := uapi.Close(); uapiErr != nil {
-			logrus.Errorf("Wireguard error: failed to close API client %v", uapiErr)
-		}
-	}()
+	wgd := &wireguardDevice{
+		device:     wgDevice,
+		uapi:       uapi,
+		privateKey: localPrivateKey,
+		peer:       peer,
+	}
 
-	err = configureWireguardDevice(ifaceName, localPrivateKey, remotePublicKey, localPort, remotePort, dstIP)
+	err = c.configureDevice(ifaceName, localPrivateKey, peer)
 	if err != nil {
-		wgDevice.Close()
+		wgd.close()
 		return errors.Errorf("Wireguard error: %v", err)
 	}
+	c.wireguardDevices[ifaceName] = wgd
 
 	return nil
 }
 
+// updateWireguardInterface updates keys and peer of the existing Wireguard interface without tearing it down,
+// returns false if there is no such interface. The interface is not reconfigured if neither the keys nor the peer
+// change, since a new configuration drops the established Wireguard session
+func (c *Connect) updateWireguardInterface(ifaceName string, remoteConnection *connection.Connection, direction uint8) (bool, error) {
+	c.wireguardDevicesMutex.Lock()
+	defer c.wireguardDevicesMutex.Unlock()
+
+	wgd, ok := c.wireguardDevices[ifaceName]
+	if !ok {
+		return false, nil
+	}
+	peer, err := newWireguardPeer(remoteConnection, direction)
+	if err != nil {
+		return true, err
+	}
+	localPrivateKey, err := c.localPrivateKey(remoteConnection, direction)
+	if err != nil {
+		return true, errors.Errorf("failed to get local private key: %v", err)
+	}
+	if localPrivateKey == wgd.privateKey && peer.equal(wgd.peer) {
+		return true, nil
+	}
+	if err = c.configureDevice(ifaceName, localPrivateKey, peer); err != nil {
+		return true, errors.Errorf("Wireguard error: %v", err)
+	}
+	wgd.privateKey = localPrivateKey
+	wgd.peer = peer
+	c.releasePreviousKeys()
+	return true, nil
+}
+
+// WireguardPublicKey returns the public key of the forwarder, the private key is never
+// exposed and is used only to configure local Wireguard devices
+func (c *Connect) WireguardPublicKey() (string, error) {
+	c.wireguardDevicesMutex.Lock()
+	defer c.wireguardDevicesMutex.Unlock()
+	key, err := c.privateKey()
+	if err != nil {
+		return "", err
+	}
+	return key.PublicKey().String(), nil
+}
+
+// RotateWireguardKey generates a new key pair of the forwarder and returns its public key. The previous key stays
+// valid while any Wireguard interface uses it: the interface switches to the new key only when it is updated with
+// the connection carrying the new public key, and the remote side is updated with the same connection
+func (c *Connect) RotateWireguardKey() (string, error) {
+	c.wireguardDevicesMutex.Lock()
+	defer c.wireguardDevicesMutex.Unlock()
+	key, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate private key")
+	}
+	if c.wireguardKey != nil {
+		c.wireguardPreviousKeys[c.wireguardKey.PublicKey()] = *c.wireguardKey
+	}
+	c.wireguardKey = &key
+	c.releasePreviousKeys()
+	return key.PublicKey().String(), nil
+}
+
+// privateKey returns the forwarder private key, generating it on the first call. Should be called under wireguardDevicesMutex
+func (c *Connect) privateKey() (wgtypes.Key, error) {
+	if c.wireguardKey == nil {
+		key, err := wgtypes.GeneratePrivateKey()
+		if err != nil {
+			return wgtypes.Key{}, errors.Wrap(err, "failed to generate private key")
+		}
+		c.wireguardKey = &key
+	}
+	return *c.wireguardKey, nil
+}
+
+// localPrivateKey returns the private key matching the local public key of the connection, the remote side is
+// configured with it: the current key or a previous one. Should be called under wireguardDevicesMutex
+func (c *Connect) localPrivateKey(remoteConnection *connection.Connection, direction uint8) (wgtypes.Key, error) {
+	mechanism := wireguard.ToMechanism(remoteConnection.GetMechanism())
+
+	var localPublicKeyStr string
+	var err error
+	if direction == INCOMING {
+		localPublicKeyStr, err = mechanism.DstPublicKey()
+	} else {
+		localPublicKeyStr, err = mechanism.SrcPublicKey()
+	}
+	if err != nil {
+		return wgtypes.Key{}, err
+	}
+	localPublicKey, err := wgtypes.ParseKey(localPublicKeyStr)
+	if err != nil {
+		return wgtypes.Key{}, errors.Errorf("failed to parse local public key: %v", err)
+	}
+
+	key, err := c.privateKey()
+	if err != nil {
+		return wgtypes.Key{}, err
+	}
+	if key.PublicKey() == localPublicKey {
+		return key, nil
+	}
+	if previousKey, ok := c.wireguardPreviousKeys[localPublicKey]; ok {
+		return previousKey, nil
+	}
+	return wgtypes.Key{}, errors.Errorf("unknown local public key %v", localPublicKey)
+}
+
+// releasePreviousKeys forgets the previous keys no Wireguard interface uses anymore. Should be called under
+// wireguardDevicesMutex
+func (c *Connect) releasePreviousKeys() {
+	used := make(map[wgtypes.Key]bool, len(c.wireguardDevices))
+	for _, wgd := range c.wireguardDevices {
+		used[wgd.privateKey.PublicKey()] = true
+	}
+	for publicKey := range c.wireguardPreviousKeys {
+		if !used[publicKey] {
+			delete(c.wireguardPreviousKeys, publicKey)
+		}
+	}
+}
+
 func (c *Connect) deleteWireguardInterface(ifaceName string) error {
 	c.wireguardDevicesMutex.Lock()
 	defer c.wireguardDevicesMutex.Unlock()
-	if wgDevice, ok := c.wireguardDevices[ifaceName]; ok {
-		wgDevice.Close()
+	if wgd, ok := c.wireguardDevices[ifaceName]; ok {
+		wgd.close()
 		delete(c.wireguardDevices, ifaceName)
+		c.releasePreviousKeys()
 	}
 
 	return nil
@@ -159,7 +316,8 @@ func startWireguardAPI(ifaceName string, wgDevice *device.Device) (net.Listener,
 	return uapi, nil
 }
 
-func configureWireguardDevice(ifaceName string, localPrivateKey, remotePublicKey wgtypes.Key, localPort, remotePort int, dstIP net.IP) error {
+// configureWireguardDevice applies the local key and the peer to the device, previous peers are replaced
+func configureWireguardDevice(ifaceName string, localPrivateKey wgtypes.Key, peer *wireguardPeer) error {
 	client, err := wgctrl.New()
 	if err != nil {
 		return errors.Errorf("failed to create configuration client: %v", err)
@@ -175,17 +333,18 @@ func configureWireguardDevice(ifaceName string, localPrivateKey, remotePublicKey
 		return errors.Errorf("failed to configure device: %v", err)
 	}
 	err = client.ConfigureDevice(ifaceName, wgtypes.Config{
-		ListenPort: intPtr(localPort),
-		PrivateKey: &localPrivateKey,
+		ListenPort:   intPtr(peer.localPort),
+		PrivateKey:   &localPrivateKey,
+		ReplacePeers: true,
 		Peers: []wgtypes.PeerConfig{
 			{
-				PublicKey: remotePublicKey,
+				PublicKey: peer.publicKey,
 				AllowedIPs: []net.IPNet{
 					*ipnet,
 				},
 				Endpoint: &net.UDPAddr{
-					IP:   dstIP,
-					Port: remotePort,
+					IP:   peer.dstIP,
+					Port: peer.remotePort,
 				},
 			},
 		},
@@ -0,0 +1,120 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/wireguard"
+)
+
+const (
+	srcInterface = "nsm-src"
+	dstInterface = "nsm-dst"
+)
+
+func wireguardConnection(srcPublicKey, dstPublicKey string) *connection.Connection {
+	return &connection.Connection{
+		Id: "1",
+		Mechanism: &connection.Mechanism{
+			Type: wireguard.MECHANISM,
+			Parameters: map[string]string{
+				wireguard.SrcIP:        "10.0.0.1",
+				wireguard.DstIP:        "10.0.0.2",
+				wireguard.SrcPort:      "51820",
+				wireguard.DstPort:      "51821",
+				wireguard.SrcPublicKey: srcPublicKey,
+				wireguard.DstPublicKey: dstPublicKey,
+			},
+		},
+	}
+}
+
+// newTestConnect creates Connect counting the configurations of its Wireguard devices instead of applying them
+func newTestConnect(configured map[string]int) *Connect {
+	c := NewConnect()
+	c.configureDevice = func(ifaceName string, _ wgtypes.Key, _ *wireguardPeer) error {
+		configured[ifaceName]++
+		return nil
+	}
+	return c
+}
+
+// addTestInterface adds the Wireguard interface configured for the connection without creating the device
+func addTestInterface(g *WithT, c *Connect, ifaceName string, conn *connection.Connection, direction uint8) {
+	peer, err := newWireguardPeer(conn, direction)
+	g.Expect(err).To(BeNil())
+	key, err := c.localPrivateKey(conn, direction)
+	g.Expect(err).To(BeNil())
+	c.wireguardDevices[ifaceName] = &wireguardDevice{privateKey: key, peer: peer}
+}
+
+// expectSession checks that each side of the connection has the public key of the other side as the peer, so the
+// Wireguard handshake succeeds and the traffic goes through
+func expectSession(g *WithT, src, dst *Connect) {
+	srcDevice, dstDevice := src.wireguardDevices[srcInterface], dst.wireguardDevices[dstInterface]
+	g.Expect(dstDevice.peer.publicKey).To(Equal(srcDevice.privateKey.PublicKey()))
+	g.Expect(srcDevice.peer.publicKey).To(Equal(dstDevice.privateKey.PublicKey()))
+}
+
+func TestWireguardKeyRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	configured := map[string]int{}
+	src, dst := newTestConnect(configured), newTestConnect(configured)
+	srcPublicKey, err := src.WireguardPublicKey()
+	g.Expect(err).To(BeNil())
+	dstPublicKey, err := dst.WireguardPublicKey()
+	g.Expect(err).To(BeNil())
+
+	conn := wireguardConnection(srcPublicKey, dstPublicKey)
+	addTestInterface(g, src, srcInterface, conn, OUTGOING)
+	addTestInterface(g, dst, dstInterface, conn, INCOMING)
+	expectSession(g, src, dst)
+
+	// Source forwarder rotates its key, the connection keeps the previous one until it is re-requested
+	newSrcPublicKey, err := src.RotateWireguardKey()
+	g.Expect(err).To(BeNil())
+	g.Expect(src.wireguardPreviousKeys).To(HaveLen(1))
+
+	ok, err := src.UpdateInterface(srcInterface, conn, OUTGOING)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(err).To(BeNil())
+	g.Expect(configured[srcInterface]).To(Equal(0))
+	expectSession(g, src, dst)
+
+	// NSM re-requests the connection with the new key, both sides are updated with it
+	conn = wireguardConnection(newSrcPublicKey, dstPublicKey)
+	ok, err = dst.UpdateInterface(dstInterface, conn, INCOMING)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(err).To(BeNil())
+	ok, err = src.UpdateInterface(srcInterface, conn, OUTGOING)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(err).To(BeNil())
+	g.Expect(configured[srcInterface]).To(Equal(1))
+	g.Expect(configured[dstInterface]).To(Equal(1))
+	expectSession(g, src, dst)
+
+	// The previous key is not used anymore
+	g.Expect(src.wireguardPreviousKeys).To(BeEmpty())
+	_, err = src.UpdateInterface(srcInterface, wireguardConnection(srcPublicKey, dstPublicKey), OUTGOING)
+	g.Expect(err).NotTo(BeNil())
+}
@@ -62,6 +62,7 @@ const (
 	ForwarderSocketTypeKey               = "FORWARDER_SOCKET_TYPE"
 	ForwarderSocketTypeDefault           = "unix"
 	ForwarderSrcIPKey                    = "NSM_FORWARDER_SRC_IP"
+	ForwarderDrainTimeoutKey             = "FORWARDER_DRAIN_TIMEOUT"
 )
 
 // ForwarderConfig keeps the common configuration for a forwarding plane
@@ -76,6 +77,7 @@ type ForwarderConfig struct {
 	Mechanisms              *Mechanisms
 	MetricsEnabled          bool
 	MetricsPeriod           time.Duration
+	DrainTimeout            time.Duration
 	SrcIP                   net.IP
 	EgressInterface         EgressInterfaceType
 	GRPCserver              *grpc.Server
@@ -152,6 +154,14 @@ func createForwarderConfig(ctx context.Context, forwarderGoals *ForwarderProbeGo
 		span.Logger().Infof("MetricsPeriod: %v ", cfg.MetricsPeriod)
 	}
 
+	if val, ok := os.LookupEnv(ForwarderDrainTimeoutKey); ok {
+		drainTimeout, err := time.ParseDuration(val)
+		if err == nil {
+			cfg.DrainTimeout = drainTimeout
+		}
+		span.Logger().Infof("DrainTimeout: %v ", cfg.DrainTimeout)
+	}
+
 	srcIPStr, ok := os.LookupEnv(ForwarderSrcIPKey)
 	if !ok {
 		span.Logger().Fatalf("Env variable %s must be set to valid srcIP for use for tunnels from this Pod.  Consider using downward API to do so.", ForwarderSrcIPKey)
@@ -218,6 +228,7 @@ func CreateForwarder(ctx context.Context, dp NSMForwarder, forwarderGoals *Forwa
 	span.Logger().Infof("%s server serving", config.Name)
 	span.Logger().Info("Creating Forwarder Registrar Client...")
 	registrar := NewForwarderRegistrarClient(config.RegistrarSocketType, config.RegistrarSocket)
+	registrar.drainTimeout = config.DrainTimeout
 	registration := registrar.Register(span.Context(), config.Name, config.ForwarderSocket, nil, nil)
 	span.Logger().Info("Registered Forwarder Registrar Client")
 
@@ -35,6 +35,7 @@ var (
 type ForwarderRegistrarClient struct {
 	registrationRetryInterval time.Duration
 	registrarSocket           net.Addr
+	drainTimeout              time.Duration
 }
 
 // ForwarderRegistration contains Forwarder registrar client info and connection events callbacks
@@ -144,8 +145,32 @@ func (dr *ForwarderRegistration) livenessMonitor(ctx context.Context) {
 	}
 }
 
-// Close forwarder registrar client
+// drain asks NSM to move all connections of the forwarder to other forwarders
+func (dr *ForwarderRegistration) drain() {
+	ctx, cancel := context.WithTimeout(context.Background(), dr.registrar.drainTimeout)
+	defer cancel()
+
+	conn, err := tools.DialContext(ctx, dr.registrar.registrarSocket)
+	if err != nil {
+		logrus.Errorf("%s: failure to communicate with the socket %v with error: %+v", dr.forwarderName, dr.registrar.registrarSocket, err)
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	client := forwarderregistrar.NewForwarderDrainClient(conn)
+
+	logrus.Infof("%s: draining forwarder with timeout %v", dr.forwarderName, dr.registrar.drainTimeout)
+	if _, err = client.RequestForwarderDrain(ctx, &forwarderregistrar.ForwarderDrainRequest{
+		ForwarderName: dr.forwarderName,
+	}); err != nil {
+		logrus.Errorf("%s: failure to drain forwarder with error: %+v", dr.forwarderName, err)
+	}
+}
+
+// Close forwarder registrar client, forwarder is drained first if drain timeout is set
 func (dr *ForwarderRegistration) Close() {
+	if dr.registrar.drainTimeout > 0 {
+		dr.drain()
+	}
 	dr.cancelFunc()
 
 	if dr.wasRegistered {
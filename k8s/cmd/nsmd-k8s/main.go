@@ -7,6 +7,9 @@ import (
 	"strings"
 
 	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/registryserver"
 	k8s_utils "github.com/networkservicemesh/networkservicemesh/k8s/pkg/utils"
@@ -47,13 +50,20 @@ func main() {
 	span.LogValue("NODE_NAME", nsmName)
 	span.Logger().Println("Starting NSMD Kubernetes on " + address + " with NsmName " + nsmName)
 
-	nsmClientSet, _, err := k8s_utils.NewClientSet()
+	nsmClientSet, config, err := k8s_utils.NewClientSet()
 	if err != nil {
 		span.LogError(err)
 		span.Logger().Fatalln("Fail to start NSMD Kubernetes service", err)
 	}
 
-	server := registryserver.New(span.Context(), nsmClientSet, nsmName)
+	nodeLabels, err := getNodeLabels(config, nsmName)
+	if err != nil {
+		// Topology aware endpoint selection will consider only local endpoints of this node
+		span.Logger().Warnf("Failed to get labels of the node %v: %v", nsmName, err)
+	}
+	span.LogObject("nodeLabels", nodeLabels)
+
+	server := registryserver.New(span.Context(), nsmClientSet, nsmName, nodeLabels)
 
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
@@ -72,3 +82,15 @@ func main() {
 	span.Finish()
 	<-c
 }
+
+func getNodeLabels(config *rest.Config, nodeName string) (map[string]string, error) {
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	node, err := clientset.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return node.GetLabels(), nil
+}
@@ -25,18 +25,36 @@ type NetworkService struct {
 }
 
 type NetworkServiceSpec struct {
-	Payload string   `json:"payload"`
-	Matches []*Match `json:"matches"`
+	Payload           string      `json:"payload"`
+	Matches           []*Match    `json:"matches"`
+	SelectionStrategy string      `json:"selectionStrategy,omitempty"`
+	TopologyKeys      []string    `json:"topologyKeys,omitempty"`
+	AffinityLabels    []string    `json:"affinityLabels,omitempty"`
+	HealPolicy        *HealPolicy `json:"healPolicy,omitempty"`
+}
+
+// HealPolicy overrides the default heal policy of NSMgr for the connections to the Network Service
+type HealPolicy struct {
+	InitialBackoff    *metaV1.Duration `json:"initialBackoff,omitempty"`
+	MaxBackoff        *metaV1.Duration `json:"maxBackoff,omitempty"`
+	BackoffMultiplier float64          `json:"backoffMultiplier,omitempty"`
+	Jitter            float64          `json:"jitter,omitempty"`
+	MaxAttempts       uint32           `json:"maxAttempts,omitempty"`
+	Deadline          *metaV1.Duration `json:"deadline,omitempty"`
+	// Endpoint is one of reselect, wait-same
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 type Match struct {
-	SourceSelector map[string]string `json:"sourceSelector,omitempty"`
-	Routes         []*Destination    `json:"route"`
+	SourceSelector         map[string]string                 `json:"sourceSelector,omitempty"`
+	SourceMatchExpressions []metaV1.LabelSelectorRequirement `json:"sourceMatchExpressions,omitempty"`
+	Routes                 []*Destination                    `json:"route"`
 }
 
 type Destination struct {
-	DestinationSelector map[string]string `json:"destinationSelector,omitempty"`
-	Weight              uint32            `json:"weight,omitempty"`
+	DestinationSelector         map[string]string                 `json:"destinationSelector,omitempty"`
+	DestinationMatchExpressions []metaV1.LabelSelectorRequirement `json:"destinationMatchExpressions,omitempty"`
+	Weight                      uint32                            `json:"weight,omitempty"`
 }
 
 type NetworkServiceStatus struct{}
@@ -63,6 +81,7 @@ type NetworkServiceEndpointSpec struct {
 	NetworkServiceName string `json:"networkservicename"`
 	Payload            string `json:"payload"`
 	NsmName            string `json:"nsmname"`
+	MaxConnections     uint32 `json:"maxConnections,omitempty"`
 }
 
 type NetworkServiceEndpointStatus struct {
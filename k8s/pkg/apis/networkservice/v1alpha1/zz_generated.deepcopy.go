@@ -21,6 +21,7 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -34,6 +35,13 @@ func (in *Destination) DeepCopyInto(out *Destination) {
 			(*out)[key] = val
 		}
 	}
+	if in.DestinationMatchExpressions != nil {
+		in, out := &in.DestinationMatchExpressions, &out.DestinationMatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -47,6 +55,37 @@ func (in *Destination) DeepCopy() *Destination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealPolicy) DeepCopyInto(out *HealPolicy) {
+	*out = *in
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Deadline != nil {
+		in, out := &in.Deadline, &out.Deadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealPolicy.
+func (in *HealPolicy) DeepCopy() *HealPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HealPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Match) DeepCopyInto(out *Match) {
 	*out = *in
@@ -57,6 +96,13 @@ func (in *Match) DeepCopyInto(out *Match) {
 			(*out)[key] = val
 		}
 	}
+	if in.SourceMatchExpressions != nil {
+		in, out := &in.SourceMatchExpressions, &out.SourceMatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Routes != nil {
 		in, out := &in.Routes, &out.Routes
 		*out = make([]*Destination, len(*in))
@@ -343,6 +389,21 @@ func (in *NetworkServiceSpec) DeepCopyInto(out *NetworkServiceSpec) {
 			}
 		}
 	}
+	if in.TopologyKeys != nil {
+		in, out := &in.TopologyKeys, &out.TopologyKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AffinityLabels != nil {
+		in, out := &in.AffinityLabels, &out.AffinityLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealPolicy != nil {
+		in, out := &in.HealPolicy, &out.HealPolicy
+		*out = new(HealPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
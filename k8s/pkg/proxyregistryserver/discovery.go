@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	utils "github.com/networkservicemesh/networkservicemesh/utils/interdomain"
@@ -15,6 +16,7 @@ import (
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/clusterinfo"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/serviceregistry"
 	"github.com/networkservicemesh/networkservicemesh/k8s/pkg/registryserver"
 )
 
@@ -45,16 +47,10 @@ func (d *discoveryService) FindNetworkService(ctx context.Context, request *regi
 	if err == nil {
 		originNetworkService := request.NetworkServiceName
 
-		remoteDomain, err = utils.ResolveDomain(remoteDomain)
-		if err != nil {
-			return nil, err
+		remoteRegistry, rErr := newRemoteRegistry(remoteDomain)
+		if rErr != nil {
+			return nil, rErr
 		}
-
-		remoteNsrPort := os.Getenv(ProxyNsmdK8sRemotePortEnv)
-		if strings.TrimSpace(remoteNsrPort) == "" {
-			remoteNsrPort = ProxyNsmdK8sRemotePortDefaults
-		}
-		remoteRegistry := nsmd.NewServiceRegistryAt(remoteDomain + ":" + remoteNsrPort)
 		defer remoteRegistry.Stop()
 
 		discoveryClient, dErr := remoteRegistry.DiscoveryClient(context.Background())
@@ -70,23 +66,7 @@ func (d *discoveryService) FindNetworkService(ctx context.Context, request *regi
 		if dErr != nil {
 			return nil, dErr
 		}
-		managers := make(map[string]*registry.NetworkServiceManager)
-		for key, nsm := range response.NetworkServiceManagers {
-			if url, urlErr := d.currentDomainNSMgrURL(ctx, d.clusterInfoService, nsm.Url); urlErr == nil && nsm.Url == url {
-				d.localizeNSMgr(response, nsm, url)
-				managers[nsm.Name] = nsm
-				continue
-			}
-			managers[key] = nsm
-			nsm.Name = fmt.Sprintf("%s@%s", nsm.Name, nsm.Url)
-			nsmURL := os.Getenv(ProxyNsmdAPIAddressEnv)
-			if strings.TrimSpace(nsmURL) == "" {
-				nsmURL = ProxyNsmdAPIAddressDefaults
-			}
-			nsm.Url = nsmURL
-			response.NetworkService.Name = originNetworkService
-		}
-		response.NetworkServiceManagers = managers
+		d.proxyRemoteResponse(ctx, response, originNetworkService)
 		logrus.Infof("Received response: %v", response)
 		return response, nil
 	}
@@ -95,8 +75,91 @@ func (d *discoveryService) FindNetworkService(ctx context.Context, request *regi
 	if err != nil {
 		return response, err
 	}
+	d.externalizeNSMgrURLs(ctx, response)
+	return response, err
+}
+
+func (d *discoveryService) WatchNetworkService(request *registry.FindNetworkServiceRequest, stream registry.NetworkServiceDiscovery_WatchNetworkServiceServer) error {
+	ctx := stream.Context()
+	networkService, remoteDomain, err := utils.ParseNsmURL(request.NetworkServiceName)
+	if err == nil {
+		originNetworkService := request.NetworkServiceName
 
-	// Swap NSMs IP to external
+		remoteRegistry, rErr := newRemoteRegistry(remoteDomain)
+		if rErr != nil {
+			return rErr
+		}
+		defer remoteRegistry.Stop()
+
+		discoveryClient, dErr := remoteRegistry.DiscoveryClient(context.Background())
+		if dErr != nil {
+			logrus.Error(dErr)
+			return dErr
+		}
+
+		logrus.Infof("Transfer watch to %v: %v", remoteDomain, request)
+		remoteStream, dErr := discoveryClient.WatchNetworkService(ctx, &registry.FindNetworkServiceRequest{
+			NetworkServiceName: networkService,
+		})
+		if dErr != nil {
+			return dErr
+		}
+		for {
+			response, recvErr := remoteStream.Recv()
+			if recvErr != nil {
+				return recvErr
+			}
+			d.proxyRemoteResponse(ctx, response, originNetworkService)
+			if sendErr := stream.Send(response); sendErr != nil {
+				return sendErr
+			}
+		}
+	}
+
+	return registryserver.WatchNetworkServiceWithCache(ctx, d.cache, request.NetworkServiceName, func(response *registry.FindNetworkServiceResponse) error {
+		// The cached response is compared with the next ones, so it should not be changed
+		response = proto.Clone(response).(*registry.FindNetworkServiceResponse)
+		d.externalizeNSMgrURLs(ctx, response)
+		return stream.Send(response)
+	})
+}
+
+func newRemoteRegistry(remoteDomain string) (serviceregistry.ServiceRegistry, error) {
+	remoteDomain, err := utils.ResolveDomain(remoteDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteNsrPort := os.Getenv(ProxyNsmdK8sRemotePortEnv)
+	if strings.TrimSpace(remoteNsrPort) == "" {
+		remoteNsrPort = ProxyNsmdK8sRemotePortDefaults
+	}
+	return nsmd.NewServiceRegistryAt(remoteDomain + ":" + remoteNsrPort), nil
+}
+
+// proxyRemoteResponse replaces remote domain NSMs in response with proxy NSMD
+func (d *discoveryService) proxyRemoteResponse(ctx context.Context, response *registry.FindNetworkServiceResponse, originNetworkService string) {
+	managers := make(map[string]*registry.NetworkServiceManager)
+	for key, nsm := range response.NetworkServiceManagers {
+		if url, urlErr := d.currentDomainNSMgrURL(ctx, d.clusterInfoService, nsm.Url); urlErr == nil && nsm.Url == url {
+			d.localizeNSMgr(response, nsm, url)
+			managers[nsm.Name] = nsm
+			continue
+		}
+		managers[key] = nsm
+		nsm.Name = fmt.Sprintf("%s@%s", nsm.Name, nsm.Url)
+		nsmURL := os.Getenv(ProxyNsmdAPIAddressEnv)
+		if strings.TrimSpace(nsmURL) == "" {
+			nsmURL = ProxyNsmdAPIAddressDefaults
+		}
+		nsm.Url = nsmURL
+		response.NetworkService.Name = originNetworkService
+	}
+	response.NetworkServiceManagers = managers
+}
+
+// externalizeNSMgrURLs swaps IPs of NSMs in response to external
+func (d *discoveryService) externalizeNSMgrURLs(ctx context.Context, response *registry.FindNetworkServiceResponse) {
 	for nsmName := range response.NetworkServiceManagers {
 		nodeConfiguration, cErr := d.clusterInfoService.GetNodeIPConfiguration(ctx, &clusterinfo.NodeIPConfiguration{NodeName: nsmName})
 		if cErr != nil {
@@ -116,7 +179,6 @@ func (d *discoveryService) FindNetworkService(ctx context.Context, request *regi
 		}
 		response.NetworkServiceManagers[nsmName].Url = externalIP
 	}
-	return response, err
 }
 
 func (d *discoveryService) localizeNSMgr(response *registry.FindNetworkServiceResponse, m *registry.NetworkServiceManager, url string) {
@@ -26,6 +26,8 @@ import (
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/clusterinfo"
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
@@ -221,3 +223,8 @@ func (rs *nseRegistryService) RemoveNSE(ctx context.Context, request *registry.R
 
 	return &empty.Empty{}, nil
 }
+
+// DrainNSE - draining is not supported for interdomain endpoints
+func (rs *nseRegistryService) DrainNSE(ctx context.Context, request *registry.DrainNSERequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "draining of interdomain NSE %s is not supported", request.GetNetworkServiceEndpointName())
+}
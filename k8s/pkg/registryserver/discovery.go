@@ -13,6 +13,9 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/selector"
+
+	"github.com/golang/protobuf/proto"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
 
@@ -61,9 +64,88 @@ func (d *discoveryService) FindNetworkService(ctx context.Context, request *regi
 	return FindNetworkServiceWithCache(d.cache, request.NetworkServiceName)
 }
 
+func (d *discoveryService) WatchNetworkService(request *registry.FindNetworkServiceRequest, stream registry.NetworkServiceDiscovery_WatchNetworkServiceServer) error {
+	span := spanhelper.FromContext(stream.Context(), "discovery.WatchNetworkService")
+	defer span.Finish()
+	span.LogObject("request", request)
+	if _, _, err := utils.ParseNsmURL(request.NetworkServiceName); err == nil {
+		nsrURL := os.Getenv(ProxyNsmdK8sAddressEnv)
+		if strings.TrimSpace(nsrURL) == "" {
+			nsrURL = ProxyNsmdK8sAddressDefaults
+		}
+		span.LogObject("nsrURL", nsrURL)
+		remoteRegistry := nsmd.NewServiceRegistryAt(nsrURL)
+		defer remoteRegistry.Stop()
+
+		discoveryClient, err := remoteRegistry.DiscoveryClient(span.Context())
+		if err != nil {
+			logrus.Error(err)
+			return err
+		}
+
+		logrus.Infof("Transfer watch to proxy nsmd-k8s: %v", request)
+		remoteStream, err := discoveryClient.WatchNetworkService(span.Context(), request)
+		if err != nil {
+			return err
+		}
+		for {
+			response, recvErr := remoteStream.Recv()
+			if recvErr != nil {
+				return recvErr
+			}
+			if sendErr := stream.Send(response); sendErr != nil {
+				return sendErr
+			}
+		}
+	}
+
+	return WatchNetworkServiceWithCache(span.Context(), d.cache, request.NetworkServiceName, stream.Send)
+}
+
 // FindNetworkServiceWithCache returns network service with name from registry cache
 func FindNetworkServiceWithCache(cache RegistryCache, networkServiceName string) (*registry.FindNetworkServiceResponse, error) {
 	st := time.Now()
+	response, err := networkServiceWithCache(cache, networkServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.NetworkServiceEndpoints) == 0 {
+		return nil, errors.Errorf("No valid endpoints found for the network service :%v", networkServiceName)
+	}
+
+	logrus.Infof("FindNetworkService done: time %v %v", time.Since(st), len(response.NetworkServiceEndpoints))
+	return response, nil
+}
+
+// WatchNetworkServiceWithCache sends network service with name from registry cache every time it is changed until
+// ctx is done or send fails. An empty response is sent while the network service is not found.
+func WatchNetworkServiceWithCache(ctx context.Context, cache RegistryCache, networkServiceName string, send func(*registry.FindNetworkServiceResponse) error) error {
+	watchCh := cache.Watch(ctx)
+	var last *registry.FindNetworkServiceResponse
+	for {
+		response, err := networkServiceWithCache(cache, networkServiceName)
+		if err != nil {
+			logrus.Warnf("Network service %v is not available: %v", networkServiceName, err)
+			response = &registry.FindNetworkServiceResponse{}
+		}
+		if last == nil || !proto.Equal(last, response) {
+			logrus.Infof("Sending network service %v update with %d endpoints", networkServiceName, len(response.NetworkServiceEndpoints))
+			if err = send(response); err != nil {
+				return err
+			}
+			last = response
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-watchCh:
+		}
+	}
+}
+
+func networkServiceWithCache(cache RegistryCache, networkServiceName string) (*registry.FindNetworkServiceResponse, error) {
 	service, err := cache.GetNetworkService(networkServiceName)
 	if err != nil {
 		return nil, err
@@ -76,7 +158,6 @@ func FindNetworkServiceWithCache(cache RegistryCache, networkServiceName string)
 	NSEs := []*registry.NetworkServiceEndpoint{}
 
 	NSMs := make(map[string]*registry.NetworkServiceManager)
-	endpointIds := []string{}
 	for _, endpoint := range endpointList {
 		// Verify the presence of the network service manager referenced
 		// by the endpoint.
@@ -87,44 +168,17 @@ func FindNetworkServiceWithCache(cache RegistryCache, networkServiceName string)
 		}
 		NSMs[endpoint.Spec.NsmName] = mapNsmFromCustomResource(nsm)
 		NSEs = append(NSEs, mapNseFromCustomResource(endpoint))
-		endpointIds = append(endpointIds, mapNseFromCustomResource(endpoint).GetName())
-	}
-
-	if len(endpointIds) == 0 {
-		return nil, errors.Errorf("No valid endpoints found for the network service :%v", networkServiceName)
 	}
 
-	var matches []*registry.Match
-
-	for _, m := range service.Spec.Matches {
-		var routes []*registry.Destination
-
-		for _, r := range m.Routes {
-			destination := &registry.Destination{
-				DestinationSelector: r.DestinationSelector,
-				Weight:              r.Weight,
-			}
-			routes = append(routes, destination)
-		}
-
-		match := &registry.Match{
-			SourceSelector: m.SourceSelector,
-			Routes:         routes,
-		}
-		matches = append(matches, match)
+	networkService := mapNetworkServiceFromCustomResource(service)
+	if err = selector.ValidateNetworkService(networkService); err != nil {
+		return nil, err
 	}
 
-	response := &registry.FindNetworkServiceResponse{
-		Payload: payload,
-		NetworkService: &registry.NetworkService{
-			Name:    service.ObjectMeta.Name,
-			Payload: service.Spec.Payload,
-			Matches: matches,
-		},
+	return &registry.FindNetworkServiceResponse{
+		Payload:                 payload,
+		NetworkService:          networkService,
 		NetworkServiceManagers:  NSMs,
 		NetworkServiceEndpoints: NSEs,
-	}
-
-	logrus.Infof("FindNetworkService done: time %v %v", time.Since(st), endpointIds)
-	return response, nil
+	}, nil
 }
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/networkservicemesh/networkservicemesh/utils"
@@ -20,7 +22,8 @@ const PodUidEnv = utils.EnvVar("POD_UID")
 func mapNsmToCustomResource(nsm *registry.NetworkServiceManager) *v1.NetworkServiceManager {
 	nsmCr := &v1.NetworkServiceManager{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: nsm.GetName(),
+			Name:   nsm.GetName(),
+			Labels: nsm.GetLabels(),
 		},
 		Spec: v1.NetworkServiceManagerSpec{
 			URL:            nsm.GetUrl(),
@@ -51,9 +54,10 @@ func mapNsmToCustomResource(nsm *registry.NetworkServiceManager) *v1.NetworkServ
 
 func mapNsmFromCustomResource(cr *v1.NetworkServiceManager) *registry.NetworkServiceManager {
 	return &registry.NetworkServiceManager{
-		Name:  cr.GetName(),
-		Url:   cr.Spec.URL,
-		State: string(cr.Status.State),
+		Name:   cr.GetName(),
+		Url:    cr.Spec.URL,
+		State:  string(cr.Status.State),
+		Labels: cr.GetLabels(),
 	}
 }
 
@@ -65,5 +69,69 @@ func mapNseFromCustomResource(cr *v1.NetworkServiceEndpoint) *registry.NetworkSe
 		Payload:                   cr.Spec.Payload,
 		Labels:                    cr.ObjectMeta.Labels,
 		State:                     string(cr.Status.State),
+		MaxConnections:            cr.Spec.MaxConnections,
 	}
 }
+
+func mapNetworkServiceFromCustomResource(cr *v1.NetworkService) *registry.NetworkService {
+	var matches []*registry.Match
+	for _, m := range cr.Spec.Matches {
+		var routes []*registry.Destination
+		for _, r := range m.Routes {
+			routes = append(routes, &registry.Destination{
+				DestinationSelector:         r.DestinationSelector,
+				DestinationMatchExpressions: mapMatchExpressionsFromCustomResource(r.DestinationMatchExpressions),
+				Weight:                      r.Weight,
+			})
+		}
+		matches = append(matches, &registry.Match{
+			SourceSelector:         m.SourceSelector,
+			SourceMatchExpressions: mapMatchExpressionsFromCustomResource(m.SourceMatchExpressions),
+			Routes:                 routes,
+		})
+	}
+
+	return &registry.NetworkService{
+		Name:              cr.GetName(),
+		Payload:           cr.Spec.Payload,
+		Matches:           matches,
+		SelectionStrategy: cr.Spec.SelectionStrategy,
+		TopologyKeys:      cr.Spec.TopologyKeys,
+		AffinityLabels:    cr.Spec.AffinityLabels,
+		HealPolicy:        mapHealPolicyFromCustomResource(cr.Spec.HealPolicy),
+	}
+}
+
+func mapHealPolicyFromCustomResource(policy *v1.HealPolicy) *registry.HealPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &registry.HealPolicy{
+		InitialBackoff:    mapDurationFromCustomResource(policy.InitialBackoff),
+		MaxBackoff:        mapDurationFromCustomResource(policy.MaxBackoff),
+		BackoffMultiplier: policy.BackoffMultiplier,
+		Jitter:            policy.Jitter,
+		MaxAttempts:       policy.MaxAttempts,
+		Deadline:          mapDurationFromCustomResource(policy.Deadline),
+		Endpoint:          policy.Endpoint,
+	}
+}
+
+func mapDurationFromCustomResource(d *metav1.Duration) *duration.Duration {
+	if d == nil {
+		return nil
+	}
+	return ptypes.DurationProto(d.Duration)
+}
+
+func mapMatchExpressionsFromCustomResource(requirements []metav1.LabelSelectorRequirement) []*registry.LabelSelectorRequirement {
+	var result []*registry.LabelSelectorRequirement
+	for _, requirement := range requirements {
+		result = append(result, &registry.LabelSelectorRequirement{
+			Key:      requirement.Key,
+			Operator: string(requirement.Operator),
+			Values:   requirement.Values,
+		})
+	}
+	return result
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/nsmd"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/pkg/selector"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context"
@@ -56,7 +57,7 @@ func (rs *nseRegistryService) RegisterNSE(ctx context.Context, request *registry
 	}
 	labels["networkservicename"] = request.GetNetworkService().GetName()
 	if request.GetNetworkServiceEndpoint() != nil && request.GetNetworkService() != nil {
-		_, err := rs.cache.AddNetworkService(&v1.NetworkService{
+		ns, err := rs.cache.AddNetworkService(&v1.NetworkService{
 			ObjectMeta: metav1.ObjectMeta{
 				Name: request.NetworkService.GetName(),
 			},
@@ -70,6 +71,12 @@ func (rs *nseRegistryService) RegisterNSE(ctx context.Context, request *registry
 			return nil, err
 		}
 
+		// Do not register endpoints of a network service which selectors will fail on endpoint selection
+		if err = selector.ValidateNetworkService(mapNetworkServiceFromCustomResource(ns)); err != nil {
+			logger.Errorf("Invalid network service: %v", err)
+			return nil, err
+		}
+
 		var objectMeta metav1.ObjectMeta
 		if request.GetNetworkServiceEndpoint().GetName() == "" {
 			objectMeta = metav1.ObjectMeta{
@@ -89,6 +96,7 @@ func (rs *nseRegistryService) RegisterNSE(ctx context.Context, request *registry
 				NetworkServiceName: request.GetNetworkService().GetName(),
 				Payload:            request.GetNetworkService().GetPayload(),
 				NsmName:            rs.nsmName,
+				MaxConnections:     request.GetNetworkServiceEndpoint().GetMaxConnections(),
 			},
 			Status: v1.NetworkServiceEndpointStatus{
 				State: v1.RUNNING,
@@ -199,6 +207,33 @@ func (rs *nseRegistryService) RemoveNSE(ctx context.Context, request *registry.R
 	return &empty.Empty{}, nil
 }
 
+// DrainNSE pauses NSE, so it is not selected for the new connections and NSMgrs move its connections to other NSEs
+func (rs *nseRegistryService) DrainNSE(ctx context.Context, request *registry.DrainNSERequest) (*empty.Empty, error) {
+	span := spanhelper.FromContext(ctx, "DrainNSE")
+	defer span.Finish()
+	logger := span.Logger()
+
+	logger.Infof("Received DrainNSE(%v)", request)
+
+	nse, err := rs.cache.GetNetworkServiceEndpoint(request.GetNetworkServiceEndpointName())
+	if err != nil {
+		span.LogError(err)
+		return nil, err
+	}
+	if nse.Status.State == v1.PAUSED {
+		return &empty.Empty{}, nil
+	}
+
+	nse = nse.DeepCopy()
+	nse.Status.State = v1.PAUSED
+	if _, err = rs.cache.UpdateNetworkServiceEndpoint(nse); err != nil {
+		span.LogError(err)
+		return nil, err
+	}
+	logger.Infof("NSE %v is paused", nse.GetName())
+	return &empty.Empty{}, nil
+}
+
 func (rs *nseRegistryService) forwardRegisterNSE(ctx context.Context, request *registry.NSERegistration) error {
 	span := spanhelper.FromContext(ctx, "ProxyNsmgr.forwardRegisterNSE")
 	defer span.Finish()
@@ -245,25 +280,7 @@ func (rs *nseRegistryService) forwardRegisterNSE(ctx context.Context, request *r
 		return err
 	}
 
-	request.NetworkService.Payload = service.Spec.Payload
-
-	for _, m := range service.Spec.Matches {
-		var routes []*registry.Destination
-
-		for _, r := range m.Routes {
-			destination := &registry.Destination{
-				DestinationSelector: r.DestinationSelector,
-				Weight:              r.Weight,
-			}
-			routes = append(routes, destination)
-		}
-
-		match := &registry.Match{
-			SourceSelector: m.SourceSelector,
-			Routes:         routes,
-		}
-		request.NetworkService.Matches = append(request.NetworkService.Matches, match)
-	}
+	request.NetworkService = mapNetworkServiceFromCustomResource(service)
 
 	_, err = nseRegistryClient.RegisterNSE(spanCtx, request)
 	if err != nil {
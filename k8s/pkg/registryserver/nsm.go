@@ -14,14 +14,16 @@ import (
 )
 
 type nsmRegistryService struct {
-	nsmName string
-	cache   RegistryCache
+	nsmName    string
+	nodeLabels map[string]string
+	cache      RegistryCache
 }
 
-func newNsmRegistryService(nsmName string, cache RegistryCache) *nsmRegistryService {
+func newNsmRegistryService(nsmName string, nodeLabels map[string]string, cache RegistryCache) *nsmRegistryService {
 	return &nsmRegistryService{
-		nsmName: nsmName,
-		cache:   cache,
+		nsmName:    nsmName,
+		nodeLabels: nodeLabels,
+		cache:      cache,
 	}
 }
 
@@ -29,6 +31,10 @@ func (n *nsmRegistryService) RegisterNSM(ctx context.Context, nsm *registry.Netw
 	span := spanhelper.FromContext(ctx, "RegisterNSM")
 	defer span.Finish()
 	span.LogObject("nsm", nsm)
+	if len(nsm.GetLabels()) == 0 {
+		// NSM has the same topology as the node it is running on
+		nsm.Labels = n.nodeLabels
+	}
 	nsmCr := mapNsmToCustomResource(nsm)
 	nsmCr.SetName(n.nsmName)
 
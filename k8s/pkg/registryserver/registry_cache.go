@@ -25,10 +25,14 @@ type RegistryCache interface {
 	GetNetworkServiceManager(name string) (*v1.NetworkServiceManager, error)
 
 	AddNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error)
+	UpdateNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error)
 	DeleteNetworkServiceEndpoint(endpointName string) error
+	GetNetworkServiceEndpoint(endpointName string) (*v1.NetworkServiceEndpoint, error)
 	GetEndpointsByNs(networkServiceName string) []*v1.NetworkServiceEndpoint
 	GetEndpointsByNsm(nsmName string) []*v1.NetworkServiceEndpoint
 
+	Watch(ctx context.Context) <-chan struct{}
+
 	Start() error
 	Stop()
 }
@@ -140,11 +144,26 @@ func (rc *registryCacheImpl) AddNetworkServiceEndpoint(nse *v1.NetworkServiceEnd
 	return nil, err
 }
 
+func (rc *registryCacheImpl) UpdateNetworkServiceEndpoint(nse *v1.NetworkServiceEndpoint) (*v1.NetworkServiceEndpoint, error) {
+	updNse, err := rc.clientset.NetworkserviceV1alpha1().NetworkServiceEndpoints(rc.nsmNamespace).Update(context.TODO(), nse, metav1.UpdateOptions{})
+	if err == nil {
+		rc.networkServiceEndpointCache.Update(updNse)
+	}
+	return updNse, err
+}
+
 func (rc *registryCacheImpl) DeleteNetworkServiceEndpoint(endpointName string) error {
 	rc.networkServiceEndpointCache.Delete(endpointName)
 	return rc.clientset.NetworkserviceV1alpha1().NetworkServiceEndpoints(rc.nsmNamespace).Delete(context.TODO(), endpointName, metav1.DeleteOptions{})
 }
 
+func (rc *registryCacheImpl) GetNetworkServiceEndpoint(endpointName string) (*v1.NetworkServiceEndpoint, error) {
+	if nse := rc.networkServiceEndpointCache.Get(endpointName); nse != nil {
+		return nse, nil
+	}
+	return rc.clientset.NetworkserviceV1alpha1().NetworkServiceEndpoints(rc.nsmNamespace).Get(context.TODO(), endpointName, metav1.GetOptions{})
+}
+
 func (rc *registryCacheImpl) GetEndpointsByNs(networkServiceName string) []*v1.NetworkServiceEndpoint {
 	return rc.networkServiceEndpointCache.GetByNetworkService(networkServiceName)
 }
@@ -178,6 +197,9 @@ func (rc *registryCacheImpl) CreateOrUpdateNetworkServiceManager(nsm *v1.Network
 			logrus.Infof("Updating existing NSM: %v with %v", existingNsm, nsm)
 			updNsm := nsm.DeepCopy()
 			updNsm.ObjectMeta = existingNsm.ObjectMeta
+			if nsm.Labels != nil {
+				updNsm.Labels = nsm.Labels
+			}
 			updNsm, err := rc.updateNetworkServiceManager(updNsm)
 			if err == nil || !apierrors.IsConflict(err) {
 				return updNsm, err
@@ -215,6 +237,31 @@ func (rc *registryCacheImpl) GetNetworkServiceManager(name string) (*v1.NetworkS
 	return rc.clientset.NetworkserviceV1alpha1().NetworkServiceManagers(rc.nsmNamespace).Get(context.TODO(), name, metav1.GetOptions{})
 }
 
+// Watch returns a channel signalled every time network services, endpoints or managers in the cache are changed until
+// ctx is done
+func (rc *registryCacheImpl) Watch(ctx context.Context) <-chan struct{} {
+	watchCh := make(chan struct{}, 1)
+	nsCh := rc.networkServiceCache.Watch(ctx)
+	nseCh := rc.networkServiceEndpointCache.Watch(ctx)
+	nsmCh := rc.networkServiceManagerCache.Watch(ctx)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-nsCh:
+			case <-nseCh:
+			case <-nsmCh:
+			}
+			select {
+			case watchCh <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return watchCh
+}
+
 func (rc *registryCacheImpl) Stop() {
 	for _, stopFunc := range rc.stopFuncs {
 		stopFunc()
@@ -51,6 +51,11 @@ func (c *NetworkServiceCache) Delete(key string) {
 	c.cache.delete(key)
 }
 
+// Watch returns a channel signalled every time network services in the cache are changed until ctx is done
+func (c *NetworkServiceCache) Watch(ctx context.Context) <-chan struct{} {
+	return c.cache.watch(ctx)
+}
+
 func (c *NetworkServiceCache) Start(f SharedInformerFactory, init ...v1.NetworkService) (func(), error) {
 	c.replace(init)
 	return c.cache.start(f)
@@ -29,6 +29,7 @@ func NewNetworkServiceEndpointCache(policy CacheFilterPolicy) *NetworkServiceEnd
 		keyFunc:             getNseKey,
 		resourceAddedFunc:   rv.resourceAdded,
 		resourceDeletedFunc: rv.resourceDeleted,
+		resourceUpdatedFunc: rv.resourceAdded,
 		resourceGetFunc:     rv.resourceGet,
 		resourceType:        NseResource,
 	}
@@ -69,10 +70,21 @@ func (c *NetworkServiceEndpointCache) Add(nse *v1.NetworkServiceEndpoint) {
 	c.cache.add(nse)
 }
 
+// Update replaces NSE in cache, f.e. if its state is changed
+func (c *NetworkServiceEndpointCache) Update(nse *v1.NetworkServiceEndpoint) {
+	logrus.Infof("Updating NSE in cache: %v", *nse)
+	c.cache.update(nse)
+}
+
 func (c *NetworkServiceEndpointCache) Delete(key string) {
 	c.cache.delete(key)
 }
 
+// Watch returns a channel signalled every time network service endpoints in the cache are changed until ctx is done
+func (c *NetworkServiceEndpointCache) Watch(ctx context.Context) <-chan struct{} {
+	return c.cache.watch(ctx)
+}
+
 func (c *NetworkServiceEndpointCache) Start(f SharedInformerFactory, init ...v1.NetworkServiceEndpoint) (func(), error) {
 	c.replace(init)
 	return c.cache.start(f)
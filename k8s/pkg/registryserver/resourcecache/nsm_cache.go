@@ -58,6 +58,11 @@ func (c *NetworkServiceManagerCache) Delete(key string) {
 	c.cache.delete(key)
 }
 
+// Watch returns a channel signalled every time network service managers in the cache are changed until ctx is done
+func (c *NetworkServiceManagerCache) Watch(ctx context.Context) <-chan struct{} {
+	return c.cache.watch(ctx)
+}
+
 func (c *NetworkServiceManagerCache) Start(f SharedInformerFactory, init ...v1.NetworkServiceManager) (func(), error) {
 	c.replace(init)
 	return c.cache.start(f)
@@ -1,6 +1,7 @@
 package resourcecache
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/sirupsen/logrus"
@@ -29,6 +30,8 @@ type abstractResourceCache struct {
 	eventCh              chan resourceEvent
 	config               cacheConfig
 	resourceFilterPolicy CacheFilterPolicy
+	// watchers are accessed from the cache event loop only
+	watchers map[chan struct{}]struct{}
 }
 
 const defaultChannelSize = 40
@@ -38,6 +41,7 @@ func newAbstractResourceCache(config cacheConfig, policy CacheFilterPolicy) abst
 		eventCh:              make(chan resourceEvent, defaultChannelSize),
 		config:               config,
 		resourceFilterPolicy: policy,
+		watchers:             make(map[chan struct{}]struct{}),
 	}
 }
 
@@ -78,11 +82,40 @@ func (c *abstractResourceCache) syncExec(f func()) {
 	<-doneCh
 }
 
+// watch returns a channel signalled after the cached resources are changed until ctx is done, subsequent changes
+// are coalesced until the channel is read
+func (c *abstractResourceCache) watch(ctx context.Context) <-chan struct{} {
+	watchCh := make(chan struct{}, 1)
+	c.syncExec(func() {
+		c.watchers[watchCh] = struct{}{}
+	})
+	go func() {
+		<-ctx.Done()
+		c.syncExec(func() {
+			delete(c.watchers, watchCh)
+		})
+	}()
+	return watchCh
+}
+
+func (c *abstractResourceCache) notifyWatchers() {
+	for watchCh := range c.watchers {
+		select {
+		case watchCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
 func (c *abstractResourceCache) run(stopCh chan struct{}) {
 	for {
 		select {
 		case e := <-c.eventCh:
 			e.accept(c.config)
+			switch e.(type) {
+			case resourceAddEvent, resourceUpdateEvent, resourceDeleteEvent:
+				c.notifyWatchers()
+			}
 		case <-stopCh:
 			return
 		}
@@ -122,11 +155,8 @@ func (c *abstractResourceCache) addEventHandlers(informer cache.SharedInformer)
 				return
 			}
 			logrus.Infof("Update from k8s-registry: %v", reflect.TypeOf(old))
-			if _, ok := old.(*v1.NetworkServiceManager); !ok {
-				return
-			}
-			logrus.Infof("Old NSM: %v", old.(*v1.NetworkServiceManager))
-			logrus.Infof("New NSM: %v", new.(*v1.NetworkServiceManager))
+			logrus.Infof("Old: %v", old)
+			logrus.Infof("New: %v", new)
 			c.update(new)
 		}
 	}
@@ -17,8 +17,8 @@ import (
 	nsmClientset "github.com/networkservicemesh/networkservicemesh/k8s/pkg/networkservice/clientset/versioned"
 )
 
-// New - construct a registration server
-func New(ctx context.Context, clientset *nsmClientset.Clientset, nsmName string) *grpc.Server {
+// New - construct a registration server, nodeLabels are used as labels of the registered NSM
+func New(ctx context.Context, clientset *nsmClientset.Clientset, nsmName string, nodeLabels map[string]string) *grpc.Server {
 	span := spanhelper.FromContext(ctx, "K8SServer.New")
 	defer span.Finish()
 	server := tools.NewServer(span.Context())
@@ -31,7 +31,7 @@ func New(ctx context.Context, clientset *nsmClientset.Clientset, nsmName string)
 	})
 
 	nseRegistry := newNseRegistryService(nsmName, cache)
-	nsmRegistry := newNsmRegistryService(nsmName, cache)
+	nsmRegistry := newNsmRegistryService(nsmName, nodeLabels, cache)
 	discovery := newDiscoveryService(cache)
 
 	registry.RegisterNetworkServiceRegistryServer(server, nseRegistry)
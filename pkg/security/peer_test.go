@@ -0,0 +1,50 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestPeerSpiffeID(t *testing.T) {
+	g := NewWithT(t)
+
+	provider := newTestProvider(g, "example.org", "nsmctl")
+	cert, err := x509.ParseCertificate(provider.cert.Certificate[0])
+	g.Expect(err).To(BeNil())
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+		},
+	})
+	g.Expect(PeerSpiffeID(ctx)).To(Equal("spiffe://example.org/nsmctl"))
+}
+
+func TestPeerSpiffeIDInsecure(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(PeerSpiffeID(context.Background())).To(Equal(""))
+	g.Expect(PeerSpiffeID(peer.NewContext(context.Background(), &peer.Peer{}))).To(Equal(""))
+}
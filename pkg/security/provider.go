@@ -19,8 +19,13 @@ package security
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 )
 
 type Provider interface {
 	GetTLSConfig(ctx context.Context) (*tls.Config, error)
+	// GetCertificate - returns X.509 SVID of the workload together with its private key
+	GetCertificate(ctx context.Context) (*tls.Certificate, error)
+	// GetRoots - returns trusted CA pools by trust domain ID, e.g. spiffe://example.org
+	GetRoots(ctx context.Context) (map[string]*x509.CertPool, error)
 }
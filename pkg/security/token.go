@@ -0,0 +1,326 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	algorithmES256 = "ES256"
+	algorithmRS256 = "RS256"
+	tokenType      = "JWT"
+	spiffeScheme   = "spiffe"
+)
+
+// PathClaims - claims of the token signed by the owner of the path segment
+type PathClaims struct {
+	// Issuer - SPIFFE ID of the workload signed the token
+	Issuer string `json:"iss"`
+	// Subject - SPIFFE ID of the workload the token is issued for, it is bound to the X.509 SVID the token is signed
+	// with, so a workload can't issue tokens on behalf of another one
+	Subject string `json:"sub"`
+	// Name - name of the path segment
+	Name string `json:"name"`
+	// ID - id of the path segment
+	ID string `json:"jti,omitempty"`
+	// ConnectionID - id of the requested connection the token is signed for
+	ConnectionID string `json:"cid,omitempty"`
+	// Audience - name of the next hop the request is sent to: next NSMgr or NSE. Empty if the signer doesn't know it,
+	// e.g. a client doesn't know the name of its NSMgr
+	Audience string `json:"aud,omitempty"`
+	// Previous - hash of the previous path segment token, see TokenHash
+	Previous string `json:"prev,omitempty"`
+	// IssuedAt - unix time the token was signed at
+	IssuedAt int64 `json:"iat"`
+	// ExpiresAt - unix time the token expires at
+	ExpiresAt int64 `json:"exp"`
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	// X509Chain - X.509 SVID of the signer followed by intermediate CAs, std base64 DER
+	X509Chain []string `json:"x5c"`
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// SignToken - signs claims with the X.509 SVID of the provider and returns JWT. Issuer and Subject are set to the
+// SPIFFE ID of the SVID, IssuedAt to the current time
+func SignToken(ctx context.Context, provider Provider, claims *PathClaims) (string, error) {
+	cert, err := provider.GetCertificate(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get X.509 SVID")
+	}
+	if len(cert.Certificate) == 0 {
+		return "", errors.New("X.509 SVID has no certificates")
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse X.509 SVID")
+	}
+	issuer, _, err := spiffeID(leaf)
+	if err != nil {
+		return "", err
+	}
+	signer, ok := cert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return "", errors.Errorf("unsupported private key type %T", cert.PrivateKey)
+	}
+
+	header := &tokenHeader{Type: tokenType}
+	switch signer.Public().(type) {
+	case *ecdsa.PublicKey:
+		header.Algorithm = algorithmES256
+	case *rsa.PublicKey:
+		header.Algorithm = algorithmRS256
+	default:
+		return "", errors.Errorf("unsupported public key type %T", signer.Public())
+	}
+	for _, der := range cert.Certificate {
+		header.X509Chain = append(header.X509Chain, base64.StdEncoding.EncodeToString(der))
+	}
+
+	claims.Issuer = issuer
+	claims.Subject = issuer
+	claims.IssuedAt = time.Now().Unix()
+
+	encodedHeader, err := encodeSegment(header)
+	if err != nil {
+		return "", err
+	}
+	encodedClaims, err := encodeSegment(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := encodedHeader + "." + encodedClaims
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign token")
+	}
+	if key, isECDSA := signer.Public().(*ecdsa.PublicKey); isECDSA {
+		// JWS uses R || S instead of ASN.1 for ECDSA signatures
+		if signature, err = ecdsaToJWS(signature, key); err != nil {
+			return "", err
+		}
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// VerifyToken - verifies that the token is signed by the X.509 SVID trusted by the provider, is issued for the SPIFFE ID
+// of the SVID, is not expired and returns its claims
+func VerifyToken(ctx context.Context, provider Provider, token string) (*PathClaims, error) {
+	claims, err := VerifyTokenSignature(ctx, provider, token)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, errors.Errorf("token of %s is expired", claims.Issuer)
+	}
+	return claims, nil
+}
+
+// VerifyTokenSignature - verifies the token as VerifyToken does, but accepts it if it is expired
+func VerifyTokenSignature(ctx context.Context, provider Provider, token string) (*PathClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	header := &tokenHeader{}
+	if err := decodeSegment(parts[0], header); err != nil {
+		return nil, err
+	}
+	if len(header.X509Chain) == 0 {
+		return nil, errors.New("token has no X.509 SVID")
+	}
+	var chain []*x509.Certificate
+	for _, encoded := range header.X509Chain {
+		der, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.Wrap(err, "malformed token X.509 chain")
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, errors.Wrap(err, "malformed token X.509 chain")
+		}
+		chain = append(chain, cert)
+	}
+	leaf := chain[0]
+
+	issuer, trustDomain, err := spiffeID(leaf)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifyChain(ctx, provider, issuer, trustDomain, chain); err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed token signature")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = verifySignature(header.Algorithm, leaf.PublicKey, digest[:], signature); err != nil {
+		return nil, err
+	}
+
+	claims := &PathClaims{}
+	if err = decodeSegment(parts[1], claims); err != nil {
+		return nil, err
+	}
+	if claims.Issuer != issuer {
+		return nil, errors.Errorf("token issuer %s doesn't match X.509 SVID %s", claims.Issuer, issuer)
+	}
+	if claims.Subject != issuer {
+		return nil, errors.Errorf("token subject %s doesn't match X.509 SVID %s", claims.Subject, issuer)
+	}
+	return claims, nil
+}
+
+// ParseToken - returns claims of the token without verification, the token should be verified with VerifyToken
+// before it is trusted
+func ParseToken(token string) (*PathClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	claims := &PathClaims{}
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// TokenHash - returns hash of the token used to chain tokens of the path
+func TokenHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func verifyChain(ctx context.Context, provider Provider, issuer, trustDomain string, chain []*x509.Certificate) error {
+	roots, err := provider.GetRoots(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get trusted roots")
+	}
+	pool, ok := roots[trustDomain]
+	if !ok {
+		return errors.Errorf("trust domain %s of %s is not trusted", trustDomain, issuer)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err = chain[0].Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return errors.Wrapf(err, "X.509 SVID of %s is not trusted", issuer)
+	}
+	return nil
+}
+
+func verifySignature(algorithm string, publicKey interface{}, digest, signature []byte) error {
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if algorithm != algorithmES256 || len(signature) != 2*size {
+			return errors.New("invalid token signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return errors.New("invalid token signature")
+		}
+	case *rsa.PublicKey:
+		if algorithm != algorithmRS256 {
+			return errors.New("invalid token signature")
+		}
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature); err != nil {
+			return errors.Wrap(err, "invalid token signature")
+		}
+	default:
+		return errors.Errorf("unsupported public key type %T", publicKey)
+	}
+	return nil
+}
+
+func ecdsaToJWS(signature []byte, key *ecdsa.PublicKey) ([]byte, error) {
+	parsed := &ecdsaSignature{}
+	if _, err := asn1.Unmarshal(signature, parsed); err != nil {
+		return nil, errors.Wrap(err, "failed to parse ECDSA signature")
+	}
+	size := (key.Curve.Params().BitSize + 7) / 8
+	result := make([]byte, 2*size)
+	r, s := parsed.R.Bytes(), parsed.S.Bytes()
+	if len(r) > size || len(s) > size {
+		return nil, errors.New("invalid ECDSA signature")
+	}
+	copy(result[size-len(r):size], r)
+	copy(result[2*size-len(s):], s)
+	return result, nil
+}
+
+func spiffeID(cert *x509.Certificate) (id, trustDomain string, err error) {
+	for _, uri := range cert.URIs {
+		if uri.Scheme == spiffeScheme {
+			return uri.String(), spiffeScheme + "://" + uri.Host, nil
+		}
+	}
+	return "", "", errors.Errorf("certificate %s has no SPIFFE ID", cert.Subject)
+}
+
+func encodeSegment(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode token")
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errors.Wrap(err, "malformed token")
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return errors.Wrap(err, "malformed token")
+	}
+	return nil
+}
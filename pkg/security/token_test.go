@@ -0,0 +1,213 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+type testProvider struct {
+	cert  *tls.Certificate
+	roots map[string]*x509.CertPool
+}
+
+func (p *testProvider) GetTLSConfig(ctx context.Context) (*tls.Config, error) {
+	return &tls.Config{Certificates: []tls.Certificate{*p.cert}}, nil
+}
+
+func (p *testProvider) GetCertificate(ctx context.Context) (*tls.Certificate, error) {
+	return p.cert, nil
+}
+
+func (p *testProvider) GetRoots(ctx context.Context) (map[string]*x509.CertPool, error) {
+	return p.roots, nil
+}
+
+func newTestProvider(g *WithT, trustDomain, id string) *testProvider {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).To(BeNil())
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: trustDomain},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	g.Expect(err).To(BeNil())
+	ca, err := x509.ParseCertificate(caDer)
+	g.Expect(err).To(BeNil())
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).To(BeNil())
+	uri, err := url.Parse("spiffe://" + trustDomain + "/" + id)
+	g.Expect(err).To(BeNil())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		URIs:         []*url.URL{uri},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	g.Expect(err).To(BeNil())
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	return &testProvider{
+		cert: &tls.Certificate{
+			Certificate: [][]byte{der},
+			PrivateKey:  key,
+		},
+		roots: map[string]*x509.CertPool{
+			"spiffe://" + trustDomain: pool,
+		},
+	}
+}
+
+func TestSignVerifyToken(t *testing.T) {
+	g := NewWithT(t)
+
+	provider := newTestProvider(g, "example.org", "nsmgr")
+	token, err := SignToken(context.Background(), provider, &PathClaims{
+		Name:      "nsmgr-1",
+		Previous:  TokenHash("previous"),
+		ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	})
+	g.Expect(err).To(BeNil())
+
+	claims, err := VerifyToken(context.Background(), provider, token)
+	g.Expect(err).To(BeNil())
+	g.Expect(claims.Issuer).To(Equal("spiffe://example.org/nsmgr"))
+	g.Expect(claims.Subject).To(Equal("spiffe://example.org/nsmgr"))
+	g.Expect(claims.Name).To(Equal("nsmgr-1"))
+	g.Expect(claims.Previous).To(Equal(TokenHash("previous")))
+
+	parsed, err := ParseToken(token)
+	g.Expect(err).To(BeNil())
+	g.Expect(parsed).To(Equal(claims))
+}
+
+func TestVerifyTokenUntrusted(t *testing.T) {
+	g := NewWithT(t)
+
+	signer := newTestProvider(g, "example.org", "nsmgr")
+	token, err := SignToken(context.Background(), signer, &PathClaims{
+		Name:      "nsmgr-1",
+		ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	})
+	g.Expect(err).To(BeNil())
+
+	// Same trust domain, but a different CA
+	_, err = VerifyToken(context.Background(), newTestProvider(g, "example.org", "nsmgr"), token)
+	g.Expect(err).NotTo(BeNil())
+
+	// Different trust domain
+	_, err = VerifyToken(context.Background(), newTestProvider(g, "other.org", "nsmgr"), token)
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestVerifyTokenForged(t *testing.T) {
+	g := NewWithT(t)
+
+	provider := newTestProvider(g, "example.org", "nsmgr")
+	token, err := SignToken(context.Background(), provider, &PathClaims{
+		Name:      "nsmgr-1",
+		ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	})
+	g.Expect(err).To(BeNil())
+
+	forged, err := SignToken(context.Background(), provider, &PathClaims{
+		Name:      "nsmgr-2",
+		ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	})
+	g.Expect(err).To(BeNil())
+
+	parts := strings.Split(token, ".")
+	forgedParts := strings.Split(forged, ".")
+	_, err = VerifyToken(context.Background(), provider, parts[0]+"."+forgedParts[1]+"."+parts[2])
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestVerifyTokenExpired(t *testing.T) {
+	g := NewWithT(t)
+
+	provider := newTestProvider(g, "example.org", "nsmgr")
+	token, err := SignToken(context.Background(), provider, &PathClaims{
+		Name:      "nsmgr-1",
+		ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+	})
+	g.Expect(err).To(BeNil())
+
+	_, err = VerifyToken(context.Background(), provider, token)
+	g.Expect(err).NotTo(BeNil())
+
+	claims, err := VerifyTokenSignature(context.Background(), provider, token)
+	g.Expect(err).To(BeNil())
+	g.Expect(claims.Name).To(Equal("nsmgr-1"))
+}
+
+func TestVerifyTokenSubject(t *testing.T) {
+	g := NewWithT(t)
+
+	provider := newTestProvider(g, "example.org", "nsmgr")
+	token, err := SignToken(context.Background(), provider, &PathClaims{
+		Subject:   "spiffe://example.org/nsc",
+		Name:      "nsmgr-1",
+		ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	})
+	g.Expect(err).To(BeNil())
+
+	// Subject is always bound to the SVID the token is signed with
+	claims, err := VerifyToken(context.Background(), provider, token)
+	g.Expect(err).To(BeNil())
+	g.Expect(claims.Subject).To(Equal("spiffe://example.org/nsmgr"))
+
+	// Token issued for another SPIFFE ID is rejected even if its signature is valid
+	claims.Subject = "spiffe://example.org/nsc"
+	encodedClaims, err := encodeSegment(claims)
+	g.Expect(err).To(BeNil())
+	signingInput := strings.Split(token, ".")[0] + "." + encodedClaims
+	digest := sha256.Sum256([]byte(signingInput))
+	key := provider.cert.PrivateKey.(*ecdsa.PrivateKey)
+	signature, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	g.Expect(err).To(BeNil())
+	signature, err = ecdsaToJWS(signature, &key.PublicKey)
+	g.Expect(err).To(BeNil())
+
+	_, err = VerifyToken(context.Background(), provider, signingInput+"."+base64.RawURLEncoding.EncodeToString(signature))
+	g.Expect(err).NotTo(BeNil())
+	g.Expect(err.Error()).To(ContainSubstring("token subject"))
+}
@@ -55,6 +55,7 @@ type NsmClient struct {
 	OutgoingConnections  []*connection.Connection
 	NscInterfaceName     string
 	tracerCloser         io.Closer
+	cancelRefresh        context.CancelFunc
 }
 
 // Connect with no retry and delay
@@ -104,6 +105,7 @@ func (nsmc *NsmClient) ConnectRetry(ctx context.Context, name, mechanism, descri
 				},
 			},
 			Labels: nsmc.ClientLabels,
+			Path:   nsmc.newPath(),
 		},
 		MechanismPreferences: []*connection.Mechanism{
 			outgoingMechanism,
@@ -119,6 +121,16 @@ func (nsmc *NsmClient) ConnectRetry(ctx context.Context, name, mechanism, descri
 		defer cancelProc()
 
 		attemptLogger := attemptSpan.Logger()
+		if nsmc.Configuration.ClientRefreshLeases {
+			if err = common.LeasePath(outgoingRequest.GetConnection().GetPath()); err != nil {
+				attemptSpan.LogError(err)
+				return nil, errors.Wrap(err, "nsm client: Failed to lease request path")
+			}
+		}
+		if err = common.SignPath(attempCtx, tools.GetConfig().SecurityProvider, outgoingRequest.GetConnection(), ""); err != nil {
+			attemptSpan.LogError(err)
+			return nil, errors.Wrap(err, "nsm client: Failed to sign request path")
+		}
 		attemptLogger.Infof("Requesting %v", outgoingRequest)
 		outgoingConnection, err = nsmc.NsClient.Request(attempCtx, outgoingRequest)
 
@@ -144,6 +156,79 @@ func (nsmc *NsmClient) ConnectRetry(ctx context.Context, name, mechanism, descri
 	return outgoingConnection, nil
 }
 
+func (nsmc *NsmClient) newPath() *connection.Path {
+	return &connection.Path{
+		PathSegments: []*connection.PathSegment{
+			{Name: nsmc.Configuration.PodName},
+		},
+	}
+}
+
+// refreshLeases periodically refreshes leases of the outgoing connections, so NSMgr doesn't close them
+func (nsmc *NsmClient) refreshLeases(ctx context.Context) {
+	ticker := time.NewTicker(common.LeaseRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			nsmc.refreshAllLeases(ctx)
+		}
+	}
+}
+
+// refreshAllLeases refreshes leases of the outgoing connections, the lock is not held during the requests so Connect
+// and Close are not blocked by them
+func (nsmc *NsmClient) refreshAllLeases(ctx context.Context) {
+	nsmc.Lock()
+	outgoingConnections := make([]*connection.Connection, len(nsmc.OutgoingConnections))
+	copy(outgoingConnections, nsmc.OutgoingConnections)
+	nsmc.Unlock()
+
+	for _, outgoingConnection := range outgoingConnections {
+		if !nsmc.isOutgoingConnection(outgoingConnection) {
+			// Connection is closed while the previous leases were refreshed
+			continue
+		}
+		if err := nsmc.refreshLease(ctx, outgoingConnection); err != nil {
+			logrus.Errorf("nsm client: Failed to refresh lease of connection %v: %v", outgoingConnection.GetId(), err)
+		}
+	}
+}
+
+func (nsmc *NsmClient) isOutgoingConnection(outgoingConnection *connection.Connection) bool {
+	nsmc.Lock()
+	defer nsmc.Unlock()
+	for _, c := range nsmc.OutgoingConnections {
+		if c == outgoingConnection {
+			return true
+		}
+	}
+	return false
+}
+
+func (nsmc *NsmClient) refreshLease(ctx context.Context, outgoingConnection *connection.Connection) error {
+	refreshConnection := outgoingConnection.Clone()
+	refreshConnection.Path = nsmc.newPath()
+	if err := common.LeasePath(refreshConnection.GetPath()); err != nil {
+		return err
+	}
+	if err := common.SignPath(ctx, tools.GetConfig().SecurityProvider, refreshConnection, ""); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ConnectTimeout)
+	defer cancel()
+	_, err := nsmc.NsClient.Request(ctx, &networkservice.NetworkServiceRequest{
+		Connection: refreshConnection,
+		MechanismPreferences: []*connection.Mechanism{
+			outgoingConnection.GetMechanism(),
+		},
+	})
+	return err
+}
+
 // Close will terminate a particular connection
 func (nsmc *NsmClient) Close(ctx context.Context, outgoingConnection *connection.Connection) error {
 	nsmc.Lock()
@@ -165,6 +250,7 @@ func (nsmc *NsmClient) Close(ctx context.Context, outgoingConnection *connection
 			arr = arr[:len(arr)-1]
 		}
 	}
+	nsmc.OutgoingConnections = arr
 	return err
 }
 
@@ -176,6 +262,8 @@ func (nsmc *NsmClient) Destroy(ctx context.Context) error {
 	span := spanhelper.FromContext(ctx, "Client.Destroy")
 	defer span.Finish()
 
+	nsmc.cancelRefresh()
+
 	err := nsmc.NsmConnection.Close()
 	span.LogError(errors.Wrap(err, "failed to close opentracing context"))
 	if nsmc.tracerCloser != nil {
@@ -209,5 +297,11 @@ func NewNSMClient(ctx context.Context, configuration *common.NSConfiguration) (*
 
 	client.NsmConnection = nsmConnection
 
+	refreshCtx, cancelRefresh := context.WithCancel(context.Background())
+	client.cancelRefresh = cancelRefresh
+	if configuration.ClientRefreshLeases {
+		go client.refreshLeases(refreshCtx)
+	}
+
 	return client, nil
 }
@@ -0,0 +1,132 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection/mechanisms/kernel"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/sdk/common"
+)
+
+type recordingNSClient struct {
+	sync.Mutex
+	requests []*networkservice.NetworkServiceRequest
+	// block holds the requests until it is closed if set
+	block chan struct{}
+}
+
+func (c *recordingNSClient) Request(ctx context.Context, request *networkservice.NetworkServiceRequest, _ ...grpc.CallOption) (*connection.Connection, error) {
+	c.Lock()
+	c.requests = append(c.requests, request)
+	block := c.block
+	c.Unlock()
+	if block != nil {
+		select {
+		case <-block:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	conn := request.GetConnection().Clone()
+	conn.Id = "1"
+	conn.Mechanism = request.GetMechanismPreferences()[0]
+	return conn, nil
+}
+
+func (c *recordingNSClient) Close(context.Context, *connection.Connection, ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func (c *recordingNSClient) lastRequest() *networkservice.NetworkServiceRequest {
+	c.Lock()
+	defer c.Unlock()
+	return c.requests[len(c.requests)-1]
+}
+
+func newTestClient(configuration *common.NSConfiguration) (*NsmClient, *recordingNSClient) {
+	nsClient := &recordingNSClient{}
+	return &NsmClient{
+		NsmConnection: &common.NsmConnection{
+			Configuration: configuration,
+			NsClient:      nsClient,
+		},
+		cancelRefresh: func() {},
+	}, nsClient
+}
+
+func TestConnectWithoutLease(t *testing.T) {
+	g := gomega.NewWithT(t)
+	g.Expect(os.Setenv("INSECURE", "true")).To(gomega.Succeed())
+
+	// Client connects and exits like nsm-init, nobody refreshes the lease
+	nsmClient, nsClient := newTestClient(&common.NSConfiguration{PodName: "nsc"})
+	_, err := nsmClient.Connect(context.Background(), "nsm", kernel.MECHANISM, "Primary interface")
+	g.Expect(err).To(gomega.BeNil())
+
+	path := nsClient.lastRequest().GetConnection().GetPath()
+	g.Expect(path.GetPathSegments()[0].GetExpires()).To(gomega.BeNil())
+	g.Expect(common.IsLeaseExpired(path, 0)).To(gomega.BeFalse())
+}
+
+func TestRefreshLeases(t *testing.T) {
+	g := gomega.NewWithT(t)
+	g.Expect(os.Setenv("INSECURE", "true")).To(gomega.Succeed())
+
+	nsmClient, nsClient := newTestClient(&common.NSConfiguration{PodName: "nsc", ClientRefreshLeases: true})
+	outgoingConnection, err := nsmClient.Connect(context.Background(), "nsm", kernel.MECHANISM, "Primary interface")
+	g.Expect(err).To(gomega.BeNil())
+	_, ok := common.LeaseExpires(nsClient.lastRequest().GetConnection().GetPath())
+	g.Expect(ok).To(gomega.BeTrue())
+
+	nsClient.block = make(chan struct{})
+	refreshed := make(chan struct{})
+	go func() {
+		nsmClient.refreshAllLeases(context.Background())
+		close(refreshed)
+	}()
+	g.Eventually(func() int {
+		nsClient.Lock()
+		defer nsClient.Unlock()
+		return len(nsClient.requests)
+	}).Should(gomega.Equal(2))
+
+	// Lease refresh in progress doesn't block the client
+	closed := make(chan error, 1)
+	go func() {
+		closed <- nsmClient.Close(context.Background(), outgoingConnection)
+	}()
+	g.Eventually(closed, time.Second).Should(gomega.Receive(gomega.BeNil()))
+
+	close(nsClient.block)
+	g.Eventually(refreshed).Should(gomega.BeClosed())
+
+	request := nsClient.lastRequest()
+	g.Expect(request.GetConnection().GetId()).To(gomega.Equal(outgoingConnection.GetId()))
+	g.Expect(common.IsLeaseRefresh(request, outgoingConnection)).To(gomega.BeTrue())
+	g.Expect(nsmClient.OutgoingConnections).To(gomega.BeEmpty())
+}
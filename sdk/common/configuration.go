@@ -16,7 +16,11 @@
 package common
 
 import (
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
 
 	"github.com/networkservicemesh/networkservicemesh/pkg/tools"
 )
@@ -25,8 +29,11 @@ const (
 	namespaceEnv              = "NSM_NAMESPACE"
 	endpointNetworkServiceEnv = "ENDPOINT_NETWORK_SERVICE"
 	endpointLabelsEnv         = "ENDPOINT_LABELS"
+	endpointMaxConnectionsEnv = "ENDPOINT_MAX_CONNECTIONS"
+	endpointDrainTimeoutEnv   = "ENDPOINT_DRAIN_TIMEOUT"
 	clientNetworkServiceEnv   = "CLIENT_NETWORK_SERVICE"
 	clientLabelsEnv           = "CLIENT_LABELS"
+	clientRefreshLeasesEnv    = "CLIENT_REFRESH_LEASES"
 	nscInterfaceNameEnv       = "NSC_INTERFACE_NAME"
 	mechanismTypeEnv          = "MECHANISM_TYPE"
 	ipAddressEnv              = "IP_ADDRESS"
@@ -42,7 +49,10 @@ type NSConfiguration struct {
 	EndpointNetworkService string
 	ClientNetworkService   string
 	EndpointLabels         string
+	EndpointMaxConnections uint32
+	EndpointDrainTimeout   time.Duration
 	ClientLabels           string
+	ClientRefreshLeases    bool
 	NscInterfaceName       string
 	MechanismType          string
 	IPAddress              string
@@ -86,10 +96,43 @@ func (configuration *NSConfiguration) FromEnv() *NSConfiguration {
 		configuration.EndpointLabels = getEnv(endpointLabelsEnv, "Advertise labels", false)
 	}
 
+	if configuration.EndpointMaxConnections == 0 {
+		if raw := getEnv(endpointMaxConnectionsEnv, "Advertise max connections", false); raw != "" {
+			maxConnections, err := strconv.ParseUint(raw, 10, 32)
+			if err != nil {
+				logrus.Errorf("Invalid %v value %q, endpoint capacity is not limited: %v", endpointMaxConnectionsEnv, raw, err)
+			} else {
+				configuration.EndpointMaxConnections = uint32(maxConnections)
+			}
+		}
+	}
+
+	if configuration.EndpointDrainTimeout == 0 {
+		if raw := getEnv(endpointDrainTimeoutEnv, "Drain timeout", false); raw != "" {
+			drainTimeout, err := time.ParseDuration(raw)
+			if err != nil {
+				logrus.Errorf("Invalid %v value %q, endpoint is not drained before deletion: %v", endpointDrainTimeoutEnv, raw, err)
+			} else {
+				configuration.EndpointDrainTimeout = drainTimeout
+			}
+		}
+	}
+
 	if configuration.ClientLabels == "" {
 		configuration.ClientLabels = getEnv(clientLabelsEnv, "Outgoing labels", false)
 	}
 
+	if !configuration.ClientRefreshLeases {
+		if raw := getEnv(clientRefreshLeasesEnv, "Refresh leases", false); raw != "" {
+			refreshLeases, err := strconv.ParseBool(raw)
+			if err != nil {
+				logrus.Errorf("Invalid %v value %q, connections are not leased: %v", clientRefreshLeasesEnv, raw, err)
+			} else {
+				configuration.ClientRefreshLeases = refreshLeases
+			}
+		}
+	}
+
 	if configuration.NscInterfaceName == "" {
 		configuration.NscInterfaceName = getEnv(nscInterfaceNameEnv, "NSC Interface name", false)
 	}
@@ -0,0 +1,81 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/utils"
+)
+
+const (
+	// LeaseGracePeriodEnv - time an expired connection lease is kept before the connection is closed
+	LeaseGracePeriodEnv = utils.EnvVar("NSM_LEASE_GRACE_PERIOD")
+	// DefaultLeaseGracePeriod - default value of LeaseGracePeriodEnv
+	DefaultLeaseGracePeriod = time.Minute
+	// LeaseRefreshInterval - interval the connection leases are refreshed and checked with
+	LeaseRefreshInterval = PathTokenLifetime / 3
+)
+
+// LeaseGracePeriod - returns grace period of the expired connection leases
+func LeaseGracePeriod() time.Duration {
+	return LeaseGracePeriodEnv.GetOrDefaultDuration(DefaultLeaseGracePeriod)
+}
+
+// LeaseExpires - returns expiration time of the current path segment, false if the connection has no lease
+func LeaseExpires(path *connection.Path) (time.Time, bool) {
+	segments := path.GetPathSegments()
+	index := int(path.GetIndex())
+	if index >= len(segments) || segments[index].GetExpires() == nil {
+		return time.Time{}, false
+	}
+	expires, err := ptypes.Timestamp(segments[index].GetExpires())
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expires, true
+}
+
+// IsLeaseExpired - returns true if lease of the connection is expired for more than grace period
+func IsLeaseExpired(path *connection.Path, gracePeriod time.Duration) bool {
+	expires, ok := LeaseExpires(path)
+	return ok && time.Now().After(expires.Add(gracePeriod))
+}
+
+// IsLeaseRefresh - returns true if the request only refreshes the lease of the established connection: it has the
+// lease and requests the same network service with the same context and mechanism
+func IsLeaseRefresh(request *networkservice.NetworkServiceRequest, established *connection.Connection) bool {
+	conn := request.GetConnection()
+	if _, ok := LeaseExpires(conn.GetPath()); !ok || established == nil {
+		return false
+	}
+	if conn.GetNetworkService() != established.GetNetworkService() ||
+		!proto.Equal(conn.GetContext(), established.GetContext()) {
+		return false
+	}
+	for _, m := range request.GetMechanismPreferences() {
+		if proto.Equal(m, established.GetMechanism()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connectioncontext"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+)
+
+func newLeaseRequest(t *testing.T, established *connection.Connection) *networkservice.NetworkServiceRequest {
+	t.Helper()
+	conn := established.Clone()
+	conn.Path = &connection.Path{
+		PathSegments: []*connection.PathSegment{{Name: "nsc"}},
+	}
+	if err := LeasePath(conn.GetPath()); err != nil {
+		t.Fatalf("LeasePath() failed: %v", err)
+	}
+	return &networkservice.NetworkServiceRequest{
+		Connection:           conn,
+		MechanismPreferences: []*connection.Mechanism{established.GetMechanism().Clone()},
+	}
+}
+
+func TestIsLeaseRefresh(t *testing.T) {
+	established := &connection.Connection{
+		Id:             "1",
+		NetworkService: "icmp-responder",
+		Mechanism: &connection.Mechanism{
+			Type:       "KERNEL_INTERFACE",
+			Parameters: map[string]string{"name": "nsm0"},
+		},
+		Context: &connectioncontext.ConnectionContext{
+			IpContext: &connectioncontext.IPContext{SrcIpAddr: "10.0.0.1/30", DstIpAddr: "10.0.0.2/30"},
+		},
+	}
+
+	if request := newLeaseRequest(t, established); !IsLeaseRefresh(request, established) {
+		t.Error("IsLeaseRefresh() should be true for the same connection")
+	}
+
+	request := newLeaseRequest(t, established)
+	request.GetConnection().GetPath().GetPathSegments()[0].Expires = nil
+	if IsLeaseRefresh(request, established) {
+		t.Error("IsLeaseRefresh() should be false for request without lease")
+	}
+
+	request = newLeaseRequest(t, established)
+	request.GetConnection().GetContext().GetIpContext().SrcIpAddr = "10.0.0.5/30"
+	if IsLeaseRefresh(request, established) {
+		t.Error("IsLeaseRefresh() should be false for changed context")
+	}
+
+	request = newLeaseRequest(t, established)
+	request.GetMechanismPreferences()[0].GetParameters()["name"] = "nsm1"
+	if IsLeaseRefresh(request, established) {
+		t.Error("IsLeaseRefresh() should be false for changed mechanism")
+	}
+}
+
+func TestIsLeaseExpired(t *testing.T) {
+	path := &connection.Path{
+		PathSegments: []*connection.PathSegment{{Name: "nsc"}},
+	}
+	if IsLeaseExpired(path, 0) {
+		t.Error("IsLeaseExpired() should be false for path without lease")
+	}
+
+	var err error
+	if path.GetPathSegments()[0].Expires, err = ptypes.TimestampProto(time.Now().Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if !IsLeaseExpired(path, 0) {
+		t.Error("IsLeaseExpired() should be true for expired lease")
+	}
+	if IsLeaseExpired(path, time.Hour) {
+		t.Error("IsLeaseExpired() should be false during grace period")
+	}
+}
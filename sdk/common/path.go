@@ -0,0 +1,171 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
+)
+
+// PathTokenLifetime - lifetime of the path segment tokens and connection leases, a token is only checked by the next
+// hop on Request
+const PathTokenLifetime = 15 * time.Minute
+
+// LeasePath - sets expiration of the current path segment to PathTokenLifetime from now, it is the lease of the
+// requested connection. The lease should only be set if the requester refreshes it until the connection is closed, a
+// connection without lease is never closed as expired
+func LeasePath(path *connection.Path) error {
+	segments := path.GetPathSegments()
+	index := int(path.GetIndex())
+	if index >= len(segments) {
+		return errors.Errorf("path index %d is out of %d path segments", index, len(segments))
+	}
+
+	expires, err := ptypes.TimestampProto(time.Now().Add(PathTokenLifetime))
+	if err != nil {
+		return err
+	}
+	segments[index].Expires = expires
+	return nil
+}
+
+// SignPath - signs the current segment of the connection path with the X.509 SVID of the provider. The token subject
+// is the SPIFFE ID of the SVID, the token is issued for the connection and the audience, the name of the next hop the
+// request is sent to, is chained to the token of the previous segment and expires in PathTokenLifetime. The segment is
+// not signed if provider is nil, e.g. in insecure mode
+func SignPath(ctx context.Context, provider security.Provider, conn *connection.Connection, audience string) error {
+	if provider == nil {
+		return nil
+	}
+	segments := conn.GetPath().GetPathSegments()
+	index := int(conn.GetPath().GetIndex())
+	if index >= len(segments) {
+		return errors.Errorf("path index %d is out of %d path segments", index, len(segments))
+	}
+
+	previous := ""
+	if index > 0 {
+		previous = security.TokenHash(segments[index-1].GetToken())
+	}
+	token, err := security.SignToken(ctx, provider, &security.PathClaims{
+		Name:         segments[index].GetName(),
+		ID:           segments[index].GetId(),
+		ConnectionID: conn.GetId(),
+		Audience:     audience,
+		Previous:     previous,
+		ExpiresAt:    time.Now().Add(PathTokenLifetime).Unix(),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to sign path segment %s", segments[index].GetName())
+	}
+	segments[index].Token = token
+	return nil
+}
+
+// VerifyPath - verifies tokens of the connection path segments up to the current one: each token should be signed by
+// the trusted X.509 SVID, be issued for the SPIFFE ID of the SVID, match its segment and be chained to the previous
+// token. Each previous token should be issued for the next segment, it may be expired: it has been verified by the
+// previous hop, and it is sent again on heal. The current token should not be expired and should be issued for the
+// connection and one of the audiences, the names of the verifier. Only the token of the originating client may have no
+// audience. Does nothing if provider is nil
+func VerifyPath(ctx context.Context, provider security.Provider, conn *connection.Connection, audiences ...string) error {
+	if provider == nil {
+		return nil
+	}
+	segments := conn.GetPath().GetPathSegments()
+	index := int(conn.GetPath().GetIndex())
+	if index >= len(segments) {
+		return errors.Errorf("path index %d is out of %d path segments", index, len(segments))
+	}
+
+	previous := ""
+	for i, segment := range segments[:index+1] {
+		if segment.GetToken() == "" {
+			return errors.Errorf("path segment %d (%s) is not signed", i, segment.GetName())
+		}
+		verifyToken := security.VerifyToken
+		if i < index {
+			verifyToken = security.VerifyTokenSignature
+		}
+		claims, err := verifyToken(ctx, provider, segment.GetToken())
+		if err != nil {
+			return errors.Wrapf(err, "path segment %d (%s) token is not valid", i, segment.GetName())
+		}
+		if claims.Subject != claims.Issuer {
+			return errors.Errorf("path segment %d (%s) token is issued for %s by %s", i, segment.GetName(), claims.Subject, claims.Issuer)
+		}
+		if claims.Name != segment.GetName() || claims.ID != segment.GetId() {
+			return errors.Errorf("path segment %d (%s) token is issued for segment %s", i, segment.GetName(), claims.Name)
+		}
+		if claims.Previous != previous {
+			return errors.Errorf("path segment %d (%s) token is not chained to the previous one", i, segment.GetName())
+		}
+		if i == index && claims.ConnectionID != conn.GetId() {
+			return errors.Errorf("path segment %d (%s) token is issued for connection %s", i, segment.GetName(), claims.ConnectionID)
+		}
+		expected := audiences
+		if i < index {
+			expected = []string{segments[i+1].GetName()}
+		}
+		if !isAudience(claims.Audience, i, expected) {
+			return errors.Errorf("path segment %d (%s) token is issued for %q", i, segment.GetName(), claims.Audience)
+		}
+		previous = security.TokenHash(segment.GetToken())
+	}
+	return nil
+}
+
+// VerifyPathPeer - verifies that the current path segment is signed by the mTLS peer sent the request, so a captured
+// path can't be replayed by another workload. Should be called for the path verified by VerifyPath
+func VerifyPathPeer(ctx context.Context, path *connection.Path) error {
+	peerSpiffeID := security.PeerSpiffeID(ctx)
+	if peerSpiffeID == "" {
+		return errors.New("request is not sent over mTLS with X.509 SVID")
+	}
+	segments := path.GetPathSegments()
+	index := int(path.GetIndex())
+	if index >= len(segments) {
+		return errors.Errorf("path index %d is out of %d path segments", index, len(segments))
+	}
+	claims, err := security.ParseToken(segments[index].GetToken())
+	if err != nil {
+		return errors.Wrapf(err, "path segment %d (%s) token is not valid", index, segments[index].GetName())
+	}
+	if claims.Subject != peerSpiffeID {
+		return errors.Errorf("path segment %d (%s) is signed by %s, but the request is sent by %s", index, segments[index].GetName(), claims.Subject, peerSpiffeID)
+	}
+	return nil
+}
+
+func isAudience(audience string, segmentIndex int, expected []string) bool {
+	if audience == "" {
+		// Client originating the connection doesn't know the name of its NSMgr
+		return segmentIndex == 0
+	}
+	for _, name := range expected {
+		if audience == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/pkg/security"
+)
+
+const trustDomain = "example.org"
+
+type testProvider struct {
+	cert  *tls.Certificate
+	leaf  *x509.Certificate
+	roots map[string]*x509.CertPool
+}
+
+func (p *testProvider) GetTLSConfig(context.Context) (*tls.Config, error) {
+	return &tls.Config{Certificates: []tls.Certificate{*p.cert}}, nil
+}
+
+func (p *testProvider) GetCertificate(context.Context) (*tls.Certificate, error) {
+	return p.cert, nil
+}
+
+func (p *testProvider) GetRoots(context.Context) (map[string]*x509.CertPool, error) {
+	return p.roots, nil
+}
+
+// newTestProviders returns providers of X.509 SVIDs with the ids issued by the same CA
+func newTestProviders(g *WithT, ids ...string) map[string]*testProvider {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).To(BeNil())
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: trustDomain},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	g.Expect(err).To(BeNil())
+	ca, err := x509.ParseCertificate(caDer)
+	g.Expect(err).To(BeNil())
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	providers := map[string]*testProvider{}
+	for i, id := range ids {
+		providers[id] = newTestProvider(g, ca, caKey, int64(i+2), id)
+		providers[id].roots = map[string]*x509.CertPool{"spiffe://" + trustDomain: pool}
+	}
+	return providers
+}
+
+func newTestProvider(g *WithT, ca *x509.Certificate, caKey *ecdsa.PrivateKey, serial int64, id string) *testProvider {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).To(BeNil())
+	uri, err := url.Parse("spiffe://" + trustDomain + "/" + id)
+	g.Expect(err).To(BeNil())
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		URIs:         []*url.URL{uri},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	g.Expect(err).To(BeNil())
+	leaf, err := x509.ParseCertificate(der)
+	g.Expect(err).To(BeNil())
+	return &testProvider{
+		cert: &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+		leaf: leaf,
+	}
+}
+
+func peerContext(p *testProvider) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{p.leaf}},
+		},
+	})
+}
+
+// remoteRequestConnection returns connection requested by nsmgr-1 from nsmgr-2 for the nsc client
+func remoteRequestConnection(g *WithT, providers map[string]*testProvider) *connection.Connection {
+	conn := &connection.Connection{
+		Path: &connection.Path{PathSegments: []*connection.PathSegment{{Name: "nsc"}}},
+	}
+	g.Expect(SignPath(context.Background(), providers["nsc"], conn, "")).To(Succeed())
+
+	conn = &connection.Connection{
+		Id: "remote-1",
+		Path: &connection.Path{
+			Index:        1,
+			PathSegments: append(conn.GetPath().GetPathSegments(), &connection.PathSegment{Name: "nsmgr-1"}, &connection.PathSegment{Name: "nsmgr-2"}),
+		},
+	}
+	g.Expect(SignPath(context.Background(), providers["nsmgr-1"], conn, "nsmgr-2")).To(Succeed())
+	return conn
+}
+
+func TestVerifyPath(t *testing.T) {
+	g := NewWithT(t)
+	providers := newTestProviders(g, "nsc", "nsmgr-1", "nsmgr-2")
+	verifier := providers["nsmgr-2"]
+
+	conn := remoteRequestConnection(g, providers)
+	g.Expect(VerifyPath(context.Background(), verifier, conn, "nsmgr-2")).To(Succeed())
+
+	// Token is issued for another hop
+	g.Expect(VerifyPath(context.Background(), verifier, conn, "nsmgr-3")).NotTo(Succeed())
+
+	// Token is issued for another connection
+	conn.Id = "remote-2"
+	g.Expect(VerifyPath(context.Background(), verifier, conn, "nsmgr-2")).NotTo(Succeed())
+
+	// Only the client may not know the next hop
+	conn = remoteRequestConnection(g, providers)
+	g.Expect(SignPath(context.Background(), providers["nsmgr-1"], conn, "")).To(Succeed())
+	g.Expect(VerifyPath(context.Background(), verifier, conn, "nsmgr-2")).NotTo(Succeed())
+}
+
+func TestVerifyPathHeal(t *testing.T) {
+	g := NewWithT(t)
+	providers := newTestProviders(g, "nsc", "nsmgr-1", "nsmgr-2")
+	verifier := providers["nsmgr-2"]
+
+	conn := remoteRequestConnection(g, providers)
+
+	// Client has signed the request longer than PathTokenLifetime ago, the heal sends it again
+	clientToken, err := security.SignToken(context.Background(), providers["nsc"], &security.PathClaims{
+		Name:      "nsc",
+		ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+	})
+	g.Expect(err).To(BeNil())
+	conn.GetPath().GetPathSegments()[0].Token = clientToken
+
+	// NSMgr signs its segment again on heal
+	g.Expect(SignPath(context.Background(), providers["nsmgr-1"], conn, "nsmgr-2")).To(Succeed())
+	g.Expect(VerifyPath(context.Background(), verifier, conn, "nsmgr-2")).To(Succeed())
+
+	// Token of the current segment should not be expired
+	nsmgrToken, err := security.SignToken(context.Background(), providers["nsmgr-1"], &security.PathClaims{
+		Name:         "nsmgr-1",
+		ConnectionID: conn.GetId(),
+		Audience:     "nsmgr-2",
+		Previous:     security.TokenHash(clientToken),
+		ExpiresAt:    time.Now().Add(-time.Minute).Unix(),
+	})
+	g.Expect(err).To(BeNil())
+	conn.GetPath().GetPathSegments()[1].Token = nsmgrToken
+	g.Expect(VerifyPath(context.Background(), verifier, conn, "nsmgr-2")).NotTo(Succeed())
+}
+
+func TestVerifyPathPeer(t *testing.T) {
+	g := NewWithT(t)
+	providers := newTestProviders(g, "nsc", "nsmgr-1", "nsmgr-2", "attacker")
+
+	conn := remoteRequestConnection(g, providers)
+	g.Expect(VerifyPathPeer(peerContext(providers["nsmgr-1"]), conn.GetPath())).To(Succeed())
+
+	// Captured path is replayed by another workload
+	g.Expect(VerifyPathPeer(peerContext(providers["attacker"]), conn.GetPath())).NotTo(Succeed())
+
+	// Request is not sent over mTLS
+	g.Expect(VerifyPathPeer(context.Background(), conn.GetPath())).NotTo(Succeed())
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/registry"
+	"github.com/networkservicemesh/networkservicemesh/pkg/tools/spanhelper"
+)
+
+const drainCheckInterval = time.Second
+
+// activeConnections tracks the incoming connections, so the draining endpoint knows when they all are moved
+type activeConnections struct {
+	sync.Mutex
+	ids map[string]bool
+}
+
+func (c *activeConnections) add(connectionID string) {
+	c.Lock()
+	defer c.Unlock()
+	if c.ids == nil {
+		c.ids = make(map[string]bool)
+	}
+	c.ids[connectionID] = true
+}
+
+func (c *activeConnections) remove(connectionID string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.ids, connectionID)
+}
+
+func (c *activeConnections) count() int {
+	c.Lock()
+	defer c.Unlock()
+	return len(c.ids)
+}
+
+// Drain asks NSMgr to stop selecting the endpoint for the new connections and to move its connections to other
+// endpoints, then waits until all the connections are closed or ctx is done
+func (nsme *nsmEndpoint) Drain(ctx context.Context) error {
+	span := spanhelper.FromContext(ctx, "Endpoint.Drain")
+	defer span.Finish()
+	logger := span.Logger()
+
+	for i := range nsme.registrations {
+		drainNSE := &registry.DrainNSERequest{
+			NetworkServiceEndpointName: nsme.registrations[i].registeredName,
+		}
+		span.LogObject("drain-request", drainNSE)
+		if _, err := nsme.registryClient.DrainNSE(span.Context(), drainNSE); err != nil {
+			err = errors.Wrapf(err, "failed to drain NSE %v", drainNSE.GetNetworkServiceEndpointName())
+			span.LogError(err)
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+	for {
+		count := nsme.connections.count()
+		if count == 0 {
+			logger.Infof("NSE is drained")
+			return nil
+		}
+		logger.Infof("NSE is draining, %d connections are not moved yet", count)
+		select {
+		case <-ctx.Done():
+			err := errors.Wrapf(ctx.Err(), "NSE is not drained, %d connections are not moved", count)
+			span.LogError(err)
+			return err
+		case <-ticker.C:
+		}
+	}
+}
@@ -42,6 +42,8 @@ import (
 // NsmEndpoint  provides the grpc mechanics for an NsmEndpoint
 type NsmEndpoint interface {
 	Start() error
+	// Drain moves connections of the endpoint to other endpoints of its network services
+	Drain(ctx context.Context) error
 	Delete() error
 }
 
@@ -50,6 +52,8 @@ type NsmEndpoint interface {
 type Registration struct {
 	Name   string
 	Labels map[string]string
+	// MaxConnections is a maximum number of connections the endpoint can serve, 0 means unlimited
+	MaxConnections uint32
 }
 
 // Option is an option that can be given to a NsmEndpoint on construction.
@@ -62,6 +66,9 @@ type nsmEndpoint struct {
 	registryClient registry.NetworkServiceRegistryClient
 	registrations  []registration
 	tracerCloser   io.Closer
+	leases         connectionLeases
+	cancelLeases   context.CancelFunc
+	connections    activeConnections
 }
 
 type registration struct {
@@ -115,6 +122,10 @@ func (nsme *nsmEndpoint) Start() error {
 	// spawn the listening thread
 	nsme.serve(listener)
 
+	leasesCtx, cancelLeases := context.WithCancel(nsme.Context)
+	nsme.cancelLeases = cancelLeases
+	go nsme.closeExpiredConnections(leasesCtx, common.LeaseRefreshInterval, common.LeaseGracePeriod())
+
 	nsme.registryClient = registry.NewNetworkServiceRegistryClient(nsme.GrpcClient)
 	for i := range nsme.registrations {
 		nsme.register(&nsme.registrations[i])
@@ -134,6 +145,7 @@ func (nsme *nsmEndpoint) register(r *registration) {
 		NetworkServiceName: r.Name,
 		Payload:            "IP",
 		Labels:             r.Labels,
+		MaxConnections:     r.MaxConnections,
 	}
 	registration := &registry.NSERegistration{
 		NetworkService: &registry.NetworkService{
@@ -169,7 +181,24 @@ func (nsme *nsmEndpoint) unregister(r *registration) error {
 	return err
 }
 
+// registeredNames returns names the endpoint is registered with, NSMgr signs the requests for one of them
+func (nsme *nsmEndpoint) registeredNames() []string {
+	names := make([]string, 0, len(nsme.registrations))
+	for i := range nsme.registrations {
+		names = append(names, nsme.registrations[i].registeredName)
+	}
+	return names
+}
+
 func (nsme *nsmEndpoint) Delete() error {
+	if drainTimeout := nsme.Configuration.EndpointDrainTimeout; drainTimeout > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		if err := nsme.Drain(ctx); err != nil {
+			logrus.Errorf("nse: failed to drain endpoint: %v", err)
+		}
+		cancel()
+	}
+
 	var result error
 	for i := range nsme.registrations {
 		err := nsme.unregister(&nsme.registrations[i])
@@ -178,6 +207,7 @@ func (nsme *nsmEndpoint) Delete() error {
 		}
 	}
 	nsme.grpcServer.Stop()
+	nsme.cancelLeases()
 	_ = nsme.tracerCloser.Close()
 
 	return result
@@ -191,11 +221,23 @@ func (nsme *nsmEndpoint) Request(ctx context.Context, request *networkservice.Ne
 	logger := span.Logger()
 	logger.Infof("Request for Network Service received %v", request)
 
+	if err := common.VerifyPath(ctx, tools.GetConfig().SecurityProvider, request.GetConnection(), nsme.registeredNames()...); err != nil {
+		logger.Errorf("The request path is not trusted: %v", err)
+		return nil, err
+	}
+
+	if conn := nsme.leases.refresh(request); conn != nil {
+		logger.Infof("Lease of connection %v is refreshed", conn.GetId())
+		return conn, nil
+	}
+
 	incomingConnection, err := nsme.service.Request(ctx, request)
 	if err != nil {
 		logger.Errorf("The composite returned an error: %v", err)
 		return nil, err
 	}
+	nsme.leases.add(incomingConnection, request.GetConnection().GetPath())
+	nsme.connections.add(incomingConnection.GetId())
 
 	logger.Infof("Responding to NetworkService.Request(%v): %v", request, incomingConnection)
 	span.LogObject("response", incomingConnection)
@@ -206,6 +248,8 @@ func (nsme *nsmEndpoint) Close(ctx context.Context, incomingConnection *connecti
 	span := spanhelper.FromContext(ctx, "Endpoint.Close")
 	defer span.Finish()
 	span.LogObject("connection", incomingConnection)
+	nsme.leases.remove(incomingConnection.GetId())
+	nsme.connections.remove(incomingConnection.GetId())
 	_, _ = nsme.service.Close(ctx, incomingConnection)
 	_, _ = nsme.NsClient.Close(ctx, incomingConnection)
 
@@ -261,7 +305,8 @@ func WithRegistrations(registrations ...Registration) Option {
 // MakeRegistration extracts Registration from the configuration.
 func MakeRegistration(configuration *common.NSConfiguration) Registration {
 	return Registration{
-		Name:   configuration.EndpointNetworkService,
-		Labels: tools.ParseKVStringToMap(configuration.EndpointLabels, ",", "="),
+		Name:           configuration.EndpointNetworkService,
+		Labels:         tools.ParseKVStringToMap(configuration.EndpointLabels, ",", "="),
+		MaxConnections: configuration.EndpointMaxConnections,
 	}
 }
@@ -0,0 +1,105 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/sdk/common"
+)
+
+// connectionLeases tracks leases of the incoming connections
+type connectionLeases struct {
+	sync.Mutex
+	leases map[string]*connectionLease
+}
+
+type connectionLease struct {
+	connection *connection.Connection
+	path       *connection.Path
+}
+
+// add starts tracking lease of the connection if the request has it
+func (l *connectionLeases) add(conn *connection.Connection, path *connection.Path) {
+	if _, ok := common.LeaseExpires(path); !ok {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	if l.leases == nil {
+		l.leases = make(map[string]*connectionLease)
+	}
+	l.leases[conn.GetId()] = &connectionLease{
+		connection: conn,
+		path:       path,
+	}
+}
+
+// refresh returns the established connection if the request only refreshes its lease
+func (l *connectionLeases) refresh(request *networkservice.NetworkServiceRequest) *connection.Connection {
+	l.Lock()
+	defer l.Unlock()
+	lease, ok := l.leases[request.GetConnection().GetId()]
+	if !ok || !common.IsLeaseRefresh(request, lease.connection) {
+		return nil
+	}
+	lease.path = request.GetConnection().GetPath()
+	return lease.connection
+}
+
+func (l *connectionLeases) remove(connectionID string) {
+	l.Lock()
+	defer l.Unlock()
+	delete(l.leases, connectionID)
+}
+
+// expired removes and returns connections with leases expired for more than grace period
+func (l *connectionLeases) expired(gracePeriod time.Duration) []*connection.Connection {
+	l.Lock()
+	defer l.Unlock()
+	var result []*connection.Connection
+	for id, lease := range l.leases {
+		if common.IsLeaseExpired(lease.path, gracePeriod) {
+			result = append(result, lease.connection)
+			delete(l.leases, id)
+		}
+	}
+	return result
+}
+
+// closeExpiredConnections periodically closes connections with expired leases, e.g. if NSMgr died without closing them
+func (nsme *nsmEndpoint) closeExpiredConnections(ctx context.Context, interval, gracePeriod time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, conn := range nsme.leases.expired(gracePeriod) {
+				logrus.Infof("Lease of connection %v is expired, closing it", conn.GetId())
+				_, _ = nsme.Close(ctx, conn)
+			}
+		}
+	}
+}
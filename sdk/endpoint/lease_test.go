@@ -0,0 +1,107 @@
+// Copyright (c) 2020 Cisco Systems, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/connection"
+	"github.com/networkservicemesh/networkservicemesh/controlplane/api/networkservice"
+	"github.com/networkservicemesh/networkservicemesh/sdk/common"
+)
+
+type closeRecorder struct {
+	sync.Mutex
+	closed []string
+}
+
+func (r *closeRecorder) Request(_ context.Context, request *networkservice.NetworkServiceRequest) (*connection.Connection, error) {
+	return request.GetConnection(), nil
+}
+
+func (r *closeRecorder) Close(_ context.Context, conn *connection.Connection) (*empty.Empty, error) {
+	r.Lock()
+	defer r.Unlock()
+	r.closed = append(r.closed, conn.GetId())
+	return &empty.Empty{}, nil
+}
+
+func (r *closeRecorder) closedIDs() []string {
+	r.Lock()
+	defer r.Unlock()
+	return append([]string(nil), r.closed...)
+}
+
+type nsmClientStub struct {
+	networkservice.NetworkServiceClient
+}
+
+func (c *nsmClientStub) Close(context.Context, *connection.Connection, ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, nil
+}
+
+func leaseRequest(t *testing.T, id string, expires time.Time) *networkservice.NetworkServiceRequest {
+	t.Helper()
+	path := &connection.Path{PathSegments: []*connection.PathSegment{{Name: "nsc"}, {Name: "nsmgr"}}, Index: 1}
+	if !expires.IsZero() {
+		expiresProto, err := ptypes.TimestampProto(expires)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path.GetPathSegments()[1].Expires = expiresProto
+	}
+	return &networkservice.NetworkServiceRequest{
+		Connection: &connection.Connection{Id: id, NetworkService: "ns", Path: path},
+	}
+}
+
+func TestCloseExpiredConnections(t *testing.T) {
+	g := gomega.NewWithT(t)
+	g.Expect(os.Setenv("INSECURE", "true")).To(gomega.Succeed())
+
+	service := &closeRecorder{}
+	nsme := &nsmEndpoint{
+		NsmConnection: &common.NsmConnection{NsClient: &nsmClientStub{}},
+		service:       service,
+	}
+
+	for _, request := range []*networkservice.NetworkServiceRequest{
+		leaseRequest(t, "expired", time.Now().Add(-time.Minute)),
+		leaseRequest(t, "leased", time.Now().Add(time.Hour)),
+		leaseRequest(t, "unleased", time.Time{}),
+	} {
+		_, err := nsme.Request(context.Background(), request)
+		g.Expect(err).To(gomega.BeNil())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go nsme.closeExpiredConnections(ctx, 10*time.Millisecond, 0)
+
+	g.Eventually(service.closedIDs).Should(gomega.Equal([]string{"expired"}))
+	g.Consistently(service.closedIDs, 100*time.Millisecond).Should(gomega.Equal([]string{"expired"}))
+	g.Expect(nsme.connections.count()).To(gomega.Equal(2))
+}
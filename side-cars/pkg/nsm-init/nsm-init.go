@@ -42,6 +42,8 @@ func (c *nsmClientApp) Run() {
 	defer span.Finish()
 
 	c.configuration = c.configuration.FromEnv()
+	// nsm-init exits after connecting, nobody would refresh the leases and the connections would be closed as expired
+	c.configuration.ClientRefreshLeases = false
 	if c.configuration.PodName == "" {
 		podName, err := tools.GetCurrentPodNameFromHostname()
 		if err != nil {